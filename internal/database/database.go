@@ -1,18 +1,33 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	models "github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/storage"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
+// defaultDeletedRetention is used when DELETED_CONTENT_RETENTION_DAYS is not set
+const defaultDeletedRetention = 30 * 24 * time.Hour
+
+// DeletedRetention returns how long soft-deleted content can be restored before it is purged,
+// configurable with DELETED_CONTENT_RETENTION_DAYS
+func DeletedRetention() time.Duration {
+	if days, err := strconv.Atoi(os.Getenv("DELETED_CONTENT_RETENTION_DAYS")); err == nil && days > 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return defaultDeletedRetention
+}
+
 func InitDB() {
 
 	// ✅ Read values from environment variables
@@ -31,15 +46,34 @@ func InitDB() {
 
 	fmt.Println("Database connection successful")
 
+	// Polls arrive with the polls table, so its absence marks a database from before them
+	hadPolls := DB.Migrator().HasTable(&models.Poll{})
+
 	// AutoMigrate ensures tables are created automatically
-	err = DB.AutoMigrate(&models.User{}, &models.Post{}, &models.Comment{}, &models.Vote{}, &models.SubInvitation{}, &models.Sub{}, &models.SubMembership{}, &models.PasswordResetToken{})
+	err = DB.AutoMigrate(&models.User{}, &models.Post{}, &models.Comment{}, &models.Vote{}, &models.SubInvitation{}, &models.Sub{}, &models.SubMembership{}, &models.PasswordResetToken{}, &models.SubRule{}, &models.SubApprovedSubmitter{}, &models.SubInviteLink{}, &models.SubModerator{}, &models.SubJoinRequest{}, &models.Notification{}, &models.SubOwnershipTransfer{}, &models.SubFlair{}, &models.UserFollow{}, &models.UserBlock{}, &models.Conversation{}, &models.ConversationParticipant{}, &models.Message{}, &models.NotificationPreference{}, &models.Mention{}, &models.Revision{}, &models.SavedItem{}, &models.HiddenPost{}, &models.Media{}, &models.MediaRendition{}, &models.LinkPreview{}, &models.Poll{}, &models.PollOption{}, &models.PollVote{})
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	} else {
 		log.Println("Database migrated successfully!")
 	}
 
+	// Sub names are unique regardless of case
+	if err := DB.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_subs_name_lower ON subs (LOWER(name))").Error; err != nil {
+		log.Println("Failed to create case-insensitive sub name index:", err)
+	}
+
+	// Subs created before polls existed kept the old default post types; allow polls there too, once, so a sub
+	// that later drops polls keeps its choice
+	if !hadPolls {
+		if err := DB.Exec("UPDATE subs SET allowed_post_types = ? WHERE allowed_post_types = ?", "text,image,link,poll", "text,image,link").Error; err != nil {
+			log.Println("Failed to allow polls in existing subs:", err)
+		}
+	}
+
 	go DeleteExpiredTokens()
+	go ExpireSubInvitations()
+	go ArchiveOldPosts()
+	go PurgeDeletedContent()
 }
 
 // DeleteExpiredTokens removes tokens that are past expiration
@@ -54,3 +88,181 @@ func DeleteExpiredTokens() {
 		}
 	}
 }
+
+// ExpireSubInvitations marks pending invitations that are past their expiry as expired
+func ExpireSubInvitations() {
+	for {
+		time.Sleep(1 * time.Hour) // Runs every hour
+		result := DB.Model(&models.SubInvitation{}).
+			Where("status = ? AND expires_at IS NOT NULL AND expires_at < ?", models.InviteStatusPending, time.Now()).
+			Update("status", models.InviteStatusExpired)
+		if result.Error != nil {
+			log.Println("Error expiring sub invitations:", result.Error)
+		} else if result.RowsAffected > 0 {
+			log.Println("Expired", result.RowsAffected, "sub invitations.")
+		}
+	}
+}
+
+// ArchiveOldPosts archives posts older than their sub's archive age
+func ArchiveOldPosts() {
+	for {
+		time.Sleep(1 * time.Hour) // Runs every hour
+		result := DB.Exec(`UPDATE posts SET archived = true FROM subs
+			WHERE posts.sub_id = subs.id AND posts.archived = false AND posts.status = 'published' AND subs.archive_after_days > 0
+			AND posts.created_at < NOW() - subs.archive_after_days * INTERVAL '1 day'`)
+		if result.Error != nil {
+			log.Println("Error archiving old posts:", result.Error)
+		} else if result.RowsAffected > 0 {
+			log.Println("Archived", result.RowsAffected, "old posts.")
+		}
+	}
+}
+
+// PurgeDeletedContent permanently removes soft-deleted content once it is past the retention period
+func PurgeDeletedContent() {
+	store, err := storage.Default()
+	if err != nil {
+		log.Println("Purging deleted content without its uploads:", err)
+		store = nil
+	}
+	for {
+		time.Sleep(1 * time.Hour) // Runs every hour
+		if err := PurgeDeletedBefore(time.Now().Add(-DeletedRetention()), store); err != nil {
+			log.Println("Error purging deleted content:", err)
+		}
+	}
+}
+
+// PurgeDeletedBefore removes subs, posts and comments deleted before the cutoff along with everything
+// that depends on them. Comments that still have replies keep their row as an empty tombstone so the
+// thread stays intact, and users are anonymized rather than removed because their content still
+// references them. Uploads nothing references any more are deleted with their files from the store;
+// without a store they are kept.
+func PurgeDeletedBefore(cutoff time.Time, store storage.BlobStore) error {
+	var blobKeys []string
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		purgedSubs := "SELECT id FROM subs WHERE deleted_at < ?"
+		purgedPosts := "SELECT id FROM posts WHERE deleted_at < ? OR sub_id IN (" + purgedSubs + ")"
+		purgedComments := "SELECT id FROM comments WHERE post_id IN (" + purgedPosts + ") OR (deleted_at < ? AND NOT EXISTS (SELECT 1 FROM comments replies WHERE replies.parent_id = comments.id))"
+
+		// Uploads of the purged posts, comments and users, found before the rows pointing at them go
+		var mediaIDs []uint
+		if err := tx.Raw("SELECT media_id FROM posts WHERE media_id IS NOT NULL AND id IN ("+purgedPosts+") "+
+			"UNION SELECT media_id FROM comments WHERE media_id IS NOT NULL AND (deleted_at < ? OR post_id IN ("+purgedPosts+")) "+
+			"UNION SELECT id FROM media WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)",
+			cutoff, cutoff, cutoff, cutoff, cutoff, cutoff).Scan(&mediaIDs).Error; err != nil {
+			return err
+		}
+
+		steps := []struct {
+			query string
+			args  []interface{}
+		}{
+			// Posts and comments, including everything inside purged subs
+			{"DELETE FROM votes WHERE post_id IN (" + purgedPosts + ") OR comment_id IN (" + purgedComments + ")", []interface{}{cutoff, cutoff, cutoff, cutoff, cutoff}},
+			{"DELETE FROM mentions WHERE post_id IN (" + purgedPosts + ") OR comment_id IN (SELECT id FROM comments WHERE deleted_at < ? OR post_id IN (" + purgedPosts + "))", []interface{}{cutoff, cutoff, cutoff, cutoff, cutoff}},
+			{"DELETE FROM revisions WHERE (content_type = 'post' AND content_id IN (" + purgedPosts + ")) OR (content_type = 'comment' AND content_id IN (SELECT id FROM comments WHERE deleted_at < ? OR post_id IN (" + purgedPosts + ")))", []interface{}{cutoff, cutoff, cutoff, cutoff, cutoff}},
+			{"DELETE FROM saved_items WHERE (content_type = 'post' AND content_id IN (" + purgedPosts + ")) OR (content_type = 'comment' AND content_id IN (" + purgedComments + "))", []interface{}{cutoff, cutoff, cutoff, cutoff, cutoff}},
+			{"DELETE FROM hidden_posts WHERE post_id IN (" + purgedPosts + ")", []interface{}{cutoff, cutoff}},
+			{"DELETE FROM poll_votes WHERE poll_id IN (SELECT id FROM polls WHERE post_id IN (" + purgedPosts + "))", []interface{}{cutoff, cutoff}},
+			{"DELETE FROM poll_options WHERE poll_id IN (SELECT id FROM polls WHERE post_id IN (" + purgedPosts + "))", []interface{}{cutoff, cutoff}},
+			{"DELETE FROM polls WHERE post_id IN (" + purgedPosts + ")", []interface{}{cutoff, cutoff}},
+			{"DELETE FROM comments WHERE id IN (" + purgedComments + ")", []interface{}{cutoff, cutoff, cutoff}},
+			{"UPDATE comments SET content = '', image_url = NULL, media_id = NULL WHERE deleted_at < ? AND (content <> '' OR media_id IS NOT NULL)", []interface{}{cutoff}},
+			{"DELETE FROM notifications WHERE post_id IN (" + purgedPosts + ")", []interface{}{cutoff, cutoff}},
+			{"DELETE FROM posts WHERE id IN (" + purgedPosts + ")", []interface{}{cutoff, cutoff}},
+
+			// Subs and their community data
+			{"DELETE FROM sub_flairs WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM sub_rules WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM sub_approved_submitters WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM sub_moderators WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM sub_memberships WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM sub_invitations WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM sub_invite_links WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM sub_join_requests WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM sub_ownership_transfers WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM notifications WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM messages WHERE conversation_id IN (SELECT id FROM conversations WHERE sub_id IN (" + purgedSubs + "))", []interface{}{cutoff}},
+			{"DELETE FROM conversation_participants WHERE conversation_id IN (SELECT id FROM conversations WHERE sub_id IN (" + purgedSubs + "))", []interface{}{cutoff}},
+			{"DELETE FROM conversations WHERE sub_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM mentions WHERE type = 'sub' AND target_id IN (" + purgedSubs + ")", []interface{}{cutoff}},
+			{"DELETE FROM subs WHERE deleted_at < ?", []interface{}{cutoff}},
+
+			// Users keep an anonymized row so their remaining posts and comments render as [deleted]. The new name
+			// can't be registered, but accounts created before that rule may hold it, so a taken name gets a random suffix.
+			{"DELETE FROM votes WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM poll_votes WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM sub_memberships WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM sub_moderators WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM sub_approved_submitters WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM sub_join_requests WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM sub_invitations WHERE invitee_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM password_reset_tokens WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM notifications WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM notification_preferences WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM saved_items WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM hidden_posts WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM user_follows WHERE follower_id IN (SELECT id FROM users WHERE deleted_at < ?) OR following_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff, cutoff}},
+			{"DELETE FROM user_blocks WHERE blocker_id IN (SELECT id FROM users WHERE deleted_at < ?) OR blocked_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff, cutoff}},
+			{"DELETE FROM mentions WHERE type = 'user' AND target_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"DELETE FROM conversation_participants WHERE user_id IN (SELECT id FROM users WHERE deleted_at < ?)", []interface{}{cutoff}},
+			{"UPDATE messages SET body = '' WHERE sender_id IN (SELECT id FROM users WHERE deleted_at < ?) AND body <> ''", []interface{}{cutoff}},
+			{"UPDATE users SET username = CASE WHEN EXISTS (SELECT 1 FROM users taken WHERE taken.username = '" + models.DeletedUsernamePrefix + "' || users.id) " +
+				"THEN '" + models.DeletedUsernamePrefix + "' || id || '_' || substr(md5(random()::text), 1, 8) ELSE '" + models.DeletedUsernamePrefix + "' || id END, password = '', email = NULL, display_name = '', bio = '', avatar_url = NULL, avatar_media_id = NULL, refresh_token = NULL WHERE deleted_at < ? AND password <> ''", []interface{}{cutoff}},
+		}
+
+		for _, step := range steps {
+			if err := tx.Exec(step.query, step.args...).Error; err != nil {
+				return err
+			}
+		}
+
+		if store == nil || len(mediaIDs) == 0 {
+			return nil
+		}
+		keys, err := purgeUnusedMedia(tx, mediaIDs)
+		blobKeys = keys
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Files go once the rows are gone for good; a file left behind is no longer served
+	for _, key := range blobKeys {
+		if err := store.Delete(context.Background(), key); err != nil {
+			log.Printf("Failed to delete purged upload %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// purgeUnusedMedia deletes the uploads among mediaIDs that no remaining post, comment or avatar uses,
+// with their renditions, and returns the storage keys of their files
+func purgeUnusedMedia(tx *gorm.DB, mediaIDs []uint) ([]string, error) {
+	var unused []uint
+	if err := tx.Raw(`SELECT id FROM media WHERE id IN ?
+		AND NOT EXISTS (SELECT 1 FROM posts WHERE posts.media_id = media.id)
+		AND NOT EXISTS (SELECT 1 FROM comments WHERE comments.media_id = media.id)
+		AND NOT EXISTS (SELECT 1 FROM users WHERE users.avatar_media_id = media.id)`, mediaIDs).Scan(&unused).Error; err != nil {
+		return nil, err
+	}
+	if len(unused) == 0 {
+		return nil, nil
+	}
+
+	var keys []string
+	if err := tx.Raw("SELECT storage_key FROM media WHERE id IN ? UNION SELECT storage_key FROM media_renditions WHERE media_id IN ?",
+		unused, unused).Scan(&keys).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Exec("DELETE FROM media_renditions WHERE media_id IN ?", unused).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Exec("DELETE FROM media WHERE id IN ?", unused).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newActivityService() *services.ActivityService {
+	return services.NewActivityService(repositories.NewActivityRepository(), repositories.NewBlockRepository(),
+		repositories.NewFollowRepository(), repositories.NewUserRepository())
+}
+
+// activityErrorStatus maps activity history errors to HTTP status codes
+func activityErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found":
+		return http.StatusNotFound
+	case "this user's activity is private", "you cannot interact with this user", "you can only see your own upvoted posts":
+		return http.StatusForbidden
+	case "failed to fetch posts", "failed to fetch comments", "failed to fetch activity", "failed to check blocks",
+		"failed to fetch follows":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Get a user's posts
+// @Description Lists a user's posts in the subs the viewer can see; logged-out viewers see posts in public subs. A private user's history is only visible to them and their followers. A token is optional
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username"
+// @Param sort query string false "Sort order: new (default) or top"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.UserPostListResponse "One page of posts"
+// @Failure 400 {object} map[string]string "error: Invalid sort"
+// @Failure 403 {object} map[string]string "error: Activity is private"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /user/{username}/posts [get]
+func GetUserPosts(c *gin.Context) {
+	page, limit := parsePagination(c)
+	posts, err := newActivityService().GetPosts(c.Param("username"), c.GetString("username"), c.DefaultQuery("sort", models.ActivitySortNew), page, limit)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
+
+// @Summary Get a user's comments
+// @Description Lists a user's comments on posts in the subs the viewer can see, with each post's title and sub; logged-out viewers see comments in public subs. A private user's history is only visible to them and their followers. A token is optional
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username"
+// @Param sort query string false "Sort order: new (default) or top"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.UserCommentListResponse "One page of comments"
+// @Failure 400 {object} map[string]string "error: Invalid sort"
+// @Failure 403 {object} map[string]string "error: Activity is private"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /user/{username}/comments [get]
+func GetUserComments(c *gin.Context) {
+	page, limit := parsePagination(c)
+	comments, err := newActivityService().GetComments(c.Param("username"), c.GetString("username"), c.DefaultQuery("sort", models.ActivitySortNew), page, limit)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// @Summary Get upvoted posts
+// @Description Lists the posts the authenticated user upvoted, most recently upvoted first unless sorted by score (own history only)
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username"
+// @Param sort query string false "Sort order: new (default) or top"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.UserPostListResponse "One page of upvoted posts"
+// @Failure 400 {object} map[string]string "error: Invalid sort"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only your own upvoted posts can be listed"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /user/{username}/upvoted [get]
+func GetUserUpvoted(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	posts, err := newActivityService().GetUpvoted(c.Param("username"), username.(string), c.DefaultQuery("sort", models.ActivitySortNew), page, limit)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
+
+// @Summary Get a user's overview
+// @Description Lists a user's posts and comments together, with the same visibility rules as the separate lists. A token is optional
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username"
+// @Param sort query string false "Sort order: new (default) or top"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.UserOverviewResponse "One page of posts and comments"
+// @Failure 400 {object} map[string]string "error: Invalid sort"
+// @Failure 403 {object} map[string]string "error: Activity is private"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /user/{username}/overview [get]
+func GetUserOverview(c *gin.Context) {
+	page, limit := parsePagination(c)
+	overview, err := newActivityService().GetOverview(c.Param("username"), c.GetString("username"), c.DefaultQuery("sort", models.ActivitySortNew), page, limit)
+	if err != nil {
+		c.JSON(activityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
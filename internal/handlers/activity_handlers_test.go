@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupActivityTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.GET("/user/:username/posts", GetUserPosts)
+	r.GET("/user/:username/comments", GetUserComments)
+	r.GET("/user/:username/upvoted", GetUserUpvoted)
+	r.GET("/user/:username/overview", GetUserOverview)
+	return r
+}
+
+func TestActivityHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	author := models.User{Username: "activityhandlerauthor", Password: "hashedpass"}
+	viewer := models.User{Username: "activityhandlerviewer", Password: "hashedpass"}
+	database.DB.Where("username = ?", author.Username).FirstOrCreate(&author)
+	database.DB.Where("username = ?", viewer.Username).FirstOrCreate(&viewer)
+	sub := models.Sub{Name: "activityhandlersub", OwnerID: author.ID}
+	database.DB.Create(&sub)
+	post := models.Post{Title: "History", Content: "Text", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+	database.DB.Create(&models.Comment{Content: "Reply", PostID: post.ID, UserID: author.ID})
+
+	router := setupActivityTestRouter(viewer.Username)
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/user/activityhandlerauthor/overview?limit=10")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var overview models.UserOverviewResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &overview))
+	assert.Equal(t, int64(2), overview.Total)
+	assert.Equal(t, 10, overview.Limit)
+
+	assert.Equal(t, http.StatusOK, get("/user/activityhandlerauthor/posts?sort=top").Code)
+	assert.Equal(t, http.StatusOK, get("/user/activityhandlerauthor/comments").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/user/activityhandlerauthor/posts?sort=hot").Code)
+	assert.Equal(t, http.StatusForbidden, get("/user/activityhandlerauthor/upvoted").Code)
+	assert.Equal(t, http.StatusOK, get("/user/activityhandlerviewer/upvoted").Code)
+	assert.Equal(t, http.StatusNotFound, get("/user/nobody-at-all/posts").Code)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newAdminService() *services.AdminService {
+	return services.NewAdminService(repositories.NewDeletedContentRepository(), repositories.NewUserRepository())
+}
+
+// adminErrorStatus maps admin errors to HTTP status codes
+func adminErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "deleted content not found":
+		return http.StatusNotFound
+	case "admin access required":
+		return http.StatusForbidden
+	case "failed to fetch deleted content", "failed to restore content":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary List deleted content
+// @Description Lists deleted posts, comments, subs or users that can still be restored (admins only)
+// @Tags Admin
+// @Produce json
+// @Param type path string true "Content type: post, comment, sub or user"
+// @Success 200 {array} models.DeletedContentResponse "Restorable deleted content"
+// @Failure 400 {object} map[string]string "error: Invalid content type"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Admin access required"
+// @Security BearerAuth
+// @Router /admin/deleted/{type} [get]
+func GetDeletedContent(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	items, err := newAdminService().GetDeletedContent(username.(string), c.Param("type"))
+	if err != nil {
+		c.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// @Summary Restore deleted content
+// @Description Restores a deleted post, comment, sub or user within the retention period (admins only)
+// @Tags Admin
+// @Produce json
+// @Param type path string true "Content type: post, comment, sub or user"
+// @Param id path string true "Content ID"
+// @Success 200 {object} map[string]string "message: Content restored successfully"
+// @Failure 400 {object} map[string]string "error: Invalid content type or ID"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Admin access required"
+// @Failure 404 {object} map[string]string "error: Deleted content not found or past the retention period"
+// @Security BearerAuth
+// @Router /admin/deleted/{type}/{id}/restore [post]
+func RestoreDeletedContent(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newAdminService().RestoreContent(username.(string), c.Param("type"), c.Param("id")); err != nil {
+		c.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Content restored successfully"})
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAdminTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.GET("/admin/deleted/:type", GetDeletedContent)
+	r.POST("/admin/deleted/:type/:id/restore", RestoreDeletedContent)
+	r.DELETE("/posts/:id", DeletePost)
+	return r
+}
+
+func TestAdminHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	// Setup
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+
+	admin := models.User{Username: "adminhandleradmin", Password: "hashedpass", IsAdmin: true}
+	author := models.User{Username: "adminhandlerauthor", Password: "hashedpass"}
+	database.DB.Where("username = ?", admin.Username).FirstOrCreate(&admin)
+	database.DB.Where("username = ?", author.Username).FirstOrCreate(&author)
+
+	sub := models.Sub{Name: "adminhandlersub", OwnerID: admin.ID}
+	database.DB.Create(&sub)
+	post := models.Post{Title: "Post", Content: "Content", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+
+	adminRouter := setupAdminTestRouter(admin.Username)
+	authorRouter := setupAdminTestRouter(author.Username)
+
+	t.Run("author deletes post", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", fmt.Sprintf("/posts/%d", post.ID), nil)
+		w := httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("non-admin cannot list deleted content", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/admin/deleted/post", nil)
+		w := httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("invalid content type", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/admin/deleted/vote", nil)
+		w := httptest.NewRecorder()
+		adminRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("admin lists and restores deleted post", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/admin/deleted/post", nil)
+		w := httptest.NewRecorder()
+		adminRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"summary":"Post"`)
+
+		req, _ = http.NewRequest("POST", fmt.Sprintf("/admin/deleted/post/%d/restore", post.ID), nil)
+		w = httptest.NewRecorder()
+		adminRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("POST", fmt.Sprintf("/admin/deleted/post/%d/restore", post.ID), nil)
+		w = httptest.NewRecorder()
+		adminRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
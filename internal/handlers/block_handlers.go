@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newBlockService() *services.BlockService {
+	return services.NewBlockService(repositories.NewBlockRepository(), repositories.NewUserRepository())
+}
+
+// blockErrorStatus maps block errors to HTTP status codes
+func blockErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found":
+		return http.StatusNotFound
+	case "you already blocked this user":
+		return http.StatusConflict
+	case "failed to block user", "failed to unblock user", "failed to fetch blocked users", "failed to check blocks":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Block a user
+// @Description Blocks a user. Their posts and comments are hidden from you and they can no longer reply to, mention or message you
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username to block"
+// @Success 201 {object} models.BlockResponse "Blocked user"
+// @Failure 400 {object} map[string]string "error: Cannot block yourself"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Failure 409 {object} map[string]string "error: Already blocked"
+// @Security BearerAuth
+// @Router /user/blocks/{username} [post]
+func BlockUser(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	block, err := newBlockService().BlockUser(username.(string), c.Param("username"))
+	if err != nil {
+		c.JSON(blockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, block)
+}
+
+// @Summary Unblock a user
+// @Description Removes a block on a user
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username to unblock"
+// @Success 200 {object} map[string]string "message: Unblocked successfully"
+// @Failure 400 {object} map[string]string "error: User is not blocked"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /user/blocks/{username} [delete]
+func UnblockUser(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newBlockService().UnblockUser(username.(string), c.Param("username")); err != nil {
+		c.JSON(blockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unblocked successfully"})
+}
+
+// @Summary Get blocked users
+// @Description Lists the users the current user has blocked
+// @Tags Users
+// @Produce json
+// @Success 200 {array} models.BlockResponse "Blocked users"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /user/blocks [get]
+func GetBlockedUsers(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	blocks, err := newBlockService().GetBlockedUsers(username.(string))
+	if err != nil {
+		c.JSON(blockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, blocks)
+}
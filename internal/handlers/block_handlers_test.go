@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupBlockTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.GET("/user/blocks", GetBlockedUsers)
+	r.POST("/user/blocks/:username", BlockUser)
+	r.DELETE("/user/blocks/:username", UnblockUser)
+	return r
+}
+
+func TestBlockHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	database.DB.Exec("DELETE FROM user_blocks")
+
+	blocker := models.User{Username: "blockhandlerblocker", Password: "hashedpass"}
+	blocked := models.User{Username: "blockhandlerblocked", Password: "hashedpass"}
+	database.DB.Where("username = ?", blocker.Username).FirstOrCreate(&blocker)
+	database.DB.Where("username = ?", blocked.Username).FirstOrCreate(&blocked)
+
+	router := setupBlockTestRouter(blocker.Username)
+
+	t.Run("block user", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/user/blocks/"+blocked.Username, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		req, _ = http.NewRequest("POST", "/user/blocks/"+blocked.Username, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("list blocked users", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/user/blocks", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), blocked.Username)
+	})
+
+	t.Run("block unknown user", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/user/blocks/nosuchuser", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("unblock user", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/user/blocks/"+blocked.Username, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -88,7 +88,8 @@ func UpdateComment(c *gin.Context) {
 	service := services.NewCommentsService(repositories.NewCommentRepository())
 	updatedComment, err := service.UpdateComment(uint(commentID), username.(string), commentReq)
 	if err != nil {
-		if err.Error() == "unauthorized: can only edit own comments" {
+		if err.Error() == "unauthorized: can only edit own comments" || err.Error() == "you can only use media you uploaded" ||
+			err.Error() == "this post is archived" {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newCrosspostService() *services.CrosspostService {
+	return services.NewCrosspostService(repositories.NewCrosspostRepository(), repositories.NewPollRepository(),
+		repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+}
+
+// crosspostErrorStatus maps crosspost errors to HTTP status codes
+func crosspostErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "post not found", "sub not found":
+		return http.StatusNotFound
+	case "posts from private subs can't be crossposted", "you cannot interact with this user",
+		"this post type is not allowed in this sub", "your account is too new to post in this sub",
+		"you don't have enough karma to post in this sub",
+		"only approved submitters can post in this sub", "only moderators can assign this flair",
+		"you must be a member to post in this sub":
+		return http.StatusForbidden
+	case "you have already crossposted this post to this sub":
+		return http.StatusConflict
+	case "failed to check crossposts", "failed to create crosspost":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Crosspost a post
+// @Description Shares a post in another sub. The crosspost links back to the original and shows its content, and the original counts its crossposts. Crossposting a crosspost shares the original. Posts from private subs can't be crossposted, and the target sub's posting settings apply.
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param crosspost body models.CrosspostRequest true "Target sub, and optionally a new title and flair"
+// @Success 201 {object} models.PostResponse
+// @Failure 400 {object} map[string]string "error: Bad request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Private source sub or sub posting restrictions not met"
+// @Failure 404 {object} map[string]string "error: Post or sub not found"
+// @Failure 409 {object} map[string]string "error: Already crossposted to this sub"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id}/crosspost [post]
+func CrosspostPost(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.CrosspostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	post, err := newCrosspostService().Crosspost(c.Param("id"), username.(string), req)
+	if err != nil {
+		c.JSON(crosspostErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, post)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCrosspostTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.POST("/posts", CreatePost)
+	r.POST("/posts/:id/crosspost", CrosspostPost)
+	return r
+}
+
+func TestCrosspostHandler(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	author := models.User{Username: "crosshandlerauthor", Password: "hashedpass"}
+	database.DB.Where("username = ?", author.Username).FirstOrCreate(&author)
+	source := models.Sub{Name: "crosshandlersource", OwnerID: author.ID}
+	target := models.Sub{Name: "crosshandlertarget", OwnerID: author.ID}
+	secret := models.Sub{Name: "crosshandlersecret", OwnerID: author.ID, Private: true}
+	database.DB.Create(&source)
+	database.DB.Create(&target)
+	database.DB.Create(&secret)
+
+	router := setupCrosspostTestRouter(author.Username)
+	send := func(path string, body interface{}) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	createPost := func(subID uint) models.Post {
+		w := send("/posts", map[string]interface{}{"title": "Original", "content": "Text", "sub_id": subID})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var post models.Post
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &post))
+		return post
+	}
+
+	post := createPost(source.ID)
+	w := send(fmt.Sprintf("/posts/%d/crosspost", post.ID), models.CrosspostRequest{SubID: target.ID})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var crosspost models.PostResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &crosspost))
+	require.NotNil(t, crosspost.Crosspost)
+	assert.Equal(t, post.ID, crosspost.Crosspost.PostID)
+
+	w = send(fmt.Sprintf("/posts/%d/crosspost", post.ID), models.CrosspostRequest{SubID: target.ID})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = send(fmt.Sprintf("/posts/%d/crosspost", post.ID), map[string]interface{}{})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = send("/posts/999999/crosspost", models.CrosspostRequest{SubID: target.ID})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	private := createPost(secret.ID)
+	w = send(fmt.Sprintf("/posts/%d/crosspost", private.ID), models.CrosspostRequest{SubID: target.ID})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
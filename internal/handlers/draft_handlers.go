@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newDraftService() *services.DraftService {
+	return services.NewDraftService(repositories.NewDraftRepository(), repositories.NewPollRepository(), repositories.NewUserRepository())
+}
+
+// draftErrorStatus maps draft and publishing errors to HTTP status codes
+func draftErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "draft not found", "sub not found":
+		return http.StatusNotFound
+	case "this post type is not allowed in this sub", "your account is too new to post in this sub",
+		"you don't have enough karma to post in this sub",
+		"only approved submitters can post in this sub", "only moderators can assign this flair",
+		"you must be a member to post in this sub":
+		return http.StatusForbidden
+	case "this post has already been published", "this post is not scheduled":
+		return http.StatusConflict
+	}
+	if strings.HasPrefix(err.Error(), "failed to") {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary List drafts
+// @Description Lists the current user's drafts and scheduled posts, scheduled posts first in publishing order. A scheduled post that couldn't be published goes back to being a draft with publish_error set.
+// @Tags Users
+// @Produce json
+// @Success 200 {array} models.DraftResponse
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /user/drafts [get]
+func GetDrafts(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	drafts, err := newDraftService().GetDrafts(username.(string))
+	if err != nil {
+		c.JSON(draftErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, drafts)
+}
+
+// @Summary Edit a draft
+// @Description Replaces the title and content of one of the current user's drafts or scheduled posts
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param draftID path string true "Post ID"
+// @Param draft body models.DraftUpdateRequest true "New title and content"
+// @Success 200 {object} models.DraftResponse
+// @Failure 400 {object} map[string]string "error: Bad request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Draft not found"
+// @Security BearerAuth
+// @Router /user/drafts/{draftID} [put]
+func UpdateDraft(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.DraftUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	draft, err := newDraftService().UpdateDraft(c.Param("draftID"), username.(string), req)
+	if err != nil {
+		c.JSON(draftErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// @Summary Delete a draft
+// @Description Discards one of the current user's drafts or scheduled posts
+// @Tags Users
+// @Produce json
+// @Param draftID path string true "Post ID"
+// @Success 200 {object} map[string]string "message: Draft deleted"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Draft not found"
+// @Security BearerAuth
+// @Router /user/drafts/{draftID} [delete]
+func DeleteDraft(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newDraftService().DeleteDraft(c.Param("draftID"), username.(string)); err != nil {
+		c.JSON(draftErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Draft deleted"})
+}
+
+// @Summary Publish a draft
+// @Description Publishes one of the current user's drafts or scheduled posts now. The sub's posting settings are checked again first.
+// @Tags Users
+// @Produce json
+// @Param draftID path string true "Post ID"
+// @Success 200 {object} models.PostResponse
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Sub posting restrictions not met"
+// @Failure 404 {object} map[string]string "error: Draft not found"
+// @Failure 409 {object} map[string]string "error: Already published"
+// @Security BearerAuth
+// @Router /user/drafts/{draftID}/publish [post]
+func PublishDraft(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	post, err := newDraftService().Publish(c.Param("draftID"), username.(string))
+	if err != nil {
+		c.JSON(draftErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// @Summary Schedule a draft
+// @Description Schedules one of the current user's drafts to be published at a future time, or moves a scheduled post to a new time. The sub's posting settings are checked again when the post is published; if they no longer allow it, the post goes back to being a draft.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param draftID path string true "Post ID"
+// @Param schedule body models.ScheduleRequest true "Publishing time"
+// @Success 200 {object} models.DraftResponse
+// @Failure 400 {object} map[string]string "error: Time is not in the future"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Draft not found"
+// @Security BearerAuth
+// @Router /user/drafts/{draftID}/schedule [put]
+func ScheduleDraft(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	draft, err := newDraftService().Schedule(c.Param("draftID"), username.(string), req)
+	if err != nil {
+		c.JSON(draftErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// @Summary Cancel a scheduled post
+// @Description Stops a scheduled post from being published, keeping it as a draft
+// @Tags Users
+// @Produce json
+// @Param draftID path string true "Post ID"
+// @Success 200 {object} models.DraftResponse
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Draft not found"
+// @Failure 409 {object} map[string]string "error: Post is not scheduled"
+// @Security BearerAuth
+// @Router /user/drafts/{draftID}/schedule [delete]
+func CancelScheduledPost(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	draft, err := newDraftService().CancelSchedule(c.Param("draftID"), username.(string))
+	if err != nil {
+		c.JSON(draftErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
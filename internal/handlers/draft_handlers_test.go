@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDraftTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.POST("/posts", CreatePost)
+	r.GET("/posts/:postID", GetPostByID)
+	r.GET("/user/drafts", GetDrafts)
+	r.PUT("/user/drafts/:draftID", UpdateDraft)
+	r.DELETE("/user/drafts/:draftID", DeleteDraft)
+	r.POST("/user/drafts/:draftID/publish", PublishDraft)
+	r.PUT("/user/drafts/:draftID/schedule", ScheduleDraft)
+	r.DELETE("/user/drafts/:draftID/schedule", CancelScheduledPost)
+	return r
+}
+
+func TestDraftHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	author := models.User{Username: "drafthandlerauthor", Password: "hashedpass"}
+	other := models.User{Username: "drafthandlerother", Password: "hashedpass"}
+	database.DB.Where("username = ?", author.Username).FirstOrCreate(&author)
+	database.DB.Where("username = ?", other.Username).FirstOrCreate(&other)
+	sub := models.Sub{Name: "drafthandlersub", OwnerID: author.ID}
+	database.DB.Create(&sub)
+
+	authorRouter := setupDraftTestRouter(author.Username)
+	otherRouter := setupDraftTestRouter(other.Username)
+
+	send := func(router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(authorRouter, http.MethodPost, "/posts", map[string]interface{}{"title": "Past", "content": "Text", "sub_id": sub.ID,
+		"scheduled_at": time.Now().Add(-time.Hour)})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = send(authorRouter, http.MethodPost, "/posts", map[string]interface{}{"title": "Draft", "content": "Text", "sub_id": sub.ID,
+		"status": models.PostStatusDraft})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var post models.Post
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &post))
+	draftPath := fmt.Sprintf("/user/drafts/%d", post.ID)
+
+	w = send(otherRouter, http.MethodGet, fmt.Sprintf("/posts/%d", post.ID), nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = send(otherRouter, http.MethodPut, draftPath, models.DraftUpdateRequest{Title: "Taken", Content: "Text"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = send(authorRouter, http.MethodGet, "/user/drafts", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var drafts []models.DraftResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &drafts))
+	require.Len(t, drafts, 1)
+	assert.Equal(t, "Draft", drafts[0].Title)
+
+	w = send(authorRouter, http.MethodPut, draftPath, models.DraftUpdateRequest{Title: "Edited", Content: "New text"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = send(authorRouter, http.MethodPut, draftPath+"/schedule", models.ScheduleRequest{ScheduledAt: time.Now().Add(time.Hour)})
+	require.Equal(t, http.StatusOK, w.Code)
+	var draft models.DraftResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+	assert.Equal(t, models.PostStatusScheduled, draft.Status)
+
+	w = send(authorRouter, http.MethodDelete, draftPath+"/schedule", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = send(authorRouter, http.MethodDelete, draftPath+"/schedule", nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = send(authorRouter, http.MethodPost, draftPath+"/publish", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = send(otherRouter, http.MethodGet, fmt.Sprintf("/posts/%d", post.ID), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = send(authorRouter, http.MethodDelete, draftPath, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newFlairService() *services.FlairService {
+	return services.NewFlairService(repositories.NewFlairRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+}
+
+// flairErrorStatus maps flair errors to HTTP status codes
+func flairErrorStatus(err error) int {
+	switch err.Error() {
+	case "sub not found", "user not found", "post not found", "flair not found", "user is not a member of this sub":
+		return http.StatusNotFound
+	case "only moderators can manage flair", "only moderators can assign this flair",
+		"only the post author or a moderator can change post flair",
+		"only moderators can change another member's flair", "you must be a member to view this sub":
+		return http.StatusForbidden
+	case "failed to fetch flairs", "failed to create flair", "failed to update flair",
+		"failed to delete flair", "failed to assign flair", "failed to check moderators", "failed to check membership":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Get sub flairs
+// @Description Lists a sub's flair templates (private subs: members and moderators only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param type query string false "Only return post or user flair"
+// @Success 200 {array} models.FlairResponse "Flair templates"
+// @Failure 400 {object} map[string]string "error: Invalid flair type"
+// @Failure 403 {object} map[string]string "error: You must be a member to view this sub"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/flairs [get]
+func GetSubFlairs(c *gin.Context) {
+	flairs, err := newFlairService().GetFlairs(c.Param("subID"), c.GetString("username"), c.Query("type"))
+	if err != nil {
+		c.JSON(flairErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, flairs)
+}
+
+// @Summary Create sub flair
+// @Description Adds a post or user flair template to a sub (moderators only)
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param flair body models.FlairRequest true "Flair template"
+// @Success 201 {object} models.FlairResponse "Created flair"
+// @Failure 400 {object} map[string]string "error: Invalid flair"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can manage flair"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/flairs [post]
+func CreateSubFlair(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var flairRequest models.FlairRequest
+	if err := c.ShouldBindJSON(&flairRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flair, err := newFlairService().CreateFlair(c.Param("subID"), username.(string), flairRequest)
+	if err != nil {
+		c.JSON(flairErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, flair)
+}
+
+// @Summary Update sub flair
+// @Description Changes a flair template's text, colors and mod-only flag (moderators only)
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param flairID path string true "Flair ID"
+// @Param flair body models.FlairRequest true "Flair template"
+// @Success 200 {object} models.FlairResponse "Updated flair"
+// @Failure 400 {object} map[string]string "error: Invalid flair"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can manage flair"
+// @Failure 404 {object} map[string]string "error: Sub or flair not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/flairs/{flairID} [patch]
+func UpdateSubFlair(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var flairRequest models.FlairRequest
+	if err := c.ShouldBindJSON(&flairRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flair, err := newFlairService().UpdateFlair(c.Param("subID"), c.Param("flairID"), username.(string), flairRequest)
+	if err != nil {
+		c.JSON(flairErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, flair)
+}
+
+// @Summary Delete sub flair
+// @Description Removes a flair template and clears it from posts and members (moderators only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param flairID path string true "Flair ID"
+// @Success 200 {object} map[string]string "message: Flair deleted"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can manage flair"
+// @Failure 404 {object} map[string]string "error: Sub or flair not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/flairs/{flairID} [delete]
+func DeleteSubFlair(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newFlairService().DeleteFlair(c.Param("subID"), c.Param("flairID"), username.(string)); err != nil {
+		c.JSON(flairErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Flair deleted"})
+}
+
+// @Summary Set member flair
+// @Description Sets or clears a member's user flair; members can set their own, moderators anyone's
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param username path string true "Member username"
+// @Param flair body models.FlairAssignmentRequest true "Flair to assign (null clears it)"
+// @Success 200 {object} map[string]interface{} "flair: Assigned flair"
+// @Failure 400 {object} map[string]string "error: Flair cannot be used as user flair"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Not allowed to assign this flair"
+// @Failure 404 {object} map[string]string "error: Sub, member or flair not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/members/{username}/flair [put]
+func SetMemberFlair(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var assignment models.FlairAssignmentRequest
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flair, err := newFlairService().AssignMemberFlair(c.Param("subID"), c.Param("username"), username.(string), assignment)
+	if err != nil {
+		c.JSON(flairErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flair": flair})
+}
+
+// @Summary Set post flair
+// @Description Sets or clears a post's flair (post author or sub moderators)
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param flair body models.FlairAssignmentRequest true "Flair to assign (null clears it)"
+// @Success 200 {object} map[string]interface{} "flair: Assigned flair"
+// @Failure 400 {object} map[string]string "error: Flair cannot be used as post flair"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Not allowed to change this post's flair"
+// @Failure 404 {object} map[string]string "error: Post or flair not found"
+// @Security BearerAuth
+// @Router /posts/{id}/flair [put]
+func SetPostFlair(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var assignment models.FlairAssignmentRequest
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flair, err := newFlairService().AssignPostFlair(c.Param("id"), username.(string), assignment)
+	if err != nil {
+		c.JSON(flairErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flair": flair})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupFlairTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.GET("/sub/:subID/flairs", GetSubFlairs)
+	r.POST("/sub/:subID/flairs", CreateSubFlair)
+	r.PATCH("/sub/:subID/flairs/:flairID", UpdateSubFlair)
+	r.DELETE("/sub/:subID/flairs/:flairID", DeleteSubFlair)
+	r.PUT("/posts/:id/flair", SetPostFlair)
+	return r
+}
+
+func TestFlairHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	// Setup
+	database.DB.Exec("DELETE FROM sub_flairs")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+
+	owner := models.User{Username: "flairhandlerowner", Password: "hashedpass"}
+	author := models.User{Username: "flairhandlerauthor", Password: "hashedpass"}
+	database.DB.Where("username = ?", owner.Username).FirstOrCreate(&owner)
+	database.DB.Where("username = ?", author.Username).FirstOrCreate(&author)
+
+	sub := models.Sub{Name: "flairhandlersub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	post := models.Post{Title: "Post", Content: "Content", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+
+	ownerRouter := setupFlairTestRouter(owner.Username)
+	authorRouter := setupFlairTestRouter(author.Username)
+
+	var flair models.FlairResponse
+
+	t.Run("moderator creates flair", func(t *testing.T) {
+		body := `{"type":"post","text":"Question","background_color":"#FF4500"}`
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/sub/%d/flairs", sub.ID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &flair)
+		assert.Equal(t, "#FF4500", flair.BackgroundColor)
+	})
+
+	t.Run("non-moderator cannot create flair", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/sub/%d/flairs", sub.ID), strings.NewReader(`{"type":"post","text":"Spam"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("list flairs by type", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/sub/%d/flairs?type=post", sub.ID), nil)
+		w := httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Question")
+	})
+
+	t.Run("author sets post flair", func(t *testing.T) {
+		body := fmt.Sprintf(`{"flair_id":%d}`, flair.ID)
+		req, _ := http.NewRequest("PUT", fmt.Sprintf("/posts/%d/flair", post.ID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Question")
+	})
+
+	t.Run("delete flair", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", fmt.Sprintf("/sub/%d/flairs/%d", sub.ID, flair.ID), nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
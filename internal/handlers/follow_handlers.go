@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newFollowService() *services.FollowService {
+	return services.NewFollowService(repositories.NewFollowRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository())
+}
+
+// followErrorStatus maps follow errors to HTTP status codes
+func followErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "follow request not found":
+		return http.StatusNotFound
+	case "this user's follows are private", "you cannot interact with this user":
+		return http.StatusForbidden
+	case "you already follow this user", "follow request already pending":
+		return http.StatusConflict
+	case "failed to follow user", "failed to unfollow user", "failed to update follow request",
+		"failed to count follows", "failed to fetch follows", "failed to fetch follow requests", "failed to fetch posts",
+		"failed to check blocks":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Follow a user
+// @Description Follows a user. Following a private user sends a follow request that they have to approve
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username to follow"
+// @Success 200 {object} models.FollowStatusResponse "Follow status: accepted or pending"
+// @Failure 400 {object} map[string]string "error: Cannot follow yourself"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Blocked by this user"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Failure 409 {object} map[string]string "error: Already following or request pending"
+// @Security BearerAuth
+// @Router /user/{username}/follow [post]
+func FollowUser(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	status, err := newFollowService().Follow(username.(string), c.Param("username"))
+	if err != nil {
+		c.JSON(followErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// @Summary Unfollow a user
+// @Description Stops following a user, or withdraws a pending follow request
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username to unfollow"
+// @Success 200 {object} map[string]string "message: Unfollowed successfully"
+// @Failure 400 {object} map[string]string "error: Not following this user"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /user/{username}/follow [delete]
+func UnfollowUser(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newFollowService().Unfollow(username.(string), c.Param("username")); err != nil {
+		c.JSON(followErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unfollowed successfully"})
+}
+
+// @Summary Get followers
+// @Description Lists a user's followers. A private user's followers are only visible to them and their followers
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.FollowListResponse "One page of followers"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Follows are private"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /user/{username}/followers [get]
+func GetFollowers(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	followers, err := newFollowService().GetFollowers(c.Param("username"), username.(string), page, limit)
+	if err != nil {
+		c.JSON(followErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, followers)
+}
+
+// @Summary Get followed users
+// @Description Lists the users someone follows. A private user's list is only visible to them and their followers
+// @Tags Users
+// @Produce json
+// @Param username path string true "Username"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.FollowListResponse "One page of followed users"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Follows are private"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /user/{username}/following [get]
+func GetFollowing(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	following, err := newFollowService().GetFollowing(c.Param("username"), username.(string), page, limit)
+	if err != nil {
+		c.JSON(followErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, following)
+}
+
+// @Summary Get follow requests
+// @Description Lists pending requests to follow the authenticated user
+// @Tags Users
+// @Produce json
+// @Success 200 {array} models.FollowRequestResponse "Pending follow requests"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Security BearerAuth
+// @Router /user/follow-requests [get]
+func GetFollowRequests(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	requests, err := newFollowService().GetFollowRequests(username.(string))
+	if err != nil {
+		c.JSON(followErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Approve a follow request
+// @Description Approves a pending request to follow the authenticated user
+// @Tags Users
+// @Produce json
+// @Param requestID path string true "Follow request ID"
+// @Success 200 {object} map[string]string "message: Follow request approved"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Follow request not found"
+// @Security BearerAuth
+// @Router /user/follow-requests/{requestID}/approve [post]
+func ApproveFollowRequest(c *gin.Context) {
+	respondToFollowRequest(c, true)
+}
+
+// @Summary Deny a follow request
+// @Description Denies a pending request to follow the authenticated user
+// @Tags Users
+// @Produce json
+// @Param requestID path string true "Follow request ID"
+// @Success 200 {object} map[string]string "message: Follow request denied"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Follow request not found"
+// @Security BearerAuth
+// @Router /user/follow-requests/{requestID}/deny [post]
+func DenyFollowRequest(c *gin.Context) {
+	respondToFollowRequest(c, false)
+}
+
+// @Summary Get following feed
+// @Description Lists posts by the users the authenticated user follows, newest first
+// @Tags Users
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {array} models.PostResponse "Posts by followed users"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Security BearerAuth
+// @Router /user/feed [get]
+func GetFollowingFeed(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	posts, err := newFollowService().GetFeed(username.(string), page, limit)
+	if err != nil {
+		c.JSON(followErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, posts)
+}
+
+// respondToFollowRequest handles both approving and denying follow requests
+func respondToFollowRequest(c *gin.Context, approve bool) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newFollowService().RespondToFollowRequest(username.(string), c.Param("requestID"), approve); err != nil {
+		c.JSON(followErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Follow request denied"
+	if approve {
+		message = "Follow request approved"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupFollowTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.POST("/user/:username/follow", FollowUser)
+	r.DELETE("/user/:username/follow", UnfollowUser)
+	r.GET("/user/:username/followers", GetFollowers)
+	r.GET("/user/:username/following", GetFollowing)
+	r.GET("/user/follow-requests", GetFollowRequests)
+	r.GET("/user/feed", GetFollowingFeed)
+	return r
+}
+
+func TestParsePagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		query       string
+		page, limit int
+	}{
+		{"", 1, defaultPageSize},
+		{"?page=3&limit=5", 3, 5},
+		{"?page=0&limit=-1", 1, defaultPageSize},
+		{"?limit=1000", 1, maxPageSize},
+	}
+	for _, tc := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest("GET", "/"+tc.query, nil)
+		page, limit := parsePagination(c)
+		assert.Equal(t, tc.page, page, tc.query)
+		assert.Equal(t, tc.limit, limit, tc.query)
+	}
+}
+
+func TestFollowHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	// Setup
+	database.DB.Exec("DELETE FROM user_follows")
+
+	follower := models.User{Username: "followhandlerfan", Password: "hashedpass"}
+	public := models.User{Username: "followhandlerpublic", Password: "hashedpass"}
+	private := models.User{Username: "followhandlerprivate", Password: "hashedpass", IsPrivate: true}
+	database.DB.Where("username = ?", follower.Username).FirstOrCreate(&follower)
+	database.DB.Where("username = ?", public.Username).FirstOrCreate(&public)
+	database.DB.Where("username = ?", private.Username).FirstOrCreate(&private)
+
+	followerRouter := setupFollowTestRouter(follower.Username)
+	privateRouter := setupFollowTestRouter(private.Username)
+
+	t.Run("follow public user", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/user/followhandlerpublic/follow", nil)
+		w := httptest.NewRecorder()
+		followerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("POST", "/user/followhandlerpublic/follow", nil)
+		w = httptest.NewRecorder()
+		followerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("list followers with pagination", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/user/followhandlerpublic/followers?page=1&limit=10", nil)
+		w := httptest.NewRecorder()
+		followerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.FollowListResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(1), response.Total)
+		assert.Equal(t, 10, response.Limit)
+	})
+
+	t.Run("private user's follows are hidden", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/user/followhandlerprivate/follow", nil)
+		w := httptest.NewRecorder()
+		followerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), models.FollowStatusPending)
+
+		req, _ = http.NewRequest("GET", "/user/followhandlerprivate/following", nil)
+		w = httptest.NewRecorder()
+		followerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		req, _ = http.NewRequest("GET", "/user/follow-requests", nil)
+		w = httptest.NewRecorder()
+		privateRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "followhandlerfan")
+	})
+
+	t.Run("unfollow unknown user", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/user/nosuchuser/follow", nil)
+		w := httptest.NewRecorder()
+		followerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newInviteService() *services.InviteService {
+	return services.NewInviteService(repositories.NewInviteRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+}
+
+// inviteErrorStatus maps invitation errors to HTTP status codes
+func inviteErrorStatus(err error) int {
+	switch err.Error() {
+	case "invitation not found", "invite link not found", "sub not found", "user not found":
+		return http.StatusNotFound
+	case "you are not the invitee for this invitation",
+		"only the sub owner or inviter can revoke this invitation",
+		"only the sub owner can manage invite links":
+		return http.StatusForbidden
+	case "invitation is no longer pending", "invitation has expired", "invite link is no longer valid", "you are already a member of this sub":
+		return http.StatusConflict
+	case "failed to update invitation", "failed to fetch invitations", "could not generate invite token",
+		"failed to create invite link", "failed to fetch invite links", "failed to revoke invite link",
+		"failed to redeem invite link", "failed to join sub":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Get my invitations
+// @Description Lists the pending, unexpired sub invitations addressed to the authenticated user
+// @Tags Subs
+// @Produce json
+// @Success 200 {array} models.UserInviteResponse "Pending invitations"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /user/invites [get]
+func GetMyInvites(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	invites, err := newInviteService().GetMyInvites(username.(string))
+	if err != nil {
+		c.JSON(inviteErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+// @Summary Decline sub invitation
+// @Description Allows the invitee to decline a pending invitation
+// @Tags Subs
+// @Produce json
+// @Param inviteID path string true "Invite ID"
+// @Success 200 {object} map[string]string "message: Invitation declined"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Not the invitee"
+// @Failure 404 {object} map[string]string "error: Invitation not found"
+// @Failure 409 {object} map[string]string "error: Invitation is no longer pending"
+// @Security BearerAuth
+// @Router /user/invite/{inviteID}/decline [post]
+func DeclineInvite(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newInviteService().DeclineInvite(c.Param("inviteID"), username.(string)); err != nil {
+		c.JSON(inviteErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation declined"})
+}
+
+// @Summary Revoke sub invitation
+// @Description Allows the sub owner or the original inviter to withdraw a pending invitation
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param inviteID path string true "Invite ID"
+// @Success 200 {object} map[string]string "message: Invitation revoked"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only the owner or inviter can revoke"
+// @Failure 404 {object} map[string]string "error: Sub or invitation not found"
+// @Failure 409 {object} map[string]string "error: Invitation is no longer pending"
+// @Security BearerAuth
+// @Router /sub/{subID}/invites/{inviteID} [delete]
+func RevokeInvite(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newInviteService().RevokeInvite(c.Param("subID"), c.Param("inviteID"), username.(string)); err != nil {
+		c.JSON(inviteErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked"})
+}
+
+// @Summary Create invite link
+// @Description Creates a single-use shareable invite link for a private sub (owner only)
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param link body models.InviteLinkRequest false "Link options"
+// @Success 201 {object} models.InviteLinkResponse "Created invite link"
+// @Failure 400 {object} map[string]string "error: Sub is not private or invalid expiry"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage invite links"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/invite-links [post]
+func CreateInviteLink(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var linkRequest models.InviteLinkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&linkRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	link, err := newInviteService().CreateInviteLink(c.Param("subID"), username.(string), linkRequest)
+	if err != nil {
+		c.JSON(inviteErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// @Summary Get invite links
+// @Description Lists a sub's unused, unexpired invite links (owner only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Success 200 {array} models.InviteLinkResponse "Active invite links"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage invite links"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/invite-links [get]
+func GetInviteLinks(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	links, err := newInviteService().GetInviteLinks(c.Param("subID"), username.(string))
+	if err != nil {
+		c.JSON(inviteErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// @Summary Revoke invite link
+// @Description Disables an invite link before it is used (owner only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param linkID path string true "Invite link ID"
+// @Success 200 {object} map[string]string "message: Invite link revoked"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage invite links"
+// @Failure 404 {object} map[string]string "error: Sub or link not found"
+// @Failure 409 {object} map[string]string "error: Invite link is no longer valid"
+// @Security BearerAuth
+// @Router /sub/{subID}/invite-links/{linkID} [delete]
+func RevokeInviteLink(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newInviteService().RevokeInviteLink(c.Param("subID"), c.Param("linkID"), username.(string)); err != nil {
+		c.JSON(inviteErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invite link revoked"})
+}
+
+// @Summary Redeem invite link
+// @Description Joins the private sub an invite link belongs to; each link can only be used once
+// @Tags Subs
+// @Produce json
+// @Param token path string true "Invite link token"
+// @Success 200 {object} map[string]interface{} "joined: ID of joined sub"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Invite link not found"
+// @Failure 409 {object} map[string]string "error: Link already used, revoked or expired"
+// @Security BearerAuth
+// @Router /user/invite-links/{token}/redeem [post]
+func RedeemInviteLink(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	membership, err := newInviteService().RedeemInviteLink(c.Param("token"), username.(string))
+	if err != nil {
+		c.JSON(inviteErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"joined": membership.SubID})
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupInviteTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.GET("/invites", GetMyInvites)
+	r.POST("/invite/:inviteID/decline", DeclineInvite)
+	r.DELETE("/sub/:subID/invites/:inviteID", RevokeInvite)
+	r.POST("/sub/:subID/invite-links", CreateInviteLink)
+	r.GET("/sub/:subID/invite-links", GetInviteLinks)
+	r.POST("/invite-links/:token/redeem", RedeemInviteLink)
+	return r
+}
+
+func TestInviteHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	// Setup
+	database.DB.Exec("DELETE FROM sub_invite_links")
+	database.DB.Exec("DELETE FROM sub_invitations")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+
+	owner := models.User{Username: "invitehandlerowner", Password: "hashedpass"}
+	invitee := models.User{Username: "invitehandlerinvitee", Password: "hashedpass"}
+	database.DB.Where("username = ?", owner.Username).FirstOrCreate(&owner)
+	database.DB.Where("username = ?", invitee.Username).FirstOrCreate(&invitee)
+
+	sub := models.Sub{Name: "invitehandlersub", OwnerID: owner.ID, Private: true}
+	database.DB.Create(&sub)
+
+	invitation := models.SubInvitation{SubID: sub.ID, InviterID: owner.ID, InviteeID: invitee.ID, Status: models.InviteStatusPending}
+	database.DB.Create(&invitation)
+
+	ownerRouter := setupInviteTestRouter(owner.Username)
+	inviteeRouter := setupInviteTestRouter(invitee.Username)
+
+	t.Run("invitee lists invites", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/invites", nil)
+		w := httptest.NewRecorder()
+		inviteeRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "invitehandlersub")
+	})
+
+	t.Run("owner cannot decline for the invitee", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/invite/%d/decline", invitation.ID), nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("owner revokes invite", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", fmt.Sprintf("/sub/%d/invites/%d", sub.ID, invitation.ID), nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("POST", fmt.Sprintf("/invite/%d/decline", invitation.ID), nil)
+		w = httptest.NewRecorder()
+		inviteeRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("invite link can be redeemed once", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/sub/%d/invite-links", sub.ID), strings.NewReader(`{"expires_in_hours":24}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var link models.InviteLinkResponse
+		json.Unmarshal(w.Body.Bytes(), &link)
+		assert.NotEmpty(t, link.Token)
+
+		req, _ = http.NewRequest("POST", "/invite-links/"+link.Token+"/redeem", nil)
+		w = httptest.NewRecorder()
+		inviteeRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("POST", "/invite-links/"+link.Token+"/redeem", nil)
+		w = httptest.NewRecorder()
+		inviteeRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("non-owner cannot list invite links", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/sub/%d/invite-links", sub.ID), nil)
+		w := httptest.NewRecorder()
+		inviteeRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
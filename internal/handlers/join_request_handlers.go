@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newJoinRequestService() *services.JoinRequestService {
+	return services.NewJoinRequestService(repositories.NewJoinRequestRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository())
+}
+
+// joinRequestErrorStatus maps join request errors to HTTP status codes
+func joinRequestErrorStatus(err error) int {
+	switch err.Error() {
+	case "sub not found", "user not found", "join request not found":
+		return http.StatusNotFound
+	case "only moderators can review join requests", "this sub is invite-only":
+		return http.StatusForbidden
+	case "you are already a member of this sub", "you already have a pending join request for this sub",
+		"join request is no longer pending":
+		return http.StatusConflict
+	case "failed to create join request", "failed to check join requests", "failed to fetch join requests",
+		"failed to approve join request", "failed to deny join request", "failed to check membership",
+		"failed to check moderators":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Request to join sub
+// @Description Submits a request to join a private sub that accepts join requests, with an optional message for the moderators
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param request body models.JoinRequestRequest false "Optional message"
+// @Success 201 {object} models.JoinRequestResponse "Created join request"
+// @Failure 400 {object} map[string]string "error: Sub is public or message too long"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Sub is invite-only"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Failure 409 {object} map[string]string "error: Already a member or request pending"
+// @Security BearerAuth
+// @Router /sub/{subID}/join-requests [post]
+func RequestToJoinSub(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var joinRequest models.JoinRequestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&joinRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	request, err := newJoinRequestService().RequestToJoin(c.Param("subID"), username.(string), joinRequest)
+	if err != nil {
+		c.JSON(joinRequestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// @Summary Get join requests
+// @Description Lists a sub's pending join requests, oldest first (moderators only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Success 200 {array} models.JoinRequestResponse "Pending join requests"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can review join requests"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/join-requests [get]
+func GetJoinRequests(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	requests, err := newJoinRequestService().GetJoinRequests(c.Param("subID"), username.(string))
+	if err != nil {
+		c.JSON(joinRequestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Approve join request
+// @Description Approves a pending join request, adds the requester to the sub and notifies them (moderators only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param requestID path string true "Join request ID"
+// @Success 200 {object} models.JoinRequestResponse "Approved join request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can review join requests"
+// @Failure 404 {object} map[string]string "error: Sub or join request not found"
+// @Failure 409 {object} map[string]string "error: Join request is no longer pending"
+// @Security BearerAuth
+// @Router /sub/{subID}/join-requests/{requestID}/approve [post]
+func ApproveJoinRequest(c *gin.Context) {
+	reviewJoinRequest(c, true)
+}
+
+// @Summary Deny join request
+// @Description Denies a pending join request and notifies the requester (moderators only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param requestID path string true "Join request ID"
+// @Success 200 {object} models.JoinRequestResponse "Denied join request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can review join requests"
+// @Failure 404 {object} map[string]string "error: Sub or join request not found"
+// @Failure 409 {object} map[string]string "error: Join request is no longer pending"
+// @Security BearerAuth
+// @Router /sub/{subID}/join-requests/{requestID}/deny [post]
+func DenyJoinRequest(c *gin.Context) {
+	reviewJoinRequest(c, false)
+}
+
+func reviewJoinRequest(c *gin.Context, approve bool) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	request, err := newJoinRequestService().ReviewJoinRequest(c.Param("subID"), c.Param("requestID"), username.(string), approve)
+	if err != nil {
+		c.JSON(joinRequestErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupJoinRequestTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.GET("/:subID/join-requests", GetJoinRequests)
+	r.POST("/:subID/join-requests", RequestToJoinSub)
+	r.POST("/:subID/join-requests/:requestID/approve", ApproveJoinRequest)
+	r.POST("/:subID/join-requests/:requestID/deny", DenyJoinRequest)
+	return r
+}
+
+func TestJoinRequestHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	// Setup
+	database.DB.Exec("DELETE FROM notifications")
+	database.DB.Exec("DELETE FROM sub_join_requests")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+
+	owner := models.User{Username: "joinhandlerowner", Password: "hashedpass"}
+	requester := models.User{Username: "joinhandlerrequester", Password: "hashedpass"}
+	database.DB.Where("username = ?", owner.Username).FirstOrCreate(&owner)
+	database.DB.Where("username = ?", requester.Username).FirstOrCreate(&requester)
+
+	sub := models.Sub{Name: "joinhandlersub", OwnerID: owner.ID, Private: true, JoinMode: models.JoinModeRequest}
+	database.DB.Create(&sub)
+
+	ownerRouter := setupJoinRequestTestRouter(owner.Username)
+	requesterRouter := setupJoinRequestTestRouter(requester.Username)
+
+	var created models.JoinRequestResponse
+
+	t.Run("user submits a join request", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/%d/join-requests", sub.ID), strings.NewReader(`{"message":"I'd like to join"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		requesterRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &created)
+		assert.Equal(t, "I'd like to join", created.Message)
+	})
+
+	t.Run("requester cannot see the queue", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/%d/join-requests", sub.ID), nil)
+		w := httptest.NewRecorder()
+		requesterRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("owner denies the request", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/%d/join-requests/%d/deny", sub.ID, created.ID), nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), models.JoinRequestStatusDenied)
+
+		req, _ = http.NewRequest("POST", fmt.Sprintf("/%d/join-requests/%d/approve", sub.ID, created.ID), nil)
+		w = httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
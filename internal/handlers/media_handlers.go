@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead allows for the multipart boundaries and headers around an uploaded file
+const multipartOverhead = 1 << 20
+
+func newMediaService() (*services.MediaService, error) {
+	store, err := storage.Default()
+	if err != nil {
+		return nil, errors.New("media storage is not configured")
+	}
+	return services.NewMediaService(repositories.NewMediaRepository(), repositories.NewUserRepository(), store), nil
+}
+
+// mediaErrorStatus maps media errors to HTTP status codes
+func mediaErrorStatus(err error) int {
+	switch {
+	case err.Error() == "user not found", err.Error() == "media not found", err.Error() == "rendition not found",
+		err.Error() == "media is still being processed", err.Error() == "media could not be processed":
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "file is too large"):
+		return http.StatusRequestEntityTooLarge
+	case strings.HasPrefix(err.Error(), "unsupported file type"):
+		return http.StatusUnsupportedMediaType
+	case err.Error() == "media storage is not configured", err.Error() == "failed to store media",
+		err.Error() == "failed to save media", err.Error() == "failed to read media", err.Error() == "failed to fetch media":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Upload media
+// @Description Uploads an image as multipart form data in the "file" field. The type is detected from the file's bytes; JPEG, PNG, GIF and WebP images are accepted. The image is processed in the background into thumbnail, preview and full renditions without its metadata. Reference the returned ID as media_id on posts and comments, or avatar_media_id on the profile.
+// @Tags Media
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Image to upload"
+// @Success 201 {object} models.MediaResponse
+// @Failure 400 {object} map[string]string "error: File is required"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 413 {object} map[string]string "error: File is too large"
+// @Failure 415 {object} map[string]string "error: Unsupported file type"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /media [post]
+func UploadMedia(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	// Larger bodies are cut off before they are buffered to disk
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.MaxUploadSize()+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large, the limit is " + strconv.FormatInt(services.MaxUploadSize()>>20, 10) + " MB"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read upload"})
+		return
+	}
+	defer file.Close()
+
+	service, err := newMediaService()
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	media, err := service.Upload(username.(string), file)
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, media)
+}
+
+// @Summary Get media
+// @Description Describes an uploaded file, with its processing status, dimensions, blurhash and renditions
+// @Tags Media
+// @Produce json
+// @Param id path string true "Media ID"
+// @Success 200 {object} models.MediaResponse
+// @Failure 400 {object} map[string]string "error: Invalid media ID"
+// @Failure 404 {object} map[string]string "error: Media not found"
+// @Router /media/{id} [get]
+func GetMedia(c *gin.Context) {
+	service, err := newMediaService()
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	media, err := service.GetMedia(c.Param("id"))
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, media)
+}
+
+// @Summary Download media
+// @Description Serves the full rendition of an uploaded image. Renditions never change, so they can be cached indefinitely.
+// @Tags Media
+// @Produce image/jpeg,image/png
+// @Param id path string true "Media ID"
+// @Success 200 {file} file "The full size image"
+// @Failure 400 {object} map[string]string "error: Invalid media ID"
+// @Failure 404 {object} map[string]string "error: Media not found or still being processed"
+// @Router /media/{id}/file [get]
+func GetMediaFile(c *gin.Context) {
+	serveRendition(c, models.RenditionFull)
+}
+
+// @Summary Download a media rendition
+// @Description Serves one size of an uploaded image: thumbnail, preview or full
+// @Tags Media
+// @Produce image/jpeg,image/png
+// @Param id path string true "Media ID"
+// @Param name path string true "Rendition name"
+// @Success 200 {file} file "The image at that size"
+// @Failure 400 {object} map[string]string "error: Invalid media ID"
+// @Failure 404 {object} map[string]string "error: Rendition not found or still being processed"
+// @Router /media/{id}/renditions/{name} [get]
+func GetMediaRendition(c *gin.Context) {
+	serveRendition(c, c.Param("name"))
+}
+
+// serveRendition streams one size of an uploaded image
+func serveRendition(c *gin.Context, name string) {
+	service, err := newMediaService()
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	rendition, file, err := service.OpenRendition(c.Param("id"), name)
+	if err != nil {
+		c.JSON(mediaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, rendition.Size, rendition.ContentType, file, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMediaTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/media/:id", GetMedia)
+	r.GET("/media/:id/file", GetMediaFile)
+	r.GET("/media/:id/renditions/:name", GetMediaRendition)
+	r.POST("/media", func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	}, UploadMedia)
+	return r
+}
+
+// multipartUpload builds a request uploading data in the given form field
+func multipartUpload(t *testing.T, field string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "upload.png")
+	require.NoError(t, err)
+	_, _ = part.Write(data)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, "/media", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestMediaHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	t.Setenv("MEDIA_STORAGE", "local")
+	t.Setenv("MEDIA_LOCAL_DIR", t.TempDir())
+
+	user := models.User{Username: "mediahandleruser", Password: "hashedpass"}
+	database.DB.Where("username = ?", user.Username).FirstOrCreate(&user)
+
+	router := setupMediaTestRouter(user.Username)
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, image.NewGray(image.Rect(0, 0, 320, 200))))
+	upload := encoded.Bytes()
+
+	var uploaded models.MediaResponse
+
+	t.Run("upload", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload(t, "file", upload))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+		assert.Equal(t, "image/png", uploaded.ContentType)
+	})
+
+	t.Run("upload rejects files that aren't images", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload(t, "file", []byte("#!/bin/sh\necho hi\n")))
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("upload needs a file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload(t, "other", upload))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get and download", func(t *testing.T) {
+		// Nothing is served until the image has been processed
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/media/%d/file", uploaded.ID), nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		store, err := storage.Default()
+		require.NoError(t, err)
+		require.NoError(t, services.NewMediaProcessor(repositories.NewMediaRepository(), store).Process(uploaded.ID))
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/media/%d", uploaded.ID), nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var media models.MediaResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &media))
+		assert.Equal(t, models.MediaStatusReady, media.Status)
+		assert.Len(t, media.Renditions, 3)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/media/%d/file", uploaded.ID), nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/media/%d/renditions/thumbnail", uploaded.ID), nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		config, _, err := image.DecodeConfig(w.Body)
+		require.NoError(t, err)
+		assert.Equal(t, 160, config.Width)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodGet, "/media/999999/file", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
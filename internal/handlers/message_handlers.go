@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newMessageService() *services.MessageService {
+	return services.NewMessageService(repositories.NewMessageRepository(), repositories.NewUserRepository(),
+		repositories.NewFollowRepository(), repositories.NewSubSettingsRepository())
+}
+
+// messageErrorStatus maps messaging errors to HTTP status codes
+func messageErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "sub not found", "conversation not found":
+		return http.StatusNotFound
+	case "you cannot interact with this user", "this user only accepts messages from people they follow",
+		"only moderators can read modmail":
+		return http.StatusForbidden
+	case "failed to create conversation", "failed to send message", "failed to mark conversation as read",
+		"failed to fetch messages", "failed to fetch conversations", "failed to count unread messages",
+		"failed to check conversation participants":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Get inbox
+// @Description Lists the current user's conversations, most recently active first, with unread counts
+// @Tags Messages
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.ConversationListResponse "One page of conversations"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /messages [get]
+func GetInbox(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	inbox, err := newMessageService().GetInbox(username.(string), page, limit)
+	if err != nil {
+		c.JSON(messageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, inbox)
+}
+
+// @Summary Get unread message count
+// @Description Counts unread messages across the current user's conversations
+// @Tags Messages
+// @Produce json
+// @Success 200 {object} models.UnreadCountResponse "Unread message count"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /messages/unread [get]
+func GetUnreadMessageCount(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	count, err := newMessageService().GetUnreadCount(username.(string))
+	if err != nil {
+		c.JSON(messageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, count)
+}
+
+// @Summary Start a conversation
+// @Description Starts a private conversation with one or more users. Users who blocked you, and private users who don't follow you, cannot be messaged
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param conversation body models.ConversationRequest true "Recipients, subject and first message"
+// @Success 201 {object} models.ConversationResponse "Created conversation"
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Recipient cannot be messaged"
+// @Failure 404 {object} map[string]string "error: User not found"
+// @Security BearerAuth
+// @Router /messages [post]
+func StartConversation(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.ConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conversation, err := newMessageService().StartConversation(username.(string), req)
+	if err != nil {
+		c.JSON(messageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, conversation)
+}
+
+// @Summary Get a conversation
+// @Description Returns a conversation with one page of its messages, newest first, including read receipts
+// @Tags Messages
+// @Produce json
+// @Param conversationID path int true "Conversation ID"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.ConversationDetailResponse "Conversation and messages"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Conversation not found"
+// @Security BearerAuth
+// @Router /messages/{conversationID} [get]
+func GetConversation(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	conversation, err := newMessageService().GetConversation(c.Param("conversationID"), username.(string), page, limit)
+	if err != nil {
+		c.JSON(messageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+// @Summary Reply to a conversation
+// @Description Sends a message in a conversation. Moderators can answer their sub's modmail
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param conversationID path int true "Conversation ID"
+// @Param message body models.MessageRequest true "Message body"
+// @Success 201 {object} models.MessageResponse "Sent message"
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Blocked by a participant"
+// @Failure 404 {object} map[string]string "error: Conversation not found"
+// @Security BearerAuth
+// @Router /messages/{conversationID} [post]
+func ReplyToConversation(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.MessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message, err := newMessageService().Reply(c.Param("conversationID"), username.(string), req)
+	if err != nil {
+		c.JSON(messageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// @Summary Mark a conversation as read
+// @Description Marks every message in a conversation as read by the current user
+// @Tags Messages
+// @Produce json
+// @Param conversationID path int true "Conversation ID"
+// @Success 200 {object} map[string]string "message: Conversation marked as read"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Conversation not found"
+// @Security BearerAuth
+// @Router /messages/{conversationID}/read [post]
+func MarkConversationRead(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newMessageService().MarkRead(c.Param("conversationID"), username.(string)); err != nil {
+		c.JSON(messageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Conversation marked as read"})
+}
+
+// @Summary Message a sub's moderators
+// @Description Starts a modmail conversation addressed to a sub rather than a person; any of its moderators can answer
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param subID path int true "Sub ID"
+// @Param message body models.ModmailRequest true "Subject and first message"
+// @Success 201 {object} models.ConversationResponse "Created modmail conversation"
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /messages/modmail/{subID} [post]
+func StartModmail(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.ModmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conversation, err := newMessageService().StartModmail(c.Param("subID"), username.(string), req)
+	if err != nil {
+		c.JSON(messageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, conversation)
+}
+
+// @Summary Get a sub's modmail
+// @Description Lists a sub's modmail conversations, most recently active first (moderators only)
+// @Tags Messages
+// @Produce json
+// @Param subID path int true "Sub ID"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.ConversationListResponse "One page of modmail conversations"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Not a moderator"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /messages/modmail/{subID} [get]
+func GetModmail(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	modmail, err := newMessageService().GetModmail(c.Param("subID"), username.(string), page, limit)
+	if err != nil {
+		c.JSON(messageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, modmail)
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newNotificationService() *services.NotificationService {
+	return services.NewNotificationService(repositories.NewNotificationRepository(), repositories.NewUserRepository(),
+		repositories.NewInviteRepository(), repositories.NewSubSettingsRepository())
+}
+
+// notificationErrorStatus maps notification errors to HTTP status codes
+func notificationErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "notification not found":
+		return http.StatusNotFound
+	case "failed to fetch notifications", "failed to update notification",
+		"failed to fetch notification preferences", "failed to update notification preferences":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Get notifications
+// @Description Lists the current user's notifications, newest first, with the total unread count
+// @Tags Notifications
+// @Produce json
+// @Param unread query bool false "Only list unread notifications"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.NotificationListResponse "One page of notifications"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /notifications [get]
+func GetNotifications(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	unreadOnly := strings.EqualFold(c.Query("unread"), "true")
+	notifications, err := newNotificationService().GetNotifications(username.(string), unreadOnly, page, limit)
+	if err != nil {
+		c.JSON(notificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// @Summary Mark a notification as read
+// @Description Marks one of the current user's notifications as read
+// @Tags Notifications
+// @Produce json
+// @Param notificationID path int true "Notification ID"
+// @Success 200 {object} map[string]string "message: Notification marked as read"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Notification not found"
+// @Security BearerAuth
+// @Router /notifications/{notificationID}/read [post]
+func MarkNotificationRead(c *gin.Context) {
+	setNotificationRead(c, true)
+}
+
+// @Summary Mark a notification as unread
+// @Description Marks one of the current user's notifications as unread
+// @Tags Notifications
+// @Produce json
+// @Param notificationID path int true "Notification ID"
+// @Success 200 {object} map[string]string "message: Notification marked as unread"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Notification not found"
+// @Security BearerAuth
+// @Router /notifications/{notificationID}/unread [post]
+func MarkNotificationUnread(c *gin.Context) {
+	setNotificationRead(c, false)
+}
+
+// setNotificationRead handles both read and unread requests
+func setNotificationRead(c *gin.Context, read bool) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newNotificationService().SetRead(username.(string), c.Param("notificationID"), read); err != nil {
+		c.JSON(notificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Notification marked as read"
+	if !read {
+		message = "Notification marked as unread"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// @Summary Mark all notifications as read
+// @Description Marks every notification of the current user as read
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} map[string]string "message: All notifications marked as read"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /notifications/read-all [post]
+func MarkAllNotificationsRead(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newNotificationService().MarkAllRead(username.(string)); err != nil {
+		c.JSON(notificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "All notifications marked as read"})
+}
+
+// @Summary Get notification preferences
+// @Description Returns whether each notification type is on for the current user
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} map[string]bool "Notification type to enabled"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /notifications/preferences [get]
+func GetNotificationPreferences(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	preferences, err := newNotificationService().GetPreferences(username.(string))
+	if err != nil {
+		c.JSON(notificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// @Summary Update notification preferences
+// @Description Turns notification types on or off; types left out of the request keep their current setting
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param preferences body map[string]bool true "Notification type to enabled"
+// @Success 200 {object} map[string]bool "Updated preferences"
+// @Failure 400 {object} map[string]string "error: Unknown notification type"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Security BearerAuth
+// @Router /notifications/preferences [put]
+func UpdateNotificationPreferences(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req map[string]bool
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preferences, err := newNotificationService().UpdatePreferences(username.(string), req)
+	if err != nil {
+		c.JSON(notificationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
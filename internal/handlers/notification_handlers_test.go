@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupNotificationTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.GET("/notifications", GetNotifications)
+	r.POST("/notifications/read-all", MarkAllNotificationsRead)
+	r.POST("/notifications/:notificationID/read", MarkNotificationRead)
+	r.POST("/notifications/:notificationID/unread", MarkNotificationUnread)
+	r.GET("/notifications/preferences", GetNotificationPreferences)
+	r.PUT("/notifications/preferences", UpdateNotificationPreferences)
+	return r
+}
+
+func TestNotificationHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	user := models.User{Username: "notifyhandleruser", Password: "hashedpass"}
+	database.DB.Where("username = ?", user.Username).FirstOrCreate(&user)
+	database.DB.Exec("DELETE FROM notifications WHERE user_id = ?", user.ID)
+	database.DB.Exec("DELETE FROM notification_preferences WHERE user_id = ?", user.ID)
+
+	notification := models.Notification{UserID: user.ID, Type: models.NotificationTypeNewFollower, Message: "someone started following you"}
+	database.DB.Create(&notification)
+
+	router := setupNotificationTestRouter(user.Username)
+
+	t.Run("list notifications", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/notifications?unread=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"unread":1`)
+	})
+
+	t.Run("mark read and unread", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/notifications/%d/read", notification.ID), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("POST", fmt.Sprintf("/notifications/%d/unread", notification.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("POST", "/notifications/999999/read", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("mark all read", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/notifications/read-all", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("update preferences", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", "/notifications/preferences", strings.NewReader(`{"new_follower":false}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"new_follower":false`)
+
+		req, _ = http.NewRequest("PUT", "/notifications/preferences", strings.NewReader(`{"newsletter":false}`))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
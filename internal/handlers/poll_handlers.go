@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newPollService() *services.PollService {
+	return services.NewPollService(repositories.NewPollRepository(), repositories.NewPostModerationRepository(),
+		repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+}
+
+// pollErrorStatus maps poll errors to HTTP status codes
+func pollErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "post not found", "sub not found", "poll not found":
+		return http.StatusNotFound
+	case "this post is locked", "this post is archived", "this poll is closed",
+		"only the post author or a moderator can close this poll":
+		return http.StatusForbidden
+	case "you have already voted in this poll", "this poll is already closed":
+		return http.StatusConflict
+	case "failed to save vote", "failed to close poll", "failed to check moderators":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Vote in a poll
+// @Description Votes for one option of a poll post. Each user votes once, and closed polls take no votes. Returns the updated results, without vote counts while the poll hides them.
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param vote body models.PollVoteRequest true "Option to vote for"
+// @Success 200 {object} models.PollResponse
+// @Failure 400 {object} map[string]string "error: Bad request or unknown option"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Poll is closed, or the post is locked or archived"
+// @Failure 404 {object} map[string]string "error: Post or poll not found"
+// @Failure 409 {object} map[string]string "error: Already voted"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id}/poll/vote [post]
+func VotePoll(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.PollVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	poll, err := newPollService().Vote(c.Param("id"), username.(string), req)
+	if err != nil {
+		c.JSON(pollErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, poll)
+}
+
+// @Summary Close a poll
+// @Description Ends voting in a poll before its closing time (post author or sub moderators). Hidden results are revealed once the poll closes.
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} models.PollResponse
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only the author or a moderator can close this poll"
+// @Failure 404 {object} map[string]string "error: Post or poll not found"
+// @Failure 409 {object} map[string]string "error: Poll is already closed"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id}/poll/close [post]
+func ClosePoll(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	poll, err := newPollService().Close(c.Param("id"), username.(string))
+	if err != nil {
+		c.JSON(pollErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, poll)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPollTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.POST("/posts", CreatePost)
+	r.POST("/posts/:id/poll/vote", VotePoll)
+	r.POST("/posts/:id/poll/close", ClosePoll)
+	return r
+}
+
+func TestPollHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	author := models.User{Username: "pollhandlerauthor", Password: "hashedpass"}
+	voter := models.User{Username: "pollhandlervoter", Password: "hashedpass"}
+	database.DB.Where("username = ?", author.Username).FirstOrCreate(&author)
+	database.DB.Where("username = ?", voter.Username).FirstOrCreate(&voter)
+	sub := models.Sub{Name: "pollhandlersub", OwnerID: author.ID}
+	database.DB.Create(&sub)
+
+	authorRouter := setupPollTestRouter(author.Username)
+	voterRouter := setupPollTestRouter(voter.Username)
+
+	send := func(router *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(authorRouter, "/posts", map[string]interface{}{"title": "One option", "sub_id": sub.ID,
+		"poll": map[string]interface{}{"options": []string{"Only"}}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = send(authorRouter, "/posts", map[string]interface{}{"title": "Lunch?", "sub_id": sub.ID,
+		"poll": map[string]interface{}{"options": []string{"Pizza", "Salad"}}})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var post models.Post
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &post))
+	var poll models.Poll
+	require.NoError(t, database.DB.Preload("Options").Where("post_id = ?", post.ID).First(&poll).Error)
+
+	w = send(voterRouter, fmt.Sprintf("/posts/%d/poll/vote", post.ID), models.PollVoteRequest{OptionID: poll.Options[0].ID})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var results models.PollResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+	assert.Equal(t, int64(1), results.TotalVotes)
+
+	w = send(voterRouter, fmt.Sprintf("/posts/%d/poll/vote", post.ID), models.PollVoteRequest{OptionID: poll.Options[1].ID})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = send(voterRouter, fmt.Sprintf("/posts/%d/poll/close", post.ID), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = send(authorRouter, fmt.Sprintf("/posts/%d/poll/close", post.ID), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = send(authorRouter, "/posts/999999/poll/close", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
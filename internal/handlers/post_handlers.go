@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
 	models "github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
 	"github.com/gin-gonic/gin"
 )
@@ -29,11 +31,17 @@ func GetPostByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, postResponse)
+	// Whether the post is saved, which poll option was voted for and whether it is blurred depend on who is viewing it
+	posts := []models.PostResponse{*postResponse}
+	repositories.MarkSavedPosts(c.GetString("username"), posts)
+	repositories.MarkPollVotes(c.GetString("username"), posts)
+	repositories.MarkSensitivePosts(c.GetString("username"), posts)
+
+	c.JSON(http.StatusOK, posts[0])
 }
 
 // @Summary Create a new post
-// @Description Creates a new post for the authenticated user
+// @Description Creates a new post for the authenticated user. Set url to create a link post, or poll to create a poll post with 2 to 10 options; their text is optional. A link post's title, description and thumbnail are fetched into its link preview. Set status to draft to save the post privately, or scheduled_at to publish it later; drafts and scheduled posts are managed under /user/drafts.
 // @Tags Posts
 // @Accept json
 // @Produce json
@@ -41,6 +49,7 @@ func GetPostByID(c *gin.Context) {
 // @Success 201 {object} interface{} "Created post with details"
 // @Failure 400 {object} map[string]string "error: Bad request or validation error"
 // @Failure 401 {object} map[string]string "error: must login to post"
+// @Failure 403 {object} map[string]string "error: Sub posting restrictions not met"
 // @Failure 500 {object} map[string]string "error: Internal server error"
 // @Security BearerAuth
 // @Router /posts/ [post]
@@ -63,8 +72,32 @@ func CreatePost(c *gin.Context) {
 	username, _ := c.Get("username")
 	postResponse, err := services.CreatePost(username.(string), post)
 	if err != nil {
+		// Sub posting restrictions are reported as forbidden, invalid flair as a bad request
+		switch err.Error() {
+		case "flair not found", "this flair cannot be used as post flair", "media not found", "content is required",
+			"link must be a valid http or https URL", "links to private addresses are not allowed", "a post can have a link or an image, not both",
+			"a poll can't have a link or an image", "poll options can't be empty",
+			"the closing time must be in the future", "a draft can't have a scheduled time", "a scheduled post needs a scheduled time",
+			"status must be published, draft or scheduled", "the scheduled time must be in the future", "the poll must close after the post is published":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case "this post type is not allowed in this sub",
+			"your account is too new to post in this sub",
+			"you don't have enough karma to post in this sub",
+			"only approved submitters can post in this sub",
+			"you must be a member to post in this sub",
+			"only moderators can assign this flair",
+			"you can only use media you uploaded":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.HasPrefix(err.Error(), "a poll needs between") || strings.HasPrefix(err.Error(), "poll options must be") ||
+			strings.HasPrefix(err.Error(), "posts can be scheduled at most") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err,
+			"error": err.Error(),
 		})
 		return
 	}
@@ -72,6 +105,52 @@ func CreatePost(c *gin.Context) {
 	c.JSON(http.StatusCreated, postResponse)
 }
 
+// @Summary Update a post
+// @Description Edits a post's content (post author only); each edit is kept in the post's edit history
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param post body models.PostUpdateRequest true "New post content"
+// @Success 200 {object} models.Post "Updated post"
+// @Failure 400 {object} map[string]string "error: Bad request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Can only edit own posts"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /posts/{id} [put]
+func UpdatePost(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.PostUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	post, err := services.UpdatePost(c.Param("id"), username.(string), req)
+	if err != nil {
+		switch err.Error() {
+		case "user not found", "post not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "unauthorized: can only edit own posts", "this post is archived":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "failed to update post":
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
 // @Summary Get comments by post ID
 // @Description Retrieves all comments for a specific post
 // @Tags Posts
@@ -84,7 +163,7 @@ func CreatePost(c *gin.Context) {
 func GetCommentsByPostID(c *gin.Context) {
 	postID := c.Param("postID") // Get postID from URL parameter
 
-	comments, err := services.GetCommentsByPostID(postID)
+	comments, err := services.GetCommentsByPostID(postID, c.GetString("username"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err,
@@ -95,16 +174,18 @@ func GetCommentsByPostID(c *gin.Context) {
 }
 
 // @Summary Get all posts
-// @Description Retrieves all posts with user details and comment/vote counts
+// @Description Retrieves all posts with user details and comment/vote counts. The token is optional on /feed/all; logged-out viewers only see posts in public subs, without NSFW content
 // @Tags Posts
 // @Produce json
+// @Param domain query string false "Only link posts to this domain, e.g. example.com"
 // @Success 200 {array} interface{} "Array of posts with user and vote details"
 // @Failure 500 {object} map[string]string "error: Internal server error"
 // @Security BearerAuth
 // @Router /posts/ [get]
+// @Router /feed/all [get]
 func GetPosts(c *gin.Context) {
 
-	postResponse, err := services.GetPosts()
+	postResponse, err := services.GetPosts(c.GetString("username"), c.Query("domain"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err,
@@ -123,6 +204,7 @@ func GetPosts(c *gin.Context) {
 // @Success 201 {object} interface{} "Created comment with details"
 // @Failure 400 {object} map[string]string "error: Bad request or validation error"
 // @Failure 401 {object} map[string]string "error: Unauthorized or user not found"
+// @Failure 403 {object} map[string]string "error: Post is locked or archived"
 // @Failure 404 {object} map[string]string "error: Post not found"
 // @Failure 500 {object} map[string]string "error: Internal server error"
 // @Security BearerAuth
@@ -152,23 +234,33 @@ func CreateComment(c *gin.Context) {
 
 	// Ensure the post exists before adding a comment
 	var post models.Post
-	if err := db.DB.First(&post, commentReq.PostID).Error; err != nil {
+	if err := db.DB.Scopes(repositories.PublishedPosts).First(&post, commentReq.PostID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
 		return
 	}
 
 	comment, err := services.CreateComment(username.(string), commentReq, post)
 	if err != nil {
+		if err.Error() == "this post is locked" || err.Error() == "this post is archived" || err.Error() == "you cannot interact with this user" ||
+			err.Error() == "you can only use media you uploaded" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "media not found" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"id":        comment.ID,
-		"content":   comment.Content,
-		"postID":    comment.PostID,
-		"username":  user.Username,
-		"imageURL":  comment.ImageURL,
-		"createdAt": comment.CreatedAt.Format("2006-01-02 15:04:05"),
+		"id":           comment.ID,
+		"content":      comment.Content,
+		"content_html": comment.ContentHTML,
+		"postID":       comment.PostID,
+		"username":     user.Username,
+		"image":        repositories.ImageFor(comment.MediaID, comment.ImageURL),
+		"createdAt":    comment.CreatedAt.Format("2006-01-02 15:04:05"),
 	})
 }
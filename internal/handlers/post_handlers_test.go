@@ -136,6 +136,37 @@ func TestCreatePostHandler(t *testing.T) {
 	assert.Equal(t, float64(user.ID), response["UserID"])
 }
 
+func TestCreateLinkPostHandler(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	router := setupPostTestRouter()
+
+	user := models.User{Username: "testuser"}
+	database.DB.Where("username = ?", "testuser").FirstOrCreate(&user)
+	sub := models.Sub{Name: "createlinkhandlersub", OwnerID: user.ID}
+	database.DB.Create(&sub)
+
+	for link, expected := range map[string]string{
+		"not a link":             "link must be a valid http or https URL",
+		"http://127.0.0.1:8080/": "links to private addresses are not allowed",
+	} {
+		jsonData, _ := json.Marshal(map[string]interface{}{"title": "Link", "sub_id": sub.ID, "url": link})
+		req, _ := http.NewRequest("POST", "/posts", bytes.NewBuffer(jsonData))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, expected, response["error"])
+	}
+}
+
 func TestGetPostsHandler(t *testing.T) {
 	if database.DB == nil {
 		t.Skip("Database not available, skipping integration test")
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newPostModerationService() *services.PostModerationService {
+	return services.NewPostModerationService(repositories.NewPostModerationRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+}
+
+// postModerationErrorStatus maps post moderation errors to HTTP status codes
+func postModerationErrorStatus(err error) int {
+	switch err.Error() {
+	case "sub not found", "user not found", "post not found":
+		return http.StatusNotFound
+	case "only moderators can pin or lock posts", "only the post author or a moderator can delete this post",
+		"only the post author or a moderator can change post flags", "only a moderator can remove post flags":
+		return http.StatusForbidden
+	case "failed to fetch pinned posts", "failed to update post", "failed to delete post", "failed to check moderators":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Pin a post
+// @Description Pins a post to the top of its sub (sub moderators only)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} map[string]interface{} "message: Post pinned"
+// @Failure 400 {object} map[string]string "error: Pinned post limit reached"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can pin posts"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id}/pin [post]
+func PinPost(c *gin.Context) {
+	setPostPinned(c, true)
+}
+
+// @Summary Unpin a post
+// @Description Removes a post from its sub's pinned posts (sub moderators only)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} map[string]interface{} "message: Post unpinned"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can unpin posts"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id}/pin [delete]
+func UnpinPost(c *gin.Context) {
+	setPostPinned(c, false)
+}
+
+// @Summary Lock a post
+// @Description Locks a post so it takes no new comments or votes (sub moderators only)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} map[string]interface{} "message: Post locked"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can lock posts"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id}/lock [post]
+func LockPost(c *gin.Context) {
+	setPostLocked(c, true)
+}
+
+// @Summary Unlock a post
+// @Description Unlocks a post so it takes comments and votes again (sub moderators only)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} map[string]interface{} "message: Post unlocked"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only moderators can unlock posts"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id}/lock [delete]
+func UnlockPost(c *gin.Context) {
+	setPostLocked(c, false)
+}
+
+// @Summary Delete a post
+// @Description Deletes a post (post author or sub moderators). The post is shown as [deleted] and can be restored by an admin until the retention period ends
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} map[string]string "message: Post deleted successfully"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Not allowed to delete this post"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id} [delete]
+func DeletePost(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newPostModerationService().DeletePost(c.Param("id"), username.(string)); err != nil {
+		c.JSON(postModerationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Post deleted successfully"})
+}
+
+// @Summary Set post flags
+// @Description Marks a post as NSFW or a spoiler (post author or sub moderators); only moderators can clear the marks. Flags left out keep their value
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param flags body models.PostFlagsRequest true "Post flags"
+// @Success 200 {object} map[string]interface{} "message: Post flags updated"
+// @Failure 400 {object} map[string]string "error: Invalid request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Not allowed to change this post's flags"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id}/flags [put]
+func SetPostFlags(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.PostFlagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	post, err := newPostModerationService().SetFlags(c.Param("id"), username.(string), req)
+	if err != nil {
+		c.JSON(postModerationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Post flags updated", "nsfw": post.NSFW, "spoiler": post.Spoiler})
+}
+
+// setPostPinned handles both pinning and unpinning
+func setPostPinned(c *gin.Context, pinned bool) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	post, err := newPostModerationService().SetPinned(c.Param("id"), username.(string), pinned)
+	if err != nil {
+		c.JSON(postModerationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Post unpinned"
+	if pinned {
+		message = "Post pinned"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message, "pinned": post.Pinned})
+}
+
+// setPostLocked handles both locking and unlocking
+func setPostLocked(c *gin.Context, locked bool) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	post, err := newPostModerationService().SetLocked(c.Param("id"), username.(string), locked)
+	if err != nil {
+		c.JSON(postModerationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Post unlocked"
+	if locked {
+		message = "Post locked"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message, "locked": post.Locked})
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupPostModerationTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.POST("/posts/:id/pin", PinPost)
+	r.DELETE("/posts/:id/pin", UnpinPost)
+	r.POST("/posts/:id/lock", LockPost)
+	r.DELETE("/posts/:id/lock", UnlockPost)
+	r.PUT("/posts/:id/flags", SetPostFlags)
+	r.POST("/vote/upvote", UpvotePost)
+	r.POST("/comments", CreateComment)
+	return r
+}
+
+func TestPostModerationHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	// Setup
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+
+	owner := models.User{Username: "pinhandlerowner", Password: "hashedpass"}
+	author := models.User{Username: "pinhandlerauthor", Password: "hashedpass"}
+	database.DB.Where("username = ?", owner.Username).FirstOrCreate(&owner)
+	database.DB.Where("username = ?", author.Username).FirstOrCreate(&author)
+
+	sub := models.Sub{Name: "pinhandlersub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	post := models.Post{Title: "Post", Content: "Content", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+
+	ownerRouter := setupPostModerationTestRouter(owner.Username)
+	authorRouter := setupPostModerationTestRouter(author.Username)
+
+	t.Run("non-moderator cannot pin", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/posts/%d/pin", post.ID), nil)
+		w := httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("moderator pins and unpins", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/posts/%d/pin", post.ID), nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"pinned":true`)
+
+		req, _ = http.NewRequest("DELETE", fmt.Sprintf("/posts/%d/pin", post.ID), nil)
+		w = httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"pinned":false`)
+	})
+
+	t.Run("locked post rejects votes and comments", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/posts/%d/lock", post.ID), nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("POST", "/vote/upvote", strings.NewReader(fmt.Sprintf(`{"postID":%d}`, post.ID)))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		req, _ = http.NewRequest("POST", "/comments", strings.NewReader(fmt.Sprintf(`{"postID":%d,"content":"Hi"}`, post.ID)))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("authors and moderators set post flags", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", fmt.Sprintf("/posts/%d/flags", post.ID), strings.NewReader(`{"nsfw":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		authorRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"nsfw":true`)
+		assert.Contains(t, w.Body.String(), `"spoiler":false`)
+
+		req, _ = http.NewRequest("PUT", fmt.Sprintf("/posts/%d/flags", post.ID), strings.NewReader(`{"spoiler":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"nsfw":true`)
+		assert.Contains(t, w.Body.String(), `"spoiler":true`)
+
+		bystander := models.User{Username: "pinhandlerbystander", Password: "hashedpass"}
+		database.DB.Where("username = ?", bystander.Username).FirstOrCreate(&bystander)
+		req, _ = http.NewRequest("PUT", fmt.Sprintf("/posts/%d/flags", post.ID), strings.NewReader(`{"nsfw":false}`))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		setupPostModerationTestRouter(bystander.Username).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("post not found", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/posts/999999/lock", nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
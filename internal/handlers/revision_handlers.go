@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newRevisionService() *services.RevisionService {
+	return services.NewRevisionService(repositories.NewRevisionRepository(), repositories.NewUserRepository(), repositories.NewSubSettingsRepository())
+}
+
+// revisionErrorStatus maps edit history errors to HTTP status codes
+func revisionErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "sub not found", "post not found", "comment not found", "revision not found":
+		return http.StatusNotFound
+	case "only the author or a moderator can view the edit history":
+		return http.StatusForbidden
+	case "failed to fetch revisions", "failed to check moderators":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Get a post's edit history
+// @Description Lists every version of a post, oldest first (post author and sub moderators only)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} models.RevisionListResponse "Post revisions"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only the author or a moderator can view the edit history"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id}/revisions [get]
+func GetPostRevisions(c *gin.Context) {
+	getRevisions(c, models.ContentTypePost)
+}
+
+// @Summary Diff two versions of a post
+// @Description Word-level diff between two revisions of a post; defaults to the latest edit (post author and sub moderators only)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param from query int false "Older revision number (default: the one before to)"
+// @Param to query int false "Newer revision number (default: the latest)"
+// @Success 200 {object} models.RevisionDiffResponse "Diff between the revisions"
+// @Failure 400 {object} map[string]string "error: Invalid revision number"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only the author or a moderator can view the edit history"
+// @Failure 404 {object} map[string]string "error: Post or revision not found"
+// @Security BearerAuth
+// @Router /posts/{id}/revisions/diff [get]
+func GetPostRevisionDiff(c *gin.Context) {
+	getRevisionDiff(c, models.ContentTypePost)
+}
+
+// @Summary Get a comment's edit history
+// @Description Lists every version of a comment, oldest first (comment author and sub moderators only)
+// @Tags comments
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Success 200 {object} models.RevisionListResponse "Comment revisions"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only the author or a moderator can view the edit history"
+// @Failure 404 {object} map[string]string "error: Comment not found"
+// @Security BearerAuth
+// @Router /comments/{id}/revisions [get]
+func GetCommentRevisions(c *gin.Context) {
+	getRevisions(c, models.ContentTypeComment)
+}
+
+// @Summary Diff two versions of a comment
+// @Description Word-level diff between two revisions of a comment; defaults to the latest edit (comment author and sub moderators only)
+// @Tags comments
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param from query int false "Older revision number (default: the one before to)"
+// @Param to query int false "Newer revision number (default: the latest)"
+// @Success 200 {object} models.RevisionDiffResponse "Diff between the revisions"
+// @Failure 400 {object} map[string]string "error: Invalid revision number"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only the author or a moderator can view the edit history"
+// @Failure 404 {object} map[string]string "error: Comment or revision not found"
+// @Security BearerAuth
+// @Router /comments/{id}/revisions/diff [get]
+func GetCommentRevisionDiff(c *gin.Context) {
+	getRevisionDiff(c, models.ContentTypeComment)
+}
+
+// getRevisions handles listing the edit history of posts and comments
+func getRevisions(c *gin.Context, contentType string) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	revisions, err := newRevisionService().GetRevisions(contentType, c.Param("id"), username.(string))
+	if err != nil {
+		c.JSON(revisionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// getRevisionDiff handles diffs between revisions of posts and comments
+func getRevisionDiff(c *gin.Context, contentType string) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	diff, err := newRevisionService().GetDiff(contentType, c.Param("id"), username.(string), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(revisionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
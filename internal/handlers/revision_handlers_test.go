@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRevisionTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.PUT("/posts/:id", UpdatePost)
+	r.GET("/posts/:id/revisions", GetPostRevisions)
+	r.GET("/posts/:id/revisions/diff", GetPostRevisionDiff)
+	r.GET("/comments/:id/revisions", GetCommentRevisions)
+	r.GET("/comments/:id/revisions/diff", GetCommentRevisionDiff)
+	return r
+}
+
+func TestRevisionHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	author := models.User{Username: "revisionhandlerauthor", Password: "hashedpass"}
+	stranger := models.User{Username: "revisionhandlerstranger", Password: "hashedpass"}
+	database.DB.Where("username = ?", author.Username).FirstOrCreate(&author)
+	database.DB.Where("username = ?", stranger.Username).FirstOrCreate(&stranger)
+
+	sub := models.Sub{Name: "revisionhandlersub", OwnerID: author.ID}
+	database.DB.Where("name = ?", sub.Name).FirstOrCreate(&sub)
+	post := models.Post{Title: "Revisions", Content: "Before", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+	comment := models.Comment{PostID: post.ID, UserID: author.ID, Content: "Comment"}
+	database.DB.Create(&comment)
+
+	router := setupRevisionTestRouter(author.Username)
+
+	t.Run("edit a post and view its history", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", fmt.Sprintf("/posts/%d", post.ID), strings.NewReader(`{"content":"After"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("GET", fmt.Sprintf("/posts/%d/revisions", post.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"revision":2`)
+
+		req, _ = http.NewRequest("GET", fmt.Sprintf("/posts/%d/revisions/diff?from=1&to=2", post.ID), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"op":"insert"`)
+	})
+
+	t.Run("invalid revision numbers", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/comments/%d/revisions/diff?to=abc", comment.ID), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/comments/%d/revisions", comment.ID), nil)
+		w := httptest.NewRecorder()
+		setupRevisionTestRouter(stranger.Username).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
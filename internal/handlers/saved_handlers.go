@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newSavedService() *services.SavedService {
+	return services.NewSavedService(repositories.NewSavedRepository(), repositories.NewUserRepository())
+}
+
+// savedErrorStatus maps saved and hidden post errors to HTTP status codes
+func savedErrorStatus(err error) int {
+	switch err.Error() {
+	case "user not found", "post not found", "comment not found":
+		return http.StatusNotFound
+	case "failed to save item", "failed to unsave item", "failed to fetch saved items",
+		"failed to hide post", "failed to unhide post", "failed to fetch hidden posts":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Save a post
+// @Description Saves a post for later, optionally under a category; saving it again moves it to the new category
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ID"
+// @Param request body models.SaveRequest false "Optional category"
+// @Success 200 {object} map[string]string "message: Post saved"
+// @Failure 400 {object} map[string]string "error: Invalid category"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id}/save [post]
+func SavePost(c *gin.Context) {
+	saveContent(c, models.ContentTypePost, "Post saved")
+}
+
+// @Summary Unsave a post
+// @Description Removes a post from the user's saved items
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} map[string]string "message: Post unsaved"
+// @Failure 400 {object} map[string]string "error: Post is not saved"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Security BearerAuth
+// @Router /posts/{id}/save [delete]
+func UnsavePost(c *gin.Context) {
+	unsaveContent(c, models.ContentTypePost, "Post unsaved")
+}
+
+// @Summary Save a comment
+// @Description Saves a comment for later, optionally under a category; saving it again moves it to the new category
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param request body models.SaveRequest false "Optional category"
+// @Success 200 {object} map[string]string "message: Comment saved"
+// @Failure 400 {object} map[string]string "error: Invalid category"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Comment not found"
+// @Security BearerAuth
+// @Router /comments/{id}/save [post]
+func SaveComment(c *gin.Context) {
+	saveContent(c, models.ContentTypeComment, "Comment saved")
+}
+
+// @Summary Unsave a comment
+// @Description Removes a comment from the user's saved items
+// @Tags comments
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Success 200 {object} map[string]string "message: Comment unsaved"
+// @Failure 400 {object} map[string]string "error: Comment is not saved"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Security BearerAuth
+// @Router /comments/{id}/save [delete]
+func UnsaveComment(c *gin.Context) {
+	unsaveContent(c, models.ContentTypeComment, "Comment unsaved")
+}
+
+// @Summary Hide a post
+// @Description Hides a post from the user's post listings, sub listings and feeds
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} map[string]string "message: Post hidden"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 404 {object} map[string]string "error: Post not found"
+// @Security BearerAuth
+// @Router /posts/{id}/hide [post]
+func HidePost(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newSavedService().HidePost(c.Param("id"), username.(string)); err != nil {
+		c.JSON(savedErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Post hidden"})
+}
+
+// @Summary Unhide a post
+// @Description Shows a hidden post in the user's listings again
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ID"
+// @Success 200 {object} map[string]string "message: Post unhidden"
+// @Failure 400 {object} map[string]string "error: Post is not hidden"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Security BearerAuth
+// @Router /posts/{id}/hide [delete]
+func UnhidePost(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newSavedService().UnhidePost(c.Param("id"), username.(string)); err != nil {
+		c.JSON(savedErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Post unhidden"})
+}
+
+// @Summary Get saved posts and comments
+// @Description Lists the user's saved posts and comments, most recently saved first, with every category in use
+// @Tags Users
+// @Produce json
+// @Param type query string false "Only list posts or comments (post, comment)"
+// @Param category query string false "Only list one category"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.SavedListResponse "One page of saved items"
+// @Failure 400 {object} map[string]string "error: Invalid type"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Security BearerAuth
+// @Router /user/saved [get]
+func GetSavedItems(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	saved, err := newSavedService().GetSaved(username.(string), c.Query("type"), c.Query("category"), page, limit)
+	if err != nil {
+		c.JSON(savedErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// @Summary Get hidden posts
+// @Description Lists the posts the user has hidden, most recently hidden first
+// @Tags Users
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Success 200 {object} models.HiddenPostListResponse "One page of hidden posts"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Security BearerAuth
+// @Router /user/hidden [get]
+func GetHiddenPosts(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	hidden, err := newSavedService().GetHidden(username.(string), page, limit)
+	if err != nil {
+		c.JSON(savedErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hidden)
+}
+
+// saveContent handles saving posts and comments; the request body is optional
+func saveContent(c *gin.Context, contentType, message string) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req models.SaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := newSavedService().Save(contentType, c.Param("id"), username.(string), req); err != nil {
+		c.JSON(savedErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// unsaveContent handles unsaving posts and comments
+func unsaveContent(c *gin.Context, contentType, message string) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newSavedService().Unsave(contentType, c.Param("id"), username.(string)); err != nil {
+		c.JSON(savedErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
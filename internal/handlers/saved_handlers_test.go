@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSavedTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.POST("/posts/:id/save", SavePost)
+	r.DELETE("/posts/:id/save", UnsavePost)
+	r.POST("/posts/:id/hide", HidePost)
+	r.DELETE("/posts/:id/hide", UnhidePost)
+	r.POST("/comments/:id/save", SaveComment)
+	r.DELETE("/comments/:id/save", UnsaveComment)
+	r.GET("/user/saved", GetSavedItems)
+	r.GET("/user/hidden", GetHiddenPosts)
+	return r
+}
+
+func TestSavedHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	user := models.User{Username: "savedhandleruser", Password: "hashedpass"}
+	database.DB.Where("username = ?", user.Username).FirstOrCreate(&user)
+	database.DB.Exec("DELETE FROM saved_items WHERE user_id = ?", user.ID)
+	database.DB.Exec("DELETE FROM hidden_posts WHERE user_id = ?", user.ID)
+
+	sub := models.Sub{Name: "savedhandlersub", OwnerID: user.ID}
+	database.DB.Where("name = ?", sub.Name).FirstOrCreate(&sub)
+	post := models.Post{Title: "Saved", Content: "Content", SubID: sub.ID, UserID: user.ID}
+	database.DB.Create(&post)
+
+	router := setupSavedTestRouter(user.Username)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("save with and without a category", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("POST", fmt.Sprintf("/posts/%d/save", post.ID), "").Code)
+		assert.Equal(t, http.StatusOK, serve("POST", fmt.Sprintf("/posts/%d/save", post.ID), `{"category":"later"}`).Code)
+
+		w := serve("GET", "/user/saved?category=later", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"total":1`)
+		assert.Contains(t, w.Body.String(), `"saved":true`)
+
+		assert.Equal(t, http.StatusBadRequest, serve("GET", "/user/saved?type=user", "").Code)
+		assert.Equal(t, http.StatusNotFound, serve("POST", "/comments/999999/save", "").Code)
+	})
+
+	t.Run("unsave", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("DELETE", fmt.Sprintf("/posts/%d/save", post.ID), "").Code)
+		assert.Equal(t, http.StatusBadRequest, serve("DELETE", fmt.Sprintf("/posts/%d/save", post.ID), "").Code)
+	})
+
+	t.Run("hide and unhide", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("POST", fmt.Sprintf("/posts/%d/hide", post.ID), "").Code)
+
+		w := serve("GET", "/user/hidden", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"total":1`)
+
+		assert.Equal(t, http.StatusOK, serve("DELETE", fmt.Sprintf("/posts/%d/hide", post.ID), "").Code)
+		assert.Equal(t, http.StatusBadRequest, serve("DELETE", fmt.Sprintf("/posts/%d/hide", post.ID), "").Code)
+	})
+}
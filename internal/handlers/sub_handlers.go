@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
@@ -9,7 +11,7 @@ import (
 )
 
 // @Summary Get all subs
-// @Description Returns all public subs and private subs the user is authorized to access
+// @Description Returns all public subs and private subs the user is authorized to access. The token is optional; logged-out viewers get public subs without NSFW subs
 // @Tags Subs
 // @Produce json
 // @Success 200 {array} interface{} "Array of available subs"
@@ -60,7 +62,7 @@ func CreateSub(c *gin.Context) {
 	newSub, err := services.CreateSub(username.(string), subRequest)
 	if err != nil {
 		// Check for specific error types
-		if err.Error() == "sub name already taken" {
+		if err.Error() == "sub name already taken" || strings.HasPrefix(err.Error(), "sub name must be") {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -143,7 +145,9 @@ func InviteUser(c *gin.Context) {
 // @Param inviteID path string true "Invite ID"
 // @Success 200 {object} map[string]string "message: You have joined the sub"
 // @Failure 401 {object} map[string]string "error: Unauthorized"
-// @Failure 404 {object} map[string]string "error: Invitation not found or expired"
+// @Failure 403 {object} map[string]string "error: Not the invitee"
+// @Failure 404 {object} map[string]string "error: Invitation not found"
+// @Failure 409 {object} map[string]string "error: Invitation is no longer pending or has expired"
 // @Failure 500 {object} map[string]string "error: Internal server error"
 // @Security BearerAuth
 // @Router /user/invite/{inviteID}/accept [post]
@@ -156,7 +160,7 @@ func AcceptInvite(c *gin.Context) {
 
 	err := services.AcceptInvite(c.Param("inviteID"), username.(string))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(inviteErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -168,8 +172,11 @@ func AcceptInvite(c *gin.Context) {
 // @Tags Subs
 // @Produce json
 // @Param subID path string true "Sub ID"
+// @Param flair query string false "Filter by post flair ID or text"
+// @Param domain query string false "Only link posts to this domain, e.g. example.com"
 // @Success 200 {array} interface{} "Array of posts in the sub"
 // @Failure 401 {object} map[string]string "error: Unauthorized access to private sub"
+// @Failure 403 {object} map[string]string "error: NSFW sub hidden by the viewer's content settings"
 // @Failure 500 {object} map[string]string "error: Internal server error"
 // @Security BearerAuth
 // @Router /sub/sub/{subID}/posts [get]
@@ -180,8 +187,13 @@ func ListSubPosts(c *gin.Context) {
 		user = username.(string)
 	}
 
-	formattedPosts, err := services.ListSubPosts(c.Param("subID"), user)
+	formattedPosts, err := services.ListSubPosts(c.Param("subID"), user, c.Query("flair"), c.Query("domain"))
 	if err != nil {
+		// NSFW subs are closed to viewers who hide NSFW content
+		if err.Error() == "this sub is marked NSFW" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -295,7 +307,7 @@ func UpdateSub(c *gin.Context) {
 }
 
 // @Summary Delete a sub
-// @Description Deletes a subreddit completely (only sub owner can delete, cascade deletes memberships, posts, comments)
+// @Description Deletes a subreddit (only sub owner can delete). The sub can be restored by an admin until the retention period ends, then it is purged with its posts and memberships
 // @Tags Subs
 // @Produce json
 // @Param subID path string true "Sub ID"
@@ -395,3 +407,41 @@ func GetPendingInvites(c *gin.Context) {
 
 	c.JSON(http.StatusOK, invites)
 }
+
+// ResolveSubName is a middleware for name-based sub routes. It looks up the sub named in the
+// :name parameter and exposes its ID as the :subID parameter, so the ID-based handlers can be reused.
+// Private subs the user can't see are not found, just like subs that don't exist.
+func ResolveSubName(c *gin.Context) {
+	subID, err := services.ResolveSubName(c.Param("name"), c.GetString("username"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Params = append(c.Params, gin.Param{Key: "subID", Value: strconv.FormatUint(uint64(subID), 10)})
+	c.Next()
+}
+
+// @Summary Get sub by name
+// @Description Returns a sub's details by its case-insensitive name (private subs: members/owners only)
+// @Tags Subs
+// @Produce json
+// @Param name path string true "Sub name"
+// @Success 200 {object} models.SubResponse "Sub details"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /r/{name} [get]
+func GetSubByName(c *gin.Context) {
+	username := ""
+	if userValue, exists := c.Get("username"); exists {
+		username = userValue.(string)
+	}
+
+	sub, err := services.GetSubByName(c.Param("name"), username)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
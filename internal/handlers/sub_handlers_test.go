@@ -141,6 +141,27 @@ func TestCreateSubHandler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		assert.Contains(t, w.Body.String(), "sub name already taken")
 	})
+
+	t.Run("create sub with name differing only in case", func(t *testing.T) {
+		subRequest := `{"name":"NewPublic","description":"Duplicate Sub","private":false}`
+		req, _ := http.NewRequest("POST", "/subs", strings.NewReader(subRequest))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "sub name already taken")
+	})
+
+	t.Run("create sub with invalid name", func(t *testing.T) {
+		subRequest := `{"name":"bad name!","description":"Invalid Sub","private":false}`
+		req, _ := http.NewRequest("POST", "/subs", strings.NewReader(subRequest))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestJoinSubHandler(t *testing.T) {
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newSubSettingsService() *services.SubSettingsService {
+	return services.NewSubSettingsService(repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+}
+
+// subSettingsErrorStatus maps sub settings errors to HTTP status codes
+func subSettingsErrorStatus(err error) int {
+	switch err.Error() {
+	case "sub not found", "user not found", "submitter user not found", "moderator user not found":
+		return http.StatusNotFound
+	case "only the sub owner can update sub settings",
+		"only the sub owner can manage approved submitters",
+		"only the sub owner can manage moderators",
+		"you must be a member to view this sub's settings":
+		return http.StatusForbidden
+	case "failed to update sub settings", "failed to fetch sub rules",
+		"failed to fetch approved submitters", "failed to add approved submitter",
+		"failed to remove approved submitter", "failed to check approved submitters",
+		"failed to check membership", "failed to check moderators", "failed to fetch moderators",
+		"failed to add moderator", "failed to remove moderator":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Get sub settings
+// @Description Returns a sub's rules, sidebar and posting settings (private subs: members/owners only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Success 200 {object} models.SubSettingsResponse "Sub settings"
+// @Failure 400 {object} map[string]string "error: Invalid sub ID"
+// @Failure 403 {object} map[string]string "error: Not a member of private sub"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/settings [get]
+func GetSubSettings(c *gin.Context) {
+	username := ""
+	if userValue, exists := c.Get("username"); exists {
+		username = userValue.(string)
+	}
+
+	settings, err := newSubSettingsService().GetSettings(c.Param("subID"), username)
+	if err != nil {
+		c.JSON(subSettingsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update sub settings
+// @Description Updates a sub's rules, sidebar, allowed post types, minimum account age, minimum karma, restricted posting, NSFW flag and join mode (owner only)
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param settings body models.SubSettingsRequest true "Settings to change (omitted fields are left unchanged)"
+// @Success 200 {object} models.SubSettingsResponse "Updated sub settings"
+// @Failure 400 {object} map[string]string "error: Invalid settings"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can update settings"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /sub/{subID}/settings [patch]
+func UpdateSubSettings(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var settingsRequest models.SubSettingsRequest
+	if err := c.ShouldBindJSON(&settingsRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := newSubSettingsService().UpdateSettings(c.Param("subID"), username.(string), settingsRequest)
+	if err != nil {
+		c.JSON(subSettingsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// @Summary Get approved submitters
+// @Description Lists users approved to post in a sub with restricted posting (owner only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Success 200 {array} models.ApprovedSubmitterResponse "Approved submitters"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage approved submitters"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/approved-submitters [get]
+func GetApprovedSubmitters(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	submitters, err := newSubSettingsService().GetApprovedSubmitters(c.Param("subID"), username.(string))
+	if err != nil {
+		c.JSON(subSettingsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, submitters)
+}
+
+// @Summary Add approved submitter
+// @Description Approves a user to post in a sub with restricted posting (owner only)
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param submitter body models.ApprovedSubmitterRequest true "User to approve"
+// @Success 200 {object} map[string]string "message: User approved to post"
+// @Failure 400 {object} map[string]string "error: Bad request or user already approved"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage approved submitters"
+// @Failure 404 {object} map[string]string "error: Sub or user not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/approved-submitters [post]
+func AddApprovedSubmitter(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var submitterRequest models.ApprovedSubmitterRequest
+	if err := c.ShouldBindJSON(&submitterRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := newSubSettingsService().AddApprovedSubmitter(c.Param("subID"), username.(string), submitterRequest.Username)
+	if err != nil {
+		c.JSON(subSettingsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": submitterRequest.Username + " approved to post"})
+}
+
+// @Summary Remove approved submitter
+// @Description Revokes a user's approval to post in a sub with restricted posting (owner only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param username path string true "Username to remove"
+// @Success 200 {object} map[string]string "message: Approval removed"
+// @Failure 400 {object} map[string]string "error: User is not an approved submitter"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage approved submitters"
+// @Failure 404 {object} map[string]string "error: Sub or user not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/approved-submitters/{username} [delete]
+func RemoveApprovedSubmitter(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := newSubSettingsService().RemoveApprovedSubmitter(c.Param("subID"), username.(string), c.Param("username"))
+	if err != nil {
+		c.JSON(subSettingsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Approval removed for " + c.Param("username")})
+}
+
+// @Summary Get sub moderators
+// @Description Lists the users who can moderate a sub, in addition to its owner (owner only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Success 200 {array} models.ModeratorResponse "Sub moderators"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage moderators"
+// @Failure 404 {object} map[string]string "error: Sub not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/moderators [get]
+func GetSubModerators(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	moderators, err := newSubSettingsService().GetModerators(c.Param("subID"), username.(string))
+	if err != nil {
+		c.JSON(subSettingsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, moderators)
+}
+
+// @Summary Add sub moderator
+// @Description Grants a user moderation rights in a sub (owner only)
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param moderator body models.ModeratorRequest true "User to make moderator"
+// @Success 200 {object} map[string]string "message: User is now a moderator"
+// @Failure 400 {object} map[string]string "error: Bad request or user already a moderator"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage moderators"
+// @Failure 404 {object} map[string]string "error: Sub or user not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/moderators [post]
+func AddSubModerator(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var moderatorRequest models.ModeratorRequest
+	if err := c.ShouldBindJSON(&moderatorRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := newSubSettingsService().AddModerator(c.Param("subID"), username.(string), moderatorRequest.Username)
+	if err != nil {
+		c.JSON(subSettingsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": moderatorRequest.Username + " is now a moderator"})
+}
+
+// @Summary Remove sub moderator
+// @Description Revokes a user's moderation rights in a sub (owner only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param username path string true "Username to remove"
+// @Success 200 {object} map[string]string "message: Moderator removed"
+// @Failure 400 {object} map[string]string "error: User is not a moderator"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can manage moderators"
+// @Failure 404 {object} map[string]string "error: Sub or user not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/moderators/{username} [delete]
+func RemoveSubModerator(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := newSubSettingsService().RemoveModerator(c.Param("subID"), username.(string), c.Param("username"))
+	if err != nil {
+		c.JSON(subSettingsErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Moderator removed: " + c.Param("username")})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSubSettingsTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.GET("/:subID/settings", GetSubSettings)
+	r.PATCH("/:subID/settings", UpdateSubSettings)
+	r.GET("/:subID/approved-submitters", GetApprovedSubmitters)
+	r.POST("/:subID/approved-submitters", AddApprovedSubmitter)
+	r.DELETE("/:subID/approved-submitters/:username", RemoveApprovedSubmitter)
+	return r
+}
+
+func TestSubSettingsHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	// Setup
+	database.DB.Exec("DELETE FROM sub_rules")
+	database.DB.Exec("DELETE FROM sub_approved_submitters")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+
+	owner := models.User{Username: "settingshandlerowner", Password: "hashedpass"}
+	member := models.User{Username: "settingshandlermember", Password: "hashedpass"}
+	database.DB.Where("username = ?", owner.Username).FirstOrCreate(&owner)
+	database.DB.Where("username = ?", member.Username).FirstOrCreate(&member)
+
+	sub := models.Sub{Name: "settingshandlersub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	ownerRouter := setupSubSettingsTestRouter(owner.Username)
+	memberRouter := setupSubSettingsTestRouter(member.Username)
+
+	t.Run("owner updates settings", func(t *testing.T) {
+		body := `{"rules":[{"title":"No spam"}],"sidebar":"Hello","allowed_post_types":["text","link"],"min_account_age_days":7}`
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/%d/settings", sub.ID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.SubSettingsResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "Hello", response.Sidebar)
+		assert.Equal(t, []string{"text", "link"}, response.AllowedPostTypes)
+		assert.Equal(t, 7, response.MinAccountAgeDays)
+		assert.Len(t, response.Rules, 1)
+	})
+
+	t.Run("invalid settings are rejected", func(t *testing.T) {
+		body := `{"allowed_post_types":["video"]}`
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/%d/settings", sub.ID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("non-owner cannot update settings", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("/%d/settings", sub.ID), strings.NewReader(`{"sidebar":"x"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		memberRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("anyone can read public sub settings", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/%d/settings", sub.ID), nil)
+		w := httptest.NewRecorder()
+		memberRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "No spam")
+	})
+
+	t.Run("manage approved submitters", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/%d/approved-submitters", sub.ID), strings.NewReader(`{"username":"settingshandlermember"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("GET", fmt.Sprintf("/%d/approved-submitters", sub.ID), nil)
+		w = httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "settingshandlermember")
+
+		req, _ = http.NewRequest("DELETE", fmt.Sprintf("/%d/approved-submitters/settingshandlermember", sub.ID), nil)
+		w = httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("sub not found", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/999999/settings", nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
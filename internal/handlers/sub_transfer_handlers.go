@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func newSubTransferService() *services.SubTransferService {
+	return services.NewSubTransferService(repositories.NewSubTransferRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository())
+}
+
+// subTransferErrorStatus maps ownership transfer errors to HTTP status codes
+func subTransferErrorStatus(err error) int {
+	switch err.Error() {
+	case "sub not found", "user not found", "recipient user not found", "ownership transfer not found":
+		return http.StatusNotFound
+	case "only the sub owner can transfer ownership", "you are not the recipient of this transfer":
+		return http.StatusForbidden
+	case "an ownership transfer is already pending for this sub", "ownership transfer is no longer pending",
+		"the sub owner has changed since this transfer was offered":
+		return http.StatusConflict
+	case "failed to create ownership transfer", "failed to fetch ownership transfers",
+		"failed to update ownership transfer", "failed to transfer sub ownership":
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// @Summary Transfer sub ownership
+// @Description Offers ownership of a sub to another user, who has to accept it (owner only)
+// @Tags Subs
+// @Accept json
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Param transfer body models.TransferRequest true "Recipient"
+// @Success 201 {object} models.TransferResponse "Pending transfer"
+// @Failure 400 {object} map[string]string "error: Bad request"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can transfer ownership"
+// @Failure 404 {object} map[string]string "error: Sub or recipient not found"
+// @Failure 409 {object} map[string]string "error: Transfer already pending"
+// @Security BearerAuth
+// @Router /sub/{subID}/transfer [post]
+func InitiateSubTransfer(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var transferRequest models.TransferRequest
+	if err := c.ShouldBindJSON(&transferRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := newSubTransferService().InitiateTransfer(c.Param("subID"), username.(string), transferRequest)
+	if err != nil {
+		c.JSON(subTransferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+// @Summary Cancel sub ownership transfer
+// @Description Withdraws the sub's pending ownership transfer (owner only)
+// @Tags Subs
+// @Produce json
+// @Param subID path string true "Sub ID"
+// @Success 200 {object} map[string]string "message: Ownership transfer cancelled"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Only sub owner can transfer ownership"
+// @Failure 404 {object} map[string]string "error: Sub or transfer not found"
+// @Security BearerAuth
+// @Router /sub/{subID}/transfer [delete]
+func CancelSubTransfer(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newSubTransferService().CancelTransfer(c.Param("subID"), username.(string)); err != nil {
+		c.JSON(subTransferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ownership transfer cancelled"})
+}
+
+// @Summary Get my sub transfers
+// @Description Lists the pending sub ownership transfers offered to the authenticated user
+// @Tags Subs
+// @Produce json
+// @Success 200 {array} models.TransferResponse "Pending transfers"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Security BearerAuth
+// @Router /user/sub-transfers [get]
+func GetMySubTransfers(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	transfers, err := newSubTransferService().GetMyTransfers(username.(string))
+	if err != nil {
+		c.JSON(subTransferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
+}
+
+// @Summary Accept sub ownership transfer
+// @Description Accepts a pending ownership transfer and becomes the sub's owner; the previous owner stays on as a moderator
+// @Tags Subs
+// @Produce json
+// @Param transferID path string true "Transfer ID"
+// @Success 200 {object} map[string]string "message: You are now the sub owner"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Not the recipient"
+// @Failure 404 {object} map[string]string "error: Transfer not found"
+// @Failure 409 {object} map[string]string "error: Transfer is no longer pending"
+// @Security BearerAuth
+// @Router /user/sub-transfers/{transferID}/accept [post]
+func AcceptSubTransfer(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newSubTransferService().RespondToTransfer(c.Param("transferID"), username.(string), true); err != nil {
+		c.JSON(subTransferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "You are now the sub owner"})
+}
+
+// @Summary Decline sub ownership transfer
+// @Description Declines a pending ownership transfer
+// @Tags Subs
+// @Produce json
+// @Param transferID path string true "Transfer ID"
+// @Success 200 {object} map[string]string "message: Ownership transfer declined"
+// @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Not the recipient"
+// @Failure 404 {object} map[string]string "error: Transfer not found"
+// @Failure 409 {object} map[string]string "error: Transfer is no longer pending"
+// @Security BearerAuth
+// @Router /user/sub-transfers/{transferID}/decline [post]
+func DeclineSubTransfer(c *gin.Context) {
+	username, exists := c.Get("username")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := newSubTransferService().RespondToTransfer(c.Param("transferID"), username.(string), false); err != nil {
+		c.JSON(subTransferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ownership transfer declined"})
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSubTransferTestRouter(username string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		// Mock authentication middleware
+		c.Set("username", username)
+		c.Next()
+	})
+	r.POST("/sub/:subID/transfer", InitiateSubTransfer)
+	r.DELETE("/sub/:subID/transfer", CancelSubTransfer)
+	r.GET("/sub-transfers", GetMySubTransfers)
+	r.POST("/sub-transfers/:transferID/accept", AcceptSubTransfer)
+
+	nameRoutes := r.Group("/r/:name")
+	nameRoutes.Use(ResolveSubName)
+	nameRoutes.GET("", GetSubByName)
+	nameRoutes.GET("/settings", GetSubSettings)
+	return r
+}
+
+func TestSubTransferHandlers(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping handler integration tests")
+		return
+	}
+
+	// Setup
+	database.DB.Exec("DELETE FROM notifications")
+	database.DB.Exec("DELETE FROM sub_ownership_transfers")
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM sub_invitations")
+	database.DB.Exec("DELETE FROM subs")
+
+	owner := models.User{Username: "transferhandlerowner", Password: "hashedpass"}
+	recipient := models.User{Username: "transferhandlerrecipient", Password: "hashedpass"}
+	database.DB.Where("username = ?", owner.Username).FirstOrCreate(&owner)
+	database.DB.Where("username = ?", recipient.Username).FirstOrCreate(&recipient)
+
+	sub := models.Sub{Name: "TransferHandlerSub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	ownerRouter := setupSubTransferTestRouter(owner.Username)
+	recipientRouter := setupSubTransferTestRouter(recipient.Username)
+
+	t.Run("sub is found by name ignoring case", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/r/transferhandlersub", nil)
+		w := httptest.NewRecorder()
+		recipientRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "TransferHandlerSub")
+
+		req, _ = http.NewRequest("GET", "/r/TRANSFERHANDLERSUB/settings", nil)
+		w = httptest.NewRecorder()
+		recipientRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/r/doesnotexist", nil)
+		w := httptest.NewRecorder()
+		recipientRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("private sub is not found for non-members", func(t *testing.T) {
+		private := models.Sub{Name: "TransferHandlerPrivate", OwnerID: owner.ID, Private: true}
+		database.DB.Create(&private)
+
+		for _, path := range []string{"/r/transferhandlerprivate", "/r/transferhandlerprivate/settings"} {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			recipientRouter.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Contains(t, w.Body.String(), "sub not found")
+		}
+
+		req, _ := http.NewRequest("GET", "/r/transferhandlerprivate", nil)
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// A pending invitee can look the sub up to decide on the invitation
+		database.DB.Create(&models.SubInvitation{SubID: private.ID, InviterID: owner.ID, InviteeID: recipient.ID, Status: models.InviteStatusPending})
+		req, _ = http.NewRequest("GET", "/r/transferhandlerprivate", nil)
+		w = httptest.NewRecorder()
+		recipientRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "TransferHandlerPrivate")
+	})
+
+	t.Run("recipient accepts a transfer", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/sub/%d/transfer", sub.ID), strings.NewReader(`{"username":"transferhandlerrecipient"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var transfer models.TransferResponse
+		json.Unmarshal(w.Body.Bytes(), &transfer)
+
+		req, _ = http.NewRequest("POST", fmt.Sprintf("/sub-transfers/%d/accept", transfer.ID), nil)
+		w = httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		req, _ = http.NewRequest("POST", fmt.Sprintf("/sub-transfers/%d/accept", transfer.ID), nil)
+		w = httptest.NewRecorder()
+		recipientRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("POST", fmt.Sprintf("/sub/%d/transfer", sub.ID), strings.NewReader(`{"username":"transferhandlerowner"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		ownerRouter.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
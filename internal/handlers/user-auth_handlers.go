@@ -6,6 +6,7 @@ import (
 
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -23,7 +24,7 @@ type UserRegisterRequest struct {
 // @Produce json
 // @Param request body UserRegisterRequest true "User registration details"
 // @Success 200 {object} map[string]string "message: User registered successfully"
-// @Failure 400 {object} map[string]string "error: Bad request - username and password required"
+// @Failure 400 {object} map[string]string "error: Bad request - username and password required, or the username is reserved"
 // @Failure 409 {object} map[string]string "error: Username already taken"
 // @Failure 500 {object} map[string]string "error: Internal server error"
 // @Router /auth/register [post]
@@ -38,6 +39,10 @@ func Register(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "username is required"})
 		return
 	}
+	if err := services.ValidateUsername(req.Username); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	if req.Password == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "password is required"})
@@ -3,14 +3,18 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -135,6 +139,37 @@ func TestRegisterHandler(t *testing.T) {
 		// This might return different status codes based on DB constraints, but should not be 200
 		assert.NotEqual(t, http.StatusOK, w2.Code)
 	})
+
+	t.Run("reserved usernames don't block the purge", func(t *testing.T) {
+		victim := models.User{Username: "purgevictim", Password: "password"}
+		require.NoError(t, database.DB.Create(&victim).Error)
+		reserved := fmt.Sprintf("deleted_%d", victim.ID)
+
+		for _, username := range []string{reserved, "Deleted_1", models.DeletedPlaceholder} {
+			jsonData, _ := json.Marshal(map[string]string{"username": username, "password": "password"})
+			req, _ := http.NewRequest("POST", "/auth/register", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "this username is reserved")
+		}
+
+		// Accounts registered before the rule may already hold the name
+		squatter := models.User{Username: reserved, Password: "password"}
+		require.NoError(t, database.DB.Create(&squatter).Error)
+		require.NoError(t, database.DB.Delete(&victim).Error)
+
+		require.NoError(t, database.PurgeDeletedBefore(time.Now().Add(time.Minute), nil))
+
+		var purged models.User
+		require.NoError(t, database.DB.Unscoped().First(&purged, victim.ID).Error)
+		assert.Empty(t, purged.Password)
+		assert.True(t, strings.HasPrefix(purged.Username, reserved+"_"))
+		var kept models.User
+		require.NoError(t, database.DB.First(&kept, squatter.ID).Error)
+		assert.Equal(t, reserved, kept.Username)
+	})
 }
 
 func TestLoginHandler(t *testing.T) {
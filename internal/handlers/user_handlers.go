@@ -13,10 +13,11 @@ import (
 
 // GetUserProfile retrieves the public profile of a user by username
 // @Summary Get user profile
-// @Description Get public profile information for a user
+// @Description Get public profile information for a user. The token is optional: private profiles hide their activity, follows, karma and join date from anyone but the user and their accepted followers, and list the hidden sections in hidden.
 // @Tags users
 // @Accept  json
 // @Produce  json
+// @Security ApiKeyAuth
 // @Param username path string true "Username"
 // @Success 200 {object} models.UserResponse
 // @Failure 404 {object} map[string]string "error: User not found"
@@ -24,8 +25,14 @@ import (
 func GetUserProfile(c *gin.Context) {
 	username := c.Param("username")
 
+	// Logged-in viewers may see more of a private profile; anyone else is treated as logged out
+	var viewerID *uint
+	if viewer, err := repositories.NewUserRepository().GetUserByUsername(c.GetString("username")); err == nil {
+		viewerID = &viewer.ID
+	}
+
 	// Get user profile via service
-	userResponse, err := services.GetUserProfile(username, nil)
+	userResponse, err := services.GetUserProfile(username, viewerID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
 	models "github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // @Summary Downvote a post
@@ -17,6 +22,7 @@ import (
 // @Success 200 {object} map[string]string "message: Vote updated/removed/recored"
 // @Failure 400 {object} map[string]string "error: Bad request or invalid data"
 // @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Post is locked or archived"
 // @Failure 404 {object} map[string]string "error: Post not found"
 // @Failure 500 {object} map[string]string "error: Database error"
 // @Security BearerAuth
@@ -34,6 +40,7 @@ func DownvotePost(c *gin.Context) {
 // @Success 200 {object} map[string]string "message: Vote updated/removed/recored"
 // @Failure 400 {object} map[string]string "error: Bad request or invalid data"
 // @Failure 401 {object} map[string]string "error: Unauthorized"
+// @Failure 403 {object} map[string]string "error: Post is locked or archived"
 // @Failure 404 {object} map[string]string "error: Post not found"
 // @Failure 500 {object} map[string]string "error: Database error"
 // @Security BearerAuth
@@ -70,43 +77,77 @@ func handleVote(c *gin.Context, voteValue int) {
 
 	// Check if the post exists
 	var post models.Post
-	if err := db.DB.First(&post, voteRequest.PostID).Error; err != nil {
+	if err := db.DB.Scopes(repositories.PublishedPosts).First(&post, voteRequest.PostID).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
 		return
 	}
 
-	// ✅ Check if the user has already voted
-	var existingVote models.Vote
-	err := db.DB.Where("user_id = ? AND post_id = ?", user.ID, voteRequest.PostID).First(&existingVote).Error
-
-	if err != nil && err.Error() != "record not found" {
-		// Only return an error if it's a real DB error, not a "record not found"
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+	// Locked and archived posts don't take new votes
+	if err := services.CheckPostOpen(post); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err == nil {
-		// ✅ If the user has already voted and selects the same vote, remove it
-		if existingVote.Vote == voteValue {
-			db.DB.Delete(&existingVote)
-			c.JSON(http.StatusOK, gin.H{"message": "Vote removed"})
-			return
+	// The vote lookup, the vote and the author's karma change together. Locking the post first serializes
+	// votes on it, so two requests from the same user can't both find no vote and record one each.
+	status, message, delta := http.StatusOK, "", 0
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Post{}, post.ID).Error; err != nil {
+			return err
+		}
+
+		// ✅ Check if the user has already voted
+		var existingVote models.Vote
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND post_id = ?", user.ID, voteRequest.PostID).First(&existingVote).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err == nil {
+			// ✅ If the user has already voted and selects the same vote, remove it
+			if existingVote.Vote == voteValue {
+				if err := tx.Delete(&existingVote).Error; err != nil {
+					return err
+				}
+				message = "Vote removed"
+				return repositories.AdjustKarma(tx, existingVote, -existingVote.Vote)
+			}
+
+			// ✅ If the user voted differently before, update it
+			previousVote := existingVote.Vote
+			existingVote.Vote = voteValue
+			if err := tx.Save(&existingVote).Error; err != nil {
+				return err
+			}
+			message, delta = "Vote updated", voteValue-previousVote
+			return repositories.AdjustKarma(tx, existingVote, delta)
 		}
 
-		// ✅ If the user voted differently before, update it
-		existingVote.Vote = voteValue
-		db.DB.Save(&existingVote)
-		c.JSON(http.StatusOK, gin.H{"message": "Vote updated"})
+		// ✅ If no previous vote exists, create a new one
+		newVote := models.Vote{
+			UserID: user.ID,
+			PostID: voteRequest.PostID,
+			Vote:   voteValue,
+		}
+		if err := tx.Create(&newVote).Error; err != nil {
+			return err
+		}
+		status, message, delta = http.StatusCreated, "Vote recorded", voteValue
+		return repositories.AdjustKarma(tx, newVote, voteValue)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
 		return
 	}
 
-	// ✅ If no previous vote exists, create a new one
-	newVote := models.Vote{
-		UserID: user.ID,
-		PostID: voteRequest.PostID,
-		Vote:   voteValue,
-	}
-	db.DB.Create(&newVote)
+	notifyPostVoted(post, delta)
+	c.JSON(status, gin.H{"message": message})
+}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Vote recorded"})
+// notifyPostVoted tells the author about score milestones a vote that changed the score by delta carried their post past
+func notifyPostVoted(post models.Post, delta int) {
+	if delta <= 0 {
+		return
+	}
+	services.NotifyPostVoted(post, delta)
 }
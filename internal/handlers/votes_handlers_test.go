@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
@@ -128,6 +129,55 @@ func TestUpvotePostHandler(t *testing.T) {
 		json.Unmarshal(w.Body.Bytes(), &response)
 		assert.Equal(t, "Vote removed", response["message"])
 	})
+
+	t.Run("votes move the author's post karma", func(t *testing.T) {
+		database.DB.Exec("DELETE FROM votes WHERE post_id = ?", post.ID)
+		database.DB.Model(&user).Update("post_karma", 0)
+		karma := func() int {
+			var author models.User
+			database.DB.First(&author, user.ID)
+			return author.PostKarma
+		}
+		vote := func(path string) {
+			jsonData, _ := json.Marshal(map[string]interface{}{"postID": post.ID})
+			req, _ := http.NewRequest("POST", path, bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		vote("/posts/upvote")
+		assert.Equal(t, 1, karma())
+		vote("/posts/downvote")
+		assert.Equal(t, -1, karma())
+		vote("/posts/downvote")
+		assert.Equal(t, 0, karma())
+	})
+
+	t.Run("concurrent votes are recorded once", func(t *testing.T) {
+		database.DB.Exec("DELETE FROM votes WHERE post_id = ?", post.ID)
+		database.DB.Model(&user).Update("post_karma", 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				jsonData, _ := json.Marshal(map[string]interface{}{"postID": post.ID})
+				req, _ := http.NewRequest("POST", "/posts/upvote", bytes.NewBuffer(jsonData))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(httptest.NewRecorder(), req)
+			}()
+		}
+		wg.Wait()
+
+		// Five toggles in a row leave exactly one upvote behind
+		var votes []models.Vote
+		database.DB.Where("user_id = ? AND post_id = ?", user.ID, post.ID).Find(&votes)
+		assert.Len(t, votes, 1)
+		var author models.User
+		database.DB.First(&author, user.ID)
+		assert.Equal(t, 1, author.PostKarma)
+	})
 }
 
 func TestDownvotePostHandler(t *testing.T) {
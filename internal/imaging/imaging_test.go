@@ -0,0 +1,142 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// solid creates an opaque image filled with one color
+func solid(width, height int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// withExif inserts an EXIF segment with an orientation tag and a GPS-looking comment after the JPEG start marker
+func withExif(t *testing.T, jpegData []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1) // One IFD entry
+	tiff = binary.BigEndian.AppendUint16(tiff, orientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	tiff = append(tiff, []byte("GPS 52.5200N 13.4050E")...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	require.Equal(t, []byte{0xFF, 0xD8}, jpegData[:2])
+	return append(append([]byte{0xFF, 0xD8}, segment...), jpegData[2:]...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	return buf.Bytes()
+}
+
+func TestOrientation(t *testing.T) {
+	data := encodeJPEG(t, solid(4, 2, color.White))
+	assert.Equal(t, 1, Orientation(data))
+	assert.Equal(t, 6, Orientation(withExif(t, data, 6)))
+	assert.Equal(t, 1, Orientation(withExif(t, data, 42)))
+	assert.Equal(t, 1, Orientation([]byte("not a jpeg")))
+	assert.Equal(t, 1, Orientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF})) // Truncated segment
+}
+
+func TestDecode(t *testing.T) {
+	// Left half red, right half blue
+	img := solid(8, 4, color.NRGBA{R: 255, A: 255})
+	for y := 0; y < 4; y++ {
+		for x := 4; x < 8; x++ {
+			img.Set(x, y, color.NRGBA{B: 255, A: 255})
+		}
+	}
+
+	t.Run("upright", func(t *testing.T) {
+		decoded, err := Decode(encodeJPEG(t, img))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 8, 4), decoded.Bounds())
+	})
+
+	t.Run("rotated photos are turned upright", func(t *testing.T) {
+		decoded, err := Decode(withExif(t, encodeJPEG(t, img), 6))
+		require.NoError(t, err)
+		assert.Equal(t, 4, decoded.Bounds().Dx())
+		assert.Equal(t, 8, decoded.Bounds().Dy())
+
+		// A clockwise turn puts the left half on top
+		r, _, b, _ := decoded.At(1, 1).RGBA()
+		assert.Greater(t, r, b)
+		r, _, b, _ = decoded.At(1, 6).RGBA()
+		assert.Greater(t, b, r)
+	})
+
+	t.Run("invalid images", func(t *testing.T) {
+		_, err := Decode([]byte("\x89PNG\r\n\x1a\ngarbage"))
+		assert.Error(t, err)
+	})
+}
+
+func TestFit(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 160, 90), Fit(solid(1600, 900, color.White), 160).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 90, 160), Fit(solid(900, 1600, color.White), 160).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 160, 1), Fit(solid(1600, 2, color.White), 160).Bounds())
+
+	small := solid(10, 10, color.White)
+	assert.Same(t, small, Fit(small, 160))
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("opaque images become JPEG without EXIF", func(t *testing.T) {
+		decoded, err := Decode(withExif(t, encodeJPEG(t, solid(8, 8, color.White)), 1))
+		require.NoError(t, err)
+
+		data, contentType, err := Encode(decoded)
+		require.NoError(t, err)
+		assert.Equal(t, "image/jpeg", contentType)
+		assert.False(t, bytes.Contains(data, []byte("Exif")))
+		assert.False(t, bytes.Contains(data, []byte("GPS")))
+	})
+
+	t.Run("transparent images become PNG", func(t *testing.T) {
+		data, contentType, err := Encode(solid(8, 8, color.NRGBA{R: 255, A: 128}))
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+		_, err = png.Decode(bytes.NewReader(data))
+		assert.NoError(t, err)
+	})
+}
+
+func TestBlurHash(t *testing.T) {
+	// Expected hashes come from the reference implementation
+	assert.Equal(t, "L9TSUA~qfQ~q~qoffQoffQfQfQfQ", BlurHash(solid(32, 32, color.White), 4, 3))
+	assert.Equal(t, "00TSUA", BlurHash(solid(8, 8, color.White), 1, 1))
+
+	// Left half green, right half red
+	img := solid(40, 30, color.NRGBA{R: 200, G: 40, B: 40, A: 255})
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 20; x++ {
+			img.Set(x, y, color.NRGBA{G: 180, B: 90, A: 255})
+		}
+	}
+	assert.Equal(t, "LwG*[S44Sz+doeW;fQjafQfQfQfQ", BlurHash(img, 4, 3))
+
+	// Large images are sampled down first
+	assert.Len(t, BlurHash(solid(640, 480, color.White), 4, 3), 28)
+}
@@ -0,0 +1,106 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// KindWrapped is the node kind of text wrapped by a spoiler or superscript delimiter
+var KindWrapped = ast.NewNodeKind("Wrapped")
+
+// Wrapped is inline content rendered inside an HTML element, such as a spoiler or superscript
+type Wrapped struct {
+	ast.BaseInline
+	Open  string
+	Close string
+}
+
+// Kind implements ast.Node.Kind
+func (n *Wrapped) Kind() ast.NodeKind {
+	return KindWrapped
+}
+
+// Dump implements ast.Node.Dump
+func (n *Wrapped) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Open": n.Open}, nil)
+}
+
+// wrapper is an inline extension that wraps text between a delimiter of a fixed length in an HTML element
+type wrapper struct {
+	char   byte
+	length int
+	open   string
+	close  string
+}
+
+// Spoiler hides text written as ||spoiler|| until the reader reveals it
+var Spoiler = &wrapper{char: '|', length: 2, open: `<span class="spoiler">`, close: "</span>"}
+
+// Superscript raises text written as ^superscript^
+var Superscript = &wrapper{char: '^', length: 1, open: "<sup>", close: "</sup>"}
+
+// Extend implements goldmark.Extender
+func (w *wrapper) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithInlineParsers(util.Prioritized(w, 500)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(wrappedRenderer{}, 500)))
+}
+
+// IsDelimiter implements parser.DelimiterProcessor
+func (w *wrapper) IsDelimiter(b byte) bool {
+	return b == w.char
+}
+
+// CanOpenCloser implements parser.DelimiterProcessor
+func (w *wrapper) CanOpenCloser(opener, closer *parser.Delimiter) bool {
+	return opener.Char == closer.Char
+}
+
+// OnMatch implements parser.DelimiterProcessor
+func (w *wrapper) OnMatch(consumes int) ast.Node {
+	return &Wrapped{Open: w.open, Close: w.close}
+}
+
+// Trigger implements parser.InlineParser
+func (w *wrapper) Trigger() []byte {
+	return []byte{w.char}
+}
+
+// Parse implements parser.InlineParser; runs of the delimiter character longer than the extension's length are left as text
+func (w *wrapper) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	before := block.PrecendingCharacter()
+	line, segment := block.PeekLine()
+	node := parser.ScanDelimiter(line, before, w.length, w)
+	if node == nil || node.OriginalLength != w.length || before == rune(w.char) {
+		return nil
+	}
+
+	node.Segment = segment.WithStop(segment.Start + node.OriginalLength)
+	block.Advance(node.OriginalLength)
+	pc.PushDelimiter(node)
+	return node
+}
+
+// CloseBlock implements parser.InlineParser
+func (w *wrapper) CloseBlock(parent ast.Node, pc parser.Context) {}
+
+// wrappedRenderer writes the opening and closing HTML of wrapped nodes
+type wrappedRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer
+func (r wrappedRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindWrapped, r.render)
+}
+
+func (r wrappedRenderer) render(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	wrapped := n.(*Wrapped)
+	if entering {
+		_, _ = w.WriteString(wrapped.Open)
+	} else {
+		_, _ = w.WriteString(wrapped.Close)
+	}
+	return ast.WalkContinue, nil
+}
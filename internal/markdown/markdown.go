@@ -0,0 +1,128 @@
+package markdown
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// DefaultAllowedTags are the HTML elements rendered content may contain when MARKDOWN_ALLOWED_TAGS is not set
+var DefaultAllowedTags = []string{
+	"p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "pre", "code",
+	"em", "strong", "a", "img", "ul", "ol", "li", "table", "thead", "tbody", "tr", "th", "td",
+	"sup", "span",
+}
+
+// allowedAttrs are the attributes kept on each element, when that element is allowed at all
+var allowedAttrs = map[string][]string{
+	"a":   {"href", "title"},
+	"img": {"src", "alt", "title"},
+	"ol":  {"start"},
+	"th":  {"align"},
+	"td":  {"align"},
+}
+
+// AllowedTags returns the HTML elements rendered content may contain, configurable with MARKDOWN_ALLOWED_TAGS
+// as a comma-separated list such as "p,em,strong,a"
+func AllowedTags() []string {
+	tags := []string{}
+	for _, tag := range strings.Split(os.Getenv("MARKDOWN_ALLOWED_TAGS"), ",") {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return DefaultAllowedTags
+	}
+	return tags
+}
+
+// Renderer converts markdown to HTML and sanitizes the result with an allowed-tag policy
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+}
+
+// NewRenderer creates a renderer for CommonMark with tables, ||spoilers|| and ^superscript^,
+// keeping only the given HTML elements in its output
+func NewRenderer(allowedTags []string) *Renderer {
+	markdown := goldmark.New(goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		Spoiler,
+		Superscript,
+	))
+
+	return &Renderer{markdown: markdown, policy: newPolicy(allowedTags)}
+}
+
+// Render converts markdown source to sanitized HTML. Raw HTML in the source is never passed through.
+func (r *Renderer) Render(source string) string {
+	if strings.TrimSpace(source) == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := r.markdown.Convert([]byte(source), &buf); err != nil {
+		// Fall back to the escaped source rather than failing the whole post or comment
+		return r.policy.Sanitize("<p>" + bluemonday.StrictPolicy().Sanitize(source) + "</p>")
+	}
+	return r.policy.Sanitize(buf.String())
+}
+
+// Sanitize strips the elements and attributes the renderer's policy doesn't allow from already rendered HTML
+func (r *Renderer) Sanitize(html string) string {
+	return r.policy.Sanitize(html)
+}
+
+var (
+	defaultRenderer     *Renderer
+	defaultRendererOnce sync.Once
+)
+
+// Render converts markdown source to sanitized HTML using the configured allowed tags
+func Render(source string) string {
+	return getDefaultRenderer().Render(source)
+}
+
+// Sanitize re-applies the configured allowed tags to stored HTML, so tightening MARKDOWN_ALLOWED_TAGS also
+// applies to content rendered before the change
+func Sanitize(html string) string {
+	return getDefaultRenderer().Sanitize(html)
+}
+
+func getDefaultRenderer() *Renderer {
+	defaultRendererOnce.Do(func() {
+		defaultRenderer = NewRenderer(AllowedTags())
+	})
+	return defaultRenderer
+}
+
+// newPolicy builds a sanitizer that keeps only the allowed elements and their safe attributes.
+// Links may only use http, https and mailto URLs and are marked nofollow.
+func newPolicy(allowedTags []string) *bluemonday.Policy {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements(allowedTags...)
+
+	// Attributes are only added for allowed elements; bluemonday allows any element that has allowed attributes
+	for _, tag := range allowedTags {
+		if attrs, ok := allowedAttrs[tag]; ok {
+			policy.AllowAttrs(attrs...).OnElements(tag)
+		}
+		switch tag {
+		case "span":
+			policy.AllowAttrs("class").Matching(regexp.MustCompile(`^spoiler$`)).OnElements("span")
+		case "code":
+			policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+		}
+	}
+
+	policy.AllowStandardURLs()
+	policy.RequireNoFollowOnLinks(true)
+	return policy
+}
@@ -0,0 +1,56 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	renderer := NewRenderer(DefaultAllowedTags)
+
+	t.Run("commonmark", func(t *testing.T) {
+		assert.Equal(t, "<p>Hello <strong>world</strong> and <em>you</em></p>\n", renderer.Render("Hello **world** and *you*"))
+		assert.Equal(t, "", renderer.Render("   "))
+	})
+
+	t.Run("spoilers and superscript", func(t *testing.T) {
+		assert.Equal(t, "<p>Ends with <span class=\"spoiler\">a <em>twist</em></span></p>\n", renderer.Render("Ends with ||a *twist*||"))
+		assert.Equal(t, "<p>E = mc<sup>2</sup></p>\n", renderer.Render("E = mc^2^"))
+		assert.Equal(t, "<p>a ||| b and x^y</p>\n", renderer.Render("a ||| b and x^y"))
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		html := renderer.Render("| a | b |\n|:--|--:|\n| 1 | 2 |")
+		assert.Contains(t, html, "<table>")
+		assert.Contains(t, html, "<th align=\"left\">a</th>")
+		assert.Contains(t, html, "<td align=\"right\">2</td>")
+	})
+
+	t.Run("unsafe content is removed", func(t *testing.T) {
+		html := renderer.Render("<script>alert(1)</script>\n\n[bad](javascript:alert(1)) [good](https://example.com) <span class=\"x\" onclick=\"y\">z</span>")
+		assert.NotContains(t, html, "script")
+		assert.NotContains(t, html, "javascript")
+		assert.NotContains(t, html, "onclick")
+		assert.Contains(t, html, "<a href=\"https://example.com\" rel=\"nofollow\">good</a>")
+	})
+
+	t.Run("allowed tags are configurable", func(t *testing.T) {
+		restricted := NewRenderer([]string{"p", "em"})
+		assert.Equal(t, "<p><em>kept</em> dropped link</p>\n", restricted.Render("*kept* **dropped** [link](https://example.com)"))
+	})
+
+	t.Run("stored html is sanitized with a tightened policy", func(t *testing.T) {
+		stored := renderer.Render("*kept* **dropped** [link](https://example.com)")
+		restricted := NewRenderer([]string{"p", "em"})
+		assert.Equal(t, "<p><em>kept</em> dropped link</p>\n", restricted.Sanitize(stored))
+	})
+}
+
+func TestAllowedTags(t *testing.T) {
+	t.Setenv("MARKDOWN_ALLOWED_TAGS", "")
+	assert.Equal(t, DefaultAllowedTags, AllowedTags())
+
+	t.Setenv("MARKDOWN_ALLOWED_TAGS", " P, em ,,a")
+	assert.Equal(t, []string{"p", "em", "a"}, AllowedTags())
+}
@@ -0,0 +1,47 @@
+package models
+
+// Sort orders for a user's activity history
+const (
+	ActivitySortNew = "new" // Newest first
+	ActivitySortTop = "top" // Highest score first, newest first among equal scores
+)
+
+// UserCommentResponse is a comment in a user's history with the post and sub it was made in
+type UserCommentResponse struct {
+	CommentResponse
+	PostID    uint   `json:"post_id"`
+	PostTitle string `json:"post_title"`
+	SubID     uint   `json:"sub_id"`
+	SubName   string `json:"sub_name"`
+}
+
+// ActivityItemResponse is a post or comment in a user's overview; exactly one of Post and Comment is set
+type ActivityItemResponse struct {
+	Type    string               `json:"type"` // ContentTypePost or ContentTypeComment
+	Post    *PostResponse        `json:"post,omitempty"`
+	Comment *UserCommentResponse `json:"comment,omitempty"`
+}
+
+// UserPostListResponse is one page of posts a user wrote or upvoted
+type UserPostListResponse struct {
+	Posts []PostResponse `json:"posts"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+	Total int64          `json:"total"`
+}
+
+// UserCommentListResponse is one page of a user's comments
+type UserCommentListResponse struct {
+	Comments []UserCommentResponse `json:"comments"`
+	Page     int                   `json:"page"`
+	Limit    int                   `json:"limit"`
+	Total    int64                 `json:"total"`
+}
+
+// UserOverviewResponse is one page of a user's posts and comments together
+type UserOverviewResponse struct {
+	Items []ActivityItemResponse `json:"items"`
+	Page  int                    `json:"page"`
+	Limit int                    `json:"limit"`
+	Total int64                  `json:"total"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// UserBlock records that one user has blocked another
+type UserBlock struct {
+	ID        uint `gorm:"primaryKey"`
+	BlockerID uint `gorm:"not null;uniqueIndex:idx_user_block"`
+	BlockedID uint `gorm:"not null;uniqueIndex:idx_user_block"`
+	CreatedAt time.Time
+}
+
+// BlockResponse represents a blocked user in API responses
+type BlockResponse struct {
+	Username  string `json:"username"`
+	BlockedAt string `json:"blocked_at"`
+}
@@ -1,18 +1,25 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // CommentResponse struct to format comment output
 type CommentResponse struct {
-	ID        uint    `json:"id"`
-	Content   string  `json:"content"`
-	Username  string  `json:"username"`
-	ImageURL  *string `json:"imageURL,omitempty"` // Link to an image
-	ParentID  *uint   `json:"parentID,omitempty"` // For comment threading
-	Upvotes   int     `json:"upvotes"`
-	Downvotes int     `json:"downvotes"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at,omitempty"`
+	ID          uint              `json:"id"`
+	Content     string            `json:"content"`
+	ContentHTML string            `json:"content_html"`       // Content rendered as sanitized HTML
+	Mentions    []MentionResponse `json:"mentions,omitempty"` // Linked @username and r/subname references in Content
+	Username    string            `json:"username"`
+	Image       *ImageResponse    `json:"image,omitempty"`    // The comment's image in each available size
+	ParentID    *uint             `json:"parentID,omitempty"` // For comment threading
+	Upvotes     int               `json:"upvotes"`
+	Downvotes   int               `json:"downvotes"`
+	CreatedAt   string            `json:"created_at"`
+	UpdatedAt   string            `json:"updated_at,omitempty"`
+	Deleted     bool              `json:"deleted,omitempty"` // Rendered as a "[deleted]" tombstone so replies keep their parent
 }
 
 // CommentRequest struct for incoming JSON data
@@ -21,23 +28,28 @@ type CommentRequest struct {
 	ParentID *uint   `json:"parentID,omitempty"` // For replying to comments
 	Content  string  `json:"content"`
 	ImageURL *string `json:"imageURL,omitempty"` // Link to an image
+	MediaID  *uint   `json:"media_id,omitempty"` // Uploaded image; when set, ImageURL links to it
 }
 
 // CommentUpdateRequest struct for updating comments
 type CommentUpdateRequest struct {
 	Content  string  `json:"content"`
 	ImageURL *string `json:"imageURL,omitempty"`
+	MediaID  *uint   `json:"media_id,omitempty"`
 }
 
 type Comment struct {
-	ID        uint `gorm:"primaryKey"`
-	Content   string
-	UserID    uint
-	PostID    uint
-	ParentID  *uint   `json:",omitempty"`         // For comment threading
-	ImageURL  *string `json:"imageURL,omitempty"` // Link to an image
-	User      User
-	Post      Post
-	CreatedAt time.Time
-	UpdatedAt *time.Time `json:",omitempty"`
+	ID          uint `gorm:"primaryKey"`
+	Content     string
+	ContentHTML string `json:"content_html"` // Content rendered as sanitized HTML, stored when Content is saved
+	UserID      uint
+	PostID      uint
+	ParentID    *uint   `json:",omitempty"`         // For comment threading
+	ImageURL    *string `json:"imageURL,omitempty"` // Link to an image
+	MediaID     *uint   `json:"media_id,omitempty"` // Uploaded image that ImageURL links to
+	User        User
+	Post        Post
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time     `json:",omitempty"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"` // Soft deletion; purged after the retention period
 }
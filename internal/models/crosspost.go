@@ -0,0 +1,25 @@
+package models
+
+// CrosspostRequest is the body for sharing a post in another sub; the title defaults to the original's
+type CrosspostRequest struct {
+	SubID   uint   `json:"sub_id" binding:"required"`
+	Title   string `json:"title"`
+	FlairID *uint  `json:"flair_id,omitempty"`
+}
+
+// CrosspostResponse attributes a crosspost to the post it shares and carries that post's content.
+// Once the original is deleted, or its sub becomes private, only its ID is left.
+type CrosspostResponse struct {
+	PostID      uint           `json:"post_id"`
+	SubID       uint           `json:"sub_id,omitempty"`
+	SubName     string         `json:"sub_name,omitempty"`
+	Username    string         `json:"username,omitempty"`
+	Title       string         `json:"title,omitempty"`
+	Content     string         `json:"content,omitempty"`
+	ContentHTML string         `json:"content_html,omitempty"`
+	Image       *ImageResponse `json:"image,omitempty"`
+	Link        *LinkResponse  `json:"link,omitempty"`
+	CreatedAt   string         `json:"created_at,omitempty"`
+	Deleted     bool           `json:"deleted,omitempty"`
+	Unavailable bool           `json:"unavailable,omitempty"` // The original's sub is private now
+}
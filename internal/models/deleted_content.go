@@ -0,0 +1,24 @@
+package models
+
+// DeletedPlaceholder replaces the content and author of deleted posts and comments
+const DeletedPlaceholder = "[deleted]"
+
+// DeletedUsernamePrefix starts the names purged accounts are renamed to; it can't be registered
+const DeletedUsernamePrefix = "deleted_"
+
+// Kinds of content that can be soft-deleted and restored
+const (
+	ContentTypePost    = "post"
+	ContentTypeComment = "comment"
+	ContentTypeSub     = "sub"
+	ContentTypeUser    = "user"
+)
+
+// DeletedContentResponse represents a soft-deleted item that an admin can still restore
+type DeletedContentResponse struct {
+	Type            string `json:"type"`
+	ID              uint   `json:"id"`
+	Summary         string `json:"summary"` // Post title, comment text, sub name or username
+	DeletedAt       string `json:"deleted_at"`
+	RestorableUntil string `json:"restorable_until"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Flair types: post flair categorizes posts, user flair is shown next to a member's name
+const (
+	FlairTypePost = "post"
+	FlairTypeUser = "user"
+)
+
+// SubFlair is a flair template defined by a sub's moderators
+type SubFlair struct {
+	ID              uint   `gorm:"primaryKey"`
+	SubID           uint   `gorm:"not null;index"`
+	Type            string `gorm:"not null"` // post or user
+	Text            string `gorm:"not null"`
+	TextColor       string `gorm:"default:'#000000'"`
+	BackgroundColor string `gorm:"default:'#EDEFF1'"`
+	ModOnly         bool   `gorm:"default:false"` // Only moderators can assign mod-only flair
+	CreatedAt       time.Time
+}
+
+// FlairRequest represents a flair template create or update request
+type FlairRequest struct {
+	Type            string `json:"type"`
+	Text            string `json:"text"`
+	TextColor       string `json:"text_color"`
+	BackgroundColor string `json:"background_color"`
+	ModOnly         bool   `json:"mod_only"`
+}
+
+// FlairAssignmentRequest selects the flair for a post or member; a nil flair ID clears it
+type FlairAssignmentRequest struct {
+	FlairID *uint `json:"flair_id"`
+}
+
+// FlairResponse represents a flair in API responses
+type FlairResponse struct {
+	ID              uint   `json:"id"`
+	Type            string `json:"type,omitempty"`
+	Text            string `json:"text"`
+	TextColor       string `json:"text_color"`
+	BackgroundColor string `json:"background_color"`
+	ModOnly         bool   `json:"mod_only,omitempty"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Follow statuses; follows of private users start out pending until the user approves them
+const (
+	FollowStatusPending  = "pending"
+	FollowStatusAccepted = "accepted"
+)
+
+// UserFollow records that one user follows another
+type UserFollow struct {
+	ID          uint   `gorm:"primaryKey"`
+	FollowerID  uint   `gorm:"not null;uniqueIndex:idx_user_follow"`
+	FollowingID uint   `gorm:"not null;uniqueIndex:idx_user_follow;index"`
+	Status      string `gorm:"default:'accepted'"` // pending, accepted
+	CreatedAt   time.Time
+	AcceptedAt  *time.Time
+}
+
+// FollowResponse represents a user in follower and following lists
+type FollowResponse struct {
+	Username    string  `json:"username"`
+	DisplayName string  `json:"display_name"`
+	AvatarURL   *string `json:"avatar_url,omitempty"`
+	FollowedAt  string  `json:"followed_at"`
+}
+
+// FollowListResponse is one page of a follower or following list
+type FollowListResponse struct {
+	Users []FollowResponse `json:"users"`
+	Page  int              `json:"page"`
+	Limit int              `json:"limit"`
+	Total int64            `json:"total"`
+}
+
+// FollowStatusResponse reports the state of a follow after following a user
+type FollowStatusResponse struct {
+	Username string `json:"username"`
+	Status   string `json:"status"`
+}
+
+// FollowRequestResponse represents a pending request to follow a private user
+type FollowRequestResponse struct {
+	ID        uint   `json:"id"`
+	Username  string `json:"username"`
+	CreatedAt string `json:"created_at"`
+}
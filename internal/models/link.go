@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// LinkPreview caches what was found when a link was unfurled, shared by every post linking to the same URL.
+// Failed fetches are cached too, so a broken link isn't fetched again for every post.
+type LinkPreview struct {
+	ID           uint   `gorm:"primaryKey"`
+	URL          string `gorm:"not null;uniqueIndex"`
+	Title        string
+	Description  string
+	SiteName     string
+	ThumbnailURL string
+	Failed       bool      `gorm:"not null;default:false"`
+	FetchedAt    time.Time `gorm:"not null"`
+}
+
+// LinkResponse is the link of a link post, with its preview once it has been unfurled. Pending is set
+// while the page hasn't been fetched yet.
+type LinkResponse struct {
+	URL          string `json:"url"`
+	Domain       string `json:"domain"`
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	SiteName     string `json:"site_name,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Pending      bool   `json:"pending,omitempty"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// Processing states of an uploaded image
+const (
+	MediaStatusPending    = "pending"    // Waiting for the background pipeline
+	MediaStatusProcessing = "processing" // Claimed by a worker
+	MediaStatusReady      = "ready"      // Renditions are available
+	MediaStatusFailed     = "failed"     // The file could not be decoded as an image
+)
+
+// Sizes an uploaded image is served in. Images linked from elsewhere have a single original rendition.
+const (
+	RenditionThumbnail = "thumbnail"
+	RenditionPreview   = "preview"
+	RenditionFull      = "full"
+	RenditionOriginal  = "original"
+)
+
+// Media is an uploaded file. The upload lives in the configured blob store under StorageKey until the
+// background pipeline has produced its renditions, after which it is deleted along with its metadata.
+// Posts, comments and avatars reference media by ID.
+type Media struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;index"` // The uploader, the only user who can attach it
+	StorageKey  string `gorm:"not null;uniqueIndex"`
+	ContentType string `gorm:"not null"` // Detected from the file's bytes, not taken from the client
+	Size        int64  `gorm:"not null"`
+	CreatedAt   time.Time
+
+	// Set by the image pipeline
+	Status              string     `gorm:"not null;default:'pending';index"`
+	ProcessingStartedAt *time.Time // When a worker claimed it; claims older than a few minutes are retried
+	Width               int        // Of the upright image, after applying its EXIF orientation
+	Height              int
+	BlurHash            string // Compact placeholder to show while the image loads
+}
+
+// MediaRendition is one size of a processed image, re-encoded without the upload's metadata
+type MediaRendition struct {
+	ID          uint   `gorm:"primaryKey"`
+	MediaID     uint   `gorm:"not null;uniqueIndex:idx_media_rendition"`
+	Name        string `gorm:"not null;uniqueIndex:idx_media_rendition"` // RenditionThumbnail, RenditionPreview or RenditionFull
+	StorageKey  string `gorm:"not null"`
+	ContentType string `gorm:"not null"`
+	Width       int
+	Height      int
+	Size        int64
+}
+
+// RenditionResponse is one size an image can be shown in
+type RenditionResponse struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+}
+
+// MediaResponse describes an uploaded file
+type MediaResponse struct {
+	ID          uint                `json:"id"`
+	URL         string              `json:"url"` // Where the full size is served from once processed
+	ContentType string              `json:"content_type"`
+	Size        int64               `json:"size"`
+	Status      string              `json:"status"`
+	Width       int                 `json:"width,omitempty"`
+	Height      int                 `json:"height,omitempty"`
+	BlurHash    string              `json:"blurhash,omitempty"`
+	Renditions  []RenditionResponse `json:"renditions"` // Empty until processed
+	CreatedAt   string              `json:"created_at"`
+}
+
+// ImageResponse is the image of a post or comment with the sizes it can be shown in
+type ImageResponse struct {
+	MediaID    *uint               `json:"media_id,omitempty"` // Unset for images linked from elsewhere
+	Status     string              `json:"status,omitempty"`   // Processing state of uploaded images
+	Width      int                 `json:"width,omitempty"`
+	Height     int                 `json:"height,omitempty"`
+	BlurHash   string              `json:"blurhash,omitempty"`
+	Renditions []RenditionResponse `json:"renditions"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// Mention types
+const (
+	MentionTypeUser = "user"
+	MentionTypeSub  = "sub"
+)
+
+// Mention is a resolved @username or r/subname reference in a post's or comment's content
+type Mention struct {
+	ID          uint   `gorm:"primaryKey"`
+	PostID      *uint  `gorm:"index"` // Set for mentions in a post
+	CommentID   *uint  `gorm:"index"` // Set for mentions in a comment
+	Type        string `gorm:"not null"`
+	TargetID    uint   `gorm:"not null"` // Mentioned user or sub
+	Name        string `gorm:"not null"`
+	StartOffset int    `gorm:"not null"` // Character offsets of the reference in the content
+	EndOffset   int    `gorm:"not null"`
+}
+
+// MentionResponse is a mention span in API responses; Start and End are character offsets into the content
+type MentionResponse struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
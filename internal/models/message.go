@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// Conversation is a private message thread between users, or a modmail thread addressed to a sub's moderators
+type Conversation struct {
+	ID        uint   `gorm:"primaryKey"`
+	SubID     *uint  `gorm:"index"` // Set for modmail threads
+	Subject   string `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time // Time of the latest message
+}
+
+// ConversationParticipant links a user to a conversation and tracks how far they have read
+type ConversationParticipant struct {
+	ID             uint `gorm:"primaryKey"`
+	ConversationID uint `gorm:"not null;uniqueIndex:idx_conversation_participant"`
+	UserID         uint `gorm:"not null;uniqueIndex:idx_conversation_participant;index"`
+	LastReadAt     *time.Time
+	CreatedAt      time.Time
+}
+
+// Message is a single message in a conversation
+type Message struct {
+	ID             uint   `gorm:"primaryKey"`
+	ConversationID uint   `gorm:"not null;index"`
+	SenderID       uint   `gorm:"not null"`
+	Body           string `gorm:"type:text;not null"`
+	CreatedAt      time.Time
+}
+
+// ConversationRequest starts a conversation with one or more users
+type ConversationRequest struct {
+	Recipients []string `json:"recipients"`
+	Subject    string   `json:"subject"`
+	Body       string   `json:"body"`
+}
+
+// ModmailRequest starts a modmail conversation with a sub's moderators
+type ModmailRequest struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// MessageRequest represents a reply in a conversation
+type MessageRequest struct {
+	Body string `json:"body"`
+}
+
+// MessageResponse represents a message in API responses; ReadBy lists the other participants who have read it
+type MessageResponse struct {
+	ID        uint     `json:"id"`
+	Sender    string   `json:"sender"`
+	Body      string   `json:"body"`
+	ReadBy    []string `json:"read_by"`
+	CreatedAt string   `json:"created_at"`
+}
+
+// ConversationResponse summarizes a conversation in the inbox
+type ConversationResponse struct {
+	ID           uint             `json:"id"`
+	Subject      string           `json:"subject"`
+	SubID        *uint            `json:"sub_id,omitempty"`
+	SubName      string           `json:"sub_name,omitempty"`
+	Participants []string         `json:"participants"`
+	LastMessage  *MessageResponse `json:"last_message,omitempty"`
+	Unread       int64            `json:"unread"`
+	UpdatedAt    string           `json:"updated_at"`
+}
+
+// ConversationListResponse is one page of conversations
+type ConversationListResponse struct {
+	Conversations []ConversationResponse `json:"conversations"`
+	Page          int                    `json:"page"`
+	Limit         int                    `json:"limit"`
+	Total         int64                  `json:"total"`
+}
+
+// ConversationDetailResponse is a conversation with one page of its messages, newest first
+type ConversationDetailResponse struct {
+	ConversationResponse
+	Messages []MessageResponse `json:"messages"`
+	Page     int               `json:"page"`
+	Limit    int               `json:"limit"`
+}
+
+// UnreadCountResponse reports how many unread messages a user has
+type UnreadCountResponse struct {
+	Unread int64 `json:"unread"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// Notification types
+const (
+	NotificationTypeJoinRequestApproved = "join_request_approved"
+	NotificationTypeJoinRequestDenied   = "join_request_denied"
+	NotificationTypeSubTransfer         = "sub_transfer"
+	NotificationTypeNewFollower         = "new_follower"
+	NotificationTypeFollowRequest       = "follow_request"
+	NotificationTypePostReply           = "post_reply"
+	NotificationTypeCommentReply        = "comment_reply"
+	NotificationTypeSubInvite           = "sub_invite"
+	NotificationTypeInviteAccepted      = "invite_accepted"
+	NotificationTypeVoteMilestone       = "vote_milestone"
+	NotificationTypeMention             = "mention"
+)
+
+// NotificationTypes lists every notification type a user can turn on or off
+var NotificationTypes = []string{
+	NotificationTypePostReply,
+	NotificationTypeCommentReply,
+	NotificationTypeMention,
+	NotificationTypeSubInvite,
+	NotificationTypeInviteAccepted,
+	NotificationTypeVoteMilestone,
+	NotificationTypeNewFollower,
+	NotificationTypeFollowRequest,
+	NotificationTypeJoinRequestApproved,
+	NotificationTypeJoinRequestDenied,
+	NotificationTypeSubTransfer,
+}
+
+// Notification is a message delivered to a user about activity that concerns them
+type Notification struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	Type      string `gorm:"not null"`
+	Message   string `gorm:"not null"`
+	SubID     *uint
+	PostID    *uint
+	CommentID *uint
+	Milestone int64 `gorm:"default:0"` // The score a vote milestone notification announces
+	Read      bool  `gorm:"default:false"`
+	CreatedAt time.Time
+}
+
+// NotificationPreference turns one notification type on or off for a user; types without a preference are on
+type NotificationPreference struct {
+	ID      uint   `gorm:"primaryKey"`
+	UserID  uint   `gorm:"not null;uniqueIndex:idx_notification_preference"`
+	Type    string `gorm:"not null;uniqueIndex:idx_notification_preference"`
+	Enabled bool   `gorm:"not null"`
+}
+
+// NotificationResponse represents a notification in API responses
+type NotificationResponse struct {
+	ID        uint   `json:"id"`
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+	SubID     *uint  `json:"sub_id,omitempty"`
+	PostID    *uint  `json:"post_id,omitempty"`
+	CommentID *uint  `json:"comment_id,omitempty"`
+	Milestone int64  `json:"milestone,omitempty"`
+	Read      bool   `json:"read"`
+	CreatedAt string `json:"created_at"`
+}
+
+// NotificationListResponse is one page of a user's notifications
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Page          int                    `json:"page"`
+	Limit         int                    `json:"limit"`
+	Total         int64                  `json:"total"`
+	Unread        int64                  `json:"unread"`
+}
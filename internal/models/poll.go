@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Poll is attached to a poll post, whose title is the question
+type Poll struct {
+	ID          uint       `gorm:"primaryKey"`
+	PostID      uint       `gorm:"not null;uniqueIndex"`
+	ClosesAt    *time.Time // When voting ends on its own; polls without one stay open until closed
+	ClosedAt    *time.Time // When the author or a moderator closed it early
+	HideResults bool       `gorm:"not null;default:false"` // Vote counts stay hidden until the poll closes
+	Options     []PollOption
+	CreatedAt   time.Time
+}
+
+// Closed reports whether a poll no longer takes votes
+func (p Poll) Closed(now time.Time) bool {
+	return p.ClosedAt != nil || (p.ClosesAt != nil && !now.Before(*p.ClosesAt))
+}
+
+// PollOption is one answer a poll can be voted for, listed in Position order
+type PollOption struct {
+	ID       uint   `gorm:"primaryKey"`
+	PollID   uint   `gorm:"not null;index"`
+	Position int    `gorm:"not null"`
+	Text     string `gorm:"not null"`
+}
+
+// PollVote is a user's vote in a poll; each user votes once
+type PollVote struct {
+	ID        uint `gorm:"primaryKey"`
+	PollID    uint `gorm:"not null;uniqueIndex:idx_poll_vote"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_poll_vote"`
+	OptionID  uint `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// PollRequest is the poll of a new poll post
+type PollRequest struct {
+	Options     []string   `json:"options"`                // 2 to 10 answers
+	ClosesAt    *time.Time `json:"closes_at,omitempty"`    // Optional time voting ends
+	HideResults bool       `json:"hide_results,omitempty"` // Hide vote counts until the poll closes
+}
+
+// PollVoteRequest is the body for voting in a poll
+type PollVoteRequest struct {
+	OptionID uint `json:"option_id" binding:"required"`
+}
+
+// PollOptionResponse is one answer of a poll; Votes is left out while the results are hidden
+type PollOptionResponse struct {
+	ID    uint   `json:"id"`
+	Text  string `json:"text"`
+	Votes *int64 `json:"votes,omitempty"`
+}
+
+// PollResponse is the poll of a poll post with its results
+type PollResponse struct {
+	Options       []PollOptionResponse `json:"options"`
+	TotalVotes    int64                `json:"total_votes"`
+	ClosesAt      string               `json:"closes_at,omitempty"`
+	Closed        bool                 `json:"closed"`
+	ResultsHidden bool                 `json:"results_hidden"`            // Counts are hidden until the poll closes
+	VotedOptionID *uint                `json:"voted_option_id,omitempty"` // The option the viewer voted for
+}
@@ -1,30 +1,143 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Post types that a sub can allow or disallow
+const (
+	PostTypeText  = "text"
+	PostTypeImage = "image"
+	PostTypeLink  = "link"
+	PostTypePoll  = "poll"
+)
+
+// Post statuses; drafts and scheduled posts are only visible to their author until they are published
+const (
+	PostStatusPublished = "published"
+	PostStatusDraft     = "draft"
+	PostStatusScheduled = "scheduled"
+)
 
 // Response struct to format the output
 type PostResponse struct {
-	ID        uint              `json:"id"`
-	Title     string            `json:"title"`
-	Content   string            `json:"content"`
-	ImageURL  *string           `json:"imageURL,omitempty"` // Link to an image
-	Username  string            `json:"username"`
-	Upvotes   int               `json:"upvotes"`
-	Downvotes int               `json:"downvotes"`
-	CreatedAt string            `json:"created_at"`
-	SubID     uint              `json:"sub_id"`
-	Comments  []CommentResponse `json:"comments"`
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	Content     string            `json:"content"`
+	ContentHTML string            `json:"content_html"`    // Content rendered as sanitized HTML
+	Image       *ImageResponse    `json:"image,omitempty"` // The post's image in each available size
+	Link        *LinkResponse     `json:"link,omitempty"`  // The linked page of a link post
+	Poll        *PollResponse     `json:"poll,omitempty"`  // The poll of a poll post, with its results
+	Username    string            `json:"username"`
+	Upvotes     int               `json:"upvotes"`
+	Downvotes   int               `json:"downvotes"`
+	CreatedAt   string            `json:"created_at"`
+	SubID       uint              `json:"sub_id"`
+	Flair       *FlairResponse    `json:"flair,omitempty"`
+	Mentions    []MentionResponse `json:"mentions,omitempty"` // Linked @username and r/subname references in Content
+	Pinned      bool              `json:"pinned"`
+	Locked      bool              `json:"locked"`
+	Archived    bool              `json:"archived"`
+	Saved       bool              `json:"saved"`             // Whether the viewer has saved this post
+	Deleted     bool              `json:"deleted,omitempty"` // Rendered as a "[deleted]" tombstone
+	Comments    []CommentResponse `json:"comments"`
+
+	// Sensitive content; NSFW includes posts in NSFW subs, and Blurred asks clients to blur the post for this viewer
+	NSFW    bool `json:"nsfw"`
+	Spoiler bool `json:"spoiler"`
+	Blurred bool `json:"blurred"`
+
+	// Crossposting: the post this one shares, and how often this one has been shared
+	Crosspost      *CrosspostResponse `json:"crosspost,omitempty"` // The post a crosspost shares, with a link back to it
+	CrosspostCount int64              `json:"crosspost_count"`     // How many published crossposts share this post
 }
 
 type Post struct {
-	ID        uint    `gorm:"primaryKey"`
-	Title     string  `json:"title" binding:"required"`
-	SubID     uint    `json:"sub_id" binding:"required"`
-	Content   string  `json:"content" binding:"required"`
-	Upvotes   int     `json:"upvotes"`
-	Downvotes int     `json:"downvotes"`
-	ImageURL  *string `json:"imageURL,omitempty"` // Link to an image
-	UserID    uint
-	User      User
-	CreatedAt time.Time
+	ID          uint         `gorm:"primaryKey"`
+	Title       string       `json:"title" binding:"required"`
+	SubID       uint         `json:"sub_id" binding:"required"`
+	Content     string       `json:"content"`      // Required except on link and poll posts
+	ContentHTML string       `json:"content_html"` // Content rendered as sanitized HTML, stored when Content is saved
+	Upvotes     int          `json:"upvotes"`
+	Downvotes   int          `json:"downvotes"`
+	ImageURL    *string      `json:"imageURL,omitempty"`            // Link to an image
+	MediaID     *uint        `json:"media_id,omitempty"`            // Uploaded image; when set, ImageURL links to it
+	URL         *string      `json:"url,omitempty"`                 // Link of a link post
+	Domain      string       `json:"domain,omitempty" gorm:"index"` // Host of URL without "www.", set from URL when the post is saved
+	FlairID     *uint        `json:"flair_id,omitempty"`            // Post flair from the sub's flair templates
+	Poll        *PollRequest `json:"poll,omitempty" gorm:"-"`       // Makes the post a poll; only read when the post is created
+	UserID      uint
+	User        User
+	CreatedAt   time.Time // Set again when a draft or scheduled post is published
+
+	// Publishing state; posts are published on creation unless saved as a draft or scheduled
+	Status       string     `json:"status" gorm:"default:published;index"`
+	ScheduledAt  *time.Time `json:"scheduled_at,omitempty" gorm:"index"` // When a scheduled post is published
+	PublishError string     `json:"publish_error,omitempty"`             // Why a scheduled post couldn't be published and went back to being a draft
+
+	// Sensitive content flags, set by the author or a moderator
+	NSFW    bool `json:"nsfw" gorm:"default:false;index"`
+	Spoiler bool `json:"spoiler" gorm:"default:false"`
+
+	// Crossposts share a post from another sub instead of having content of their own; set through the crosspost endpoint
+	CrosspostParentID *uint `json:"crosspost_parent_id,omitempty" gorm:"index"`
+
+	// Moderation state, only changed through the moderator endpoints and the archival job
+	Pinned   bool       `json:"pinned" gorm:"default:false"`
+	PinnedAt *time.Time `json:"-"`
+	Locked   bool       `json:"locked" gorm:"default:false"`   // No new comments or votes
+	Archived bool       `json:"archived" gorm:"default:false"` // Set automatically once a post is older than the sub's archive age
+
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // Soft deletion; purged after the retention period
+}
+
+// PostUpdateRequest is the body for editing a post; the title, sub and image can't be changed
+type PostUpdateRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// PostFlagsRequest is the body for marking a post NSFW or as a spoiler; flags left out are unchanged
+type PostFlagsRequest struct {
+	NSFW    *bool `json:"nsfw,omitempty"`
+	Spoiler *bool `json:"spoiler,omitempty"`
+}
+
+// DraftUpdateRequest is the body for editing a draft or scheduled post
+type DraftUpdateRequest struct {
+	Title   string `json:"title" binding:"required"`
+	Content string `json:"content"`
+}
+
+// ScheduleRequest is the body for scheduling a draft or moving a scheduled post to a new time
+type ScheduleRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" binding:"required"`
+}
+
+// DraftResponse is a draft or scheduled post as its author sees it
+type DraftResponse struct {
+	PostResponse
+	Status       string `json:"status"`
+	ScheduledAt  string `json:"scheduled_at,omitempty"`
+	PublishError string `json:"publish_error,omitempty"`
+}
+
+// Published reports whether a post is visible to everyone, matching the PublishedPosts query scope
+func (p Post) Published() bool {
+	return p.Status == PostStatusPublished
+}
+
+// PostType reports the kind of post, used to enforce a sub's allowed post types
+func (p Post) PostType() string {
+	if p.Poll != nil {
+		return PostTypePoll
+	}
+	if p.URL != nil && *p.URL != "" {
+		return PostTypeLink
+	}
+	if p.ImageURL != nil && *p.ImageURL != "" {
+		return PostTypeImage
+	}
+	return PostTypeText
 }
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Operations in a revision diff
+const (
+	DiffOpEqual  = "equal"
+	DiffOpInsert = "insert"
+	DiffOpDelete = "delete"
+)
+
+// Revision is one saved version of a post's or comment's content. The original version is recorded the first
+// time the content is edited, so content that was never edited has no stored revisions.
+type Revision struct {
+	ID          uint   `gorm:"primaryKey"`
+	ContentType string `gorm:"not null;uniqueIndex:idx_revision"` // ContentTypePost or ContentTypeComment
+	ContentID   uint   `gorm:"not null;uniqueIndex:idx_revision"`
+	Number      int    `gorm:"not null;uniqueIndex:idx_revision"` // 1 for the original version
+	Content     string `gorm:"not null"`
+	ContentHTML string `gorm:"not null"`
+	ImageURL    *string
+	CreatedAt   time.Time
+}
+
+// RevisionResponse is one version of a post or comment
+type RevisionResponse struct {
+	Revision    int     `json:"revision"`
+	Content     string  `json:"content"`
+	ContentHTML string  `json:"content_html"`
+	ImageURL    *string `json:"imageURL,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// RevisionListResponse is the edit history of a post or comment, oldest version first
+type RevisionListResponse struct {
+	Type      string             `json:"type"`
+	ID        uint               `json:"id"`
+	Revisions []RevisionResponse `json:"revisions"`
+}
+
+// DiffSegment is a run of text that is unchanged, added or removed between two revisions
+type DiffSegment struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// RevisionDiffResponse is a word-level diff between two revisions of a post or comment
+type RevisionDiffResponse struct {
+	Type         string        `json:"type"`
+	ID           uint          `json:"id"`
+	From         int           `json:"from"`
+	To           int           `json:"to"`
+	Content      []DiffSegment `json:"content"`
+	ImageChanged bool          `json:"image_changed"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// SavedItem is a post or comment a user bookmarked, optionally filed under a category they chose
+type SavedItem struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_saved_item"`
+	ContentType string `gorm:"not null;uniqueIndex:idx_saved_item"` // ContentTypePost or ContentTypeComment
+	ContentID   uint   `gorm:"not null;uniqueIndex:idx_saved_item"`
+	Category    string `gorm:"not null;default:''"` // Empty when uncategorized
+	CreatedAt   time.Time
+}
+
+// HiddenPost is a post a user doesn't want to see in their listings and feeds
+type HiddenPost struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_hidden_post"`
+	PostID    uint `gorm:"not null;uniqueIndex:idx_hidden_post"`
+	CreatedAt time.Time
+}
+
+// SaveRequest is the optional body for saving a post or comment; saving again moves it to the new category
+type SaveRequest struct {
+	Category string `json:"category"`
+}
+
+// SavedItemResponse is a saved post or comment; exactly one of Post and Comment is set
+type SavedItemResponse struct {
+	Type     string           `json:"type"`
+	Category string           `json:"category,omitempty"`
+	SavedAt  string           `json:"saved_at"`
+	PostID   uint             `json:"post_id"` // The saved post, or the post a saved comment belongs to
+	Post     *PostResponse    `json:"post,omitempty"`
+	Comment  *CommentResponse `json:"comment,omitempty"`
+}
+
+// SavedListResponse is one page of a user's saved items, with every category they use
+type SavedListResponse struct {
+	Items      []SavedItemResponse `json:"items"`
+	Categories []string            `json:"categories"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	Total      int64               `json:"total"`
+}
+
+// HiddenPostListResponse is one page of the posts a user has hidden
+type HiddenPostListResponse struct {
+	Posts []PostResponse `json:"posts"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+	Total int64          `json:"total"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Sub represents community
 type Sub struct {
@@ -11,17 +15,130 @@ type Sub struct {
 	OwnerID     uint `gorm:"not null"`
 	Owner       User `gorm:"foreignKey:OwnerID"` // ✅ Define the relationship
 	CreatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"` // Soft deletion; purged after the retention period
+
+	// Community settings (managed through the sub settings endpoint)
+	Sidebar           string `json:"sidebar" gorm:"type:text"`
+	AllowedPostTypes  string `json:"allowed_post_types" gorm:"default:'text,image,link,poll'"` // Comma-separated list of post types
+	MinAccountAgeDays int    `json:"min_account_age_days" gorm:"default:0"`
+	MinKarma          int    `json:"min_karma" gorm:"default:0"`              // Karma users need to post; 0 disables the check
+	RestrictedPosting bool   `json:"restricted_posting" gorm:"default:false"` // Only approved submitters can post
+	NSFW              bool   `json:"nsfw" gorm:"default:false"`
+	JoinMode          string `json:"join_mode" gorm:"default:'invite_only'"` // How users get into a private sub
+	ArchiveAfterDays  int    `json:"archive_after_days" gorm:"default:0"`    // Posts older than this are archived; 0 disables archival
 }
 
+// Join modes for private subs
+const (
+	JoinModeInviteOnly = "invite_only"
+	JoinModeRequest    = "request"
+)
+
+// SubModerator grants a user moderation rights in a sub; the owner is always a moderator
+type SubModerator struct {
+	ID        uint `gorm:"primaryKey"`
+	SubID     uint `gorm:"not null;uniqueIndex:idx_sub_moderator"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_sub_moderator"`
+	CreatedAt time.Time
+	User      User `gorm:"foreignKey:UserID"` // For preloading user data
+}
+
+// SubRule is a single entry in a sub's ordered list of rules
+type SubRule struct {
+	ID          uint   `gorm:"primaryKey"`
+	SubID       uint   `gorm:"not null;index"`
+	Position    int    `gorm:"not null"`
+	Title       string `gorm:"not null"`
+	Description string `gorm:"type:text"`
+	CreatedAt   time.Time
+}
+
+// SubApprovedSubmitter allows a user to post in a sub with restricted posting
+type SubApprovedSubmitter struct {
+	ID        uint `gorm:"primaryKey"`
+	SubID     uint `gorm:"not null;uniqueIndex:idx_sub_approved_submitter"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_sub_approved_submitter"`
+	CreatedAt time.Time
+	User      User `gorm:"foreignKey:UserID"` // For preloading user data
+}
+
+// Invitation statuses
+const (
+	InviteStatusPending  = "pending"
+	InviteStatusAccepted = "accepted"
+	InviteStatusRejected = "rejected"
+	InviteStatusRevoked  = "revoked"
+	InviteStatusExpired  = "expired"
+)
+
 // SubInvitation represents an invitation to join a private sub
 type SubInvitation struct {
-	ID        uint   `gorm:"primaryKey"`
-	SubID     uint   `gorm:"not null"`
-	InviterID uint   `gorm:"not null"`
-	InviteeID uint   `gorm:"not null"`
-	Status    string `gorm:"default:'pending'"` // pending, accepted, rejected
+	ID          uint   `gorm:"primaryKey"`
+	SubID       uint   `gorm:"not null"`
+	InviterID   uint   `gorm:"not null"`
+	InviteeID   uint   `gorm:"not null"`
+	Status      string `gorm:"default:'pending'"` // pending, accepted, rejected, revoked, expired
+	CreatedAt   time.Time
+	ExpiresAt   *time.Time // Nil means the invitation never expires
+	RespondedAt *time.Time
+	Invitee     User `gorm:"foreignKey:InviteeID"` // For preloading invitee data
+}
+
+// IsExpired reports whether the invitation is past its expiry time
+func (i SubInvitation) IsExpired() bool {
+	return i.ExpiresAt != nil && time.Now().After(*i.ExpiresAt)
+}
+
+// SubInviteLink is a single-use shareable link that grants membership to a private sub
+type SubInviteLink struct {
+	ID        uint      `gorm:"primaryKey"`
+	SubID     uint      `gorm:"not null;index"`
+	CreatorID uint      `gorm:"not null"`
+	Token     string    `gorm:"unique;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedByID  *uint
+	UsedAt    *time.Time
+	RevokedAt *time.Time
 	CreatedAt time.Time
-	Invitee   User `gorm:"foreignKey:InviteeID"` // For preloading invitee data
+}
+
+// Join request statuses
+const (
+	JoinRequestStatusPending  = "pending"
+	JoinRequestStatusApproved = "approved"
+	JoinRequestStatusDenied   = "denied"
+)
+
+// SubJoinRequest is a user's request to join a private sub, reviewed by its moderators
+type SubJoinRequest struct {
+	ID         uint   `gorm:"primaryKey"`
+	SubID      uint   `gorm:"not null;index"`
+	UserID     uint   `gorm:"not null"`
+	Message    string `gorm:"type:text"`
+	Status     string `gorm:"default:'pending'"` // pending, approved, denied
+	ReviewerID *uint
+	CreatedAt  time.Time
+	ReviewedAt *time.Time
+	User       User `gorm:"foreignKey:UserID"` // For preloading requester data
+}
+
+// Ownership transfer statuses
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+)
+
+// SubOwnershipTransfer is an owner's offer to hand a sub to another user, who has to accept it
+type SubOwnershipTransfer struct {
+	ID          uint   `gorm:"primaryKey"`
+	SubID       uint   `gorm:"not null;index"`
+	FromUserID  uint   `gorm:"not null"`
+	ToUserID    uint   `gorm:"not null"`
+	Status      string `gorm:"default:'pending'"` // pending, accepted, declined, cancelled
+	CreatedAt   time.Time
+	RespondedAt *time.Time
 }
 
 // SubMembership tracks users who join subs
@@ -30,7 +147,8 @@ type SubMembership struct {
 	SubID    uint `gorm:"not null"`
 	UserID   uint `gorm:"not null"`
 	JoinedAt time.Time
-	User     User `gorm:"foreignKey:UserID"` // For preloading user data
+	FlairID  *uint // User flair shown next to the member's name in this sub
+	User     User  `gorm:"foreignKey:UserID"` // For preloading user data
 }
 
 // SubResponse struct for formatted output
@@ -55,12 +173,132 @@ type InviteRequest struct {
 
 // SubMemberResponse represents a sub member in API responses
 type SubMemberResponse struct {
-	Username string `json:"username"`
-	JoinedAt string `json:"joined_at"`
+	Username string         `json:"username"`
+	JoinedAt string         `json:"joined_at"`
+	Flair    *FlairResponse `json:"flair,omitempty"`
 }
 
 // InviteResponse represents a pending invitation in API responses
 type InviteResponse struct {
+	ID              uint   `json:"id"`
 	InviteeUsername string `json:"invitee_username"`
 	CreatedAt       string `json:"created_at"`
+	ExpiresAt       string `json:"expires_at,omitempty"`
+}
+
+// UserInviteResponse represents an invitation received by the current user
+type UserInviteResponse struct {
+	ID              uint   `json:"id"`
+	SubID           uint   `json:"sub_id"`
+	SubName         string `json:"sub_name"`
+	InviterUsername string `json:"inviter_username"`
+	CreatedAt       string `json:"created_at"`
+	ExpiresAt       string `json:"expires_at,omitempty"`
+}
+
+// InviteLinkRequest represents the options for creating a shareable invite link
+type InviteLinkRequest struct {
+	ExpiresInHours int `json:"expires_in_hours,omitempty"`
+}
+
+// InviteLinkResponse represents a shareable invite link in API responses
+type InviteLinkResponse struct {
+	ID        uint   `json:"id"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+	Used      bool   `json:"used"`
+	CreatedAt string `json:"created_at"`
+}
+
+// SubRuleRequest represents a single rule in a settings update
+type SubRuleRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// SubSettingsRequest represents the settings that can be changed by the sub owner.
+// Only non-nil fields are applied; Rules replaces the whole ordered list.
+type SubSettingsRequest struct {
+	Rules             *[]SubRuleRequest `json:"rules,omitempty"`
+	Sidebar           *string           `json:"sidebar,omitempty"`
+	AllowedPostTypes  *[]string         `json:"allowed_post_types,omitempty"`
+	MinAccountAgeDays *int              `json:"min_account_age_days,omitempty"`
+	MinKarma          *int              `json:"min_karma,omitempty"`
+	RestrictedPosting *bool             `json:"restricted_posting,omitempty"`
+	NSFW              *bool             `json:"nsfw,omitempty"`
+	JoinMode          *string           `json:"join_mode,omitempty"`
+	ArchiveAfterDays  *int              `json:"archive_after_days,omitempty"`
+}
+
+// SubRuleResponse represents a sub rule in API responses
+type SubRuleResponse struct {
+	Position    int    `json:"position"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// SubSettingsResponse represents a sub's settings in API responses
+type SubSettingsResponse struct {
+	SubID             uint              `json:"sub_id"`
+	Rules             []SubRuleResponse `json:"rules"`
+	Sidebar           string            `json:"sidebar"`
+	AllowedPostTypes  []string          `json:"allowed_post_types"`
+	MinAccountAgeDays int               `json:"min_account_age_days"`
+	MinKarma          int               `json:"min_karma"`
+	RestrictedPosting bool              `json:"restricted_posting"`
+	NSFW              bool              `json:"nsfw"`
+	JoinMode          string            `json:"join_mode"`
+	ArchiveAfterDays  int               `json:"archive_after_days"`
+}
+
+// ApprovedSubmitterRequest identifies a user to approve for posting
+type ApprovedSubmitterRequest struct {
+	Username string `json:"username"`
+}
+
+// ApprovedSubmitterResponse represents an approved submitter in API responses
+type ApprovedSubmitterResponse struct {
+	Username   string `json:"username"`
+	ApprovedAt string `json:"approved_at"`
+}
+
+// ModeratorRequest identifies a user to add as a sub moderator
+type ModeratorRequest struct {
+	Username string `json:"username"`
+}
+
+// ModeratorResponse represents a sub moderator in API responses
+type ModeratorResponse struct {
+	Username string `json:"username"`
+	AddedAt  string `json:"added_at"`
+}
+
+// JoinRequestRequest represents a request to join a private sub
+type JoinRequestRequest struct {
+	Message string `json:"message"`
+}
+
+// JoinRequestResponse represents a join request in API responses
+type JoinRequestResponse struct {
+	ID        uint   `json:"id"`
+	Username  string `json:"username"`
+	Message   string `json:"message"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+}
+
+// TransferRequest identifies the user a sub should be transferred to
+type TransferRequest struct {
+	Username string `json:"username"`
+}
+
+// TransferResponse represents an ownership transfer in API responses
+type TransferResponse struct {
+	ID           uint   `json:"id"`
+	SubID        uint   `json:"sub_id"`
+	SubName      string `json:"sub_name"`
+	FromUsername string `json:"from_username"`
+	ToUsername   string `json:"to_username"`
+	Status       string `json:"status"`
+	CreatedAt    string `json:"created_at"`
 }
@@ -2,22 +2,43 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
+)
+
+// Ways of showing sensitive content, set separately for NSFW posts and spoilers
+const (
+	ContentHide = "hide"
+	ContentBlur = "blur"
+	ContentShow = "show"
 )
 
 // User represents a registered user account
 type User struct {
-	ID           uint      `gorm:"primaryKey"`
-	Username     string    `gorm:"unique;not null" json:"username"`
-	Password     string    `gorm:"not null" json:"-"`
-	Email        *string   `gorm:"unique" json:"email,omitempty"`
-	DisplayName  string    `gorm:"default:''" json:"display_name"`
-	Bio          string    `gorm:"type:text" json:"bio"`
-	AvatarURL    *string   `json:"avatar_url,omitempty"`
-	IsPrivate    bool      `gorm:"default:false" json:"is_private"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	RefreshToken *string   `gorm:"unique" json:"-"`
-	TokenExpires time.Time `json:"-"`
+	ID            uint      `gorm:"primaryKey"`
+	Username      string    `gorm:"unique;not null" json:"username"`
+	Password      string    `gorm:"not null" json:"-"`
+	Email         *string   `gorm:"unique" json:"email,omitempty"`
+	DisplayName   string    `gorm:"default:''" json:"display_name"`
+	Bio           string    `gorm:"type:text" json:"bio"`
+	AvatarURL     *string   `json:"avatar_url,omitempty"`
+	AvatarMediaID *uint     `json:"avatar_media_id,omitempty"` // Uploaded avatar that AvatarURL links to
+	IsPrivate     bool      `gorm:"default:false" json:"is_private"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	RefreshToken  *string   `gorm:"unique" json:"-"`
+	TokenExpires  time.Time `json:"-"`
+
+	// How NSFW posts and spoilers are shown to the user: hidden from listings, blurred, or shown
+	NSFWContent    string `gorm:"default:'hide'" json:"nsfw_content"`
+	SpoilerContent string `gorm:"default:'blur'" json:"spoiler_content"`
+
+	// Karma: the score of the user's posts and comments, kept up to date as votes change
+	PostKarma    int `gorm:"default:0" json:"post_karma"`
+	CommentKarma int `gorm:"default:0" json:"comment_karma"`
+
+	IsAdmin   bool           `gorm:"default:false" json:"-"` // Site administrators can restore deleted content
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`         // Soft deletion; anonymized after the retention period
 }
 
 // UserResponse represents user data in API responses (excludes sensitive fields)
@@ -28,16 +49,35 @@ type UserResponse struct {
 	Bio         string  `json:"bio"`
 	AvatarURL   *string `json:"avatar_url,omitempty"`
 	IsPrivate   bool    `json:"is_private"`
-	CreatedAt   string  `json:"created_at"`
+	CreatedAt   string  `json:"created_at,omitempty"`
+
+	FollowerCount  int64 `json:"follower_count"`
+	FollowingCount int64 `json:"following_count"`
+
+	PostKarma    int `json:"post_karma"`
+	CommentKarma int `json:"comment_karma"`
+	Karma        int `json:"karma"` // Post and comment karma combined
+
+	// Profile sections a private profile hides from this viewer (activity, follows, karma, join_date); their fields are left empty
+	Hidden []string `json:"hidden,omitempty"`
+}
+
+// Karma is the user's post and comment karma combined
+func (u User) Karma() int {
+	return u.PostKarma + u.CommentKarma
 }
 
 // UserUpdateRequest represents data that can be updated by the user
 type UserUpdateRequest struct {
-	Email       *string `json:"email,omitempty"`
-	DisplayName *string `json:"display_name,omitempty"`
-	Bio         *string `json:"bio,omitempty"`
-	AvatarURL   *string `json:"avatar_url,omitempty"`
-	IsPrivate   *bool   `json:"is_private,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	DisplayName   *string `json:"display_name,omitempty"`
+	Bio           *string `json:"bio,omitempty"`
+	AvatarURL     *string `json:"avatar_url,omitempty"`
+	AvatarMediaID *uint   `json:"avatar_media_id,omitempty"` // Uploaded image to use as the avatar, instead of AvatarURL
+	IsPrivate     *bool   `json:"is_private,omitempty"`
+
+	NSFWContent    *string `json:"nsfw_content,omitempty"`    // hide, blur or show
+	SpoilerContent *string `json:"spoiler_content,omitempty"` // hide, blur or show
 }
 
 // UserProfileResponse represents the full profile view (includes private data for owner)
@@ -51,4 +91,7 @@ type UserProfileResponse struct {
 	IsPrivate   bool    `json:"is_private"`
 	CreatedAt   string  `json:"created_at"`
 	UpdatedAt   string  `json:"updated_at"`
+
+	NSFWContent    string `json:"nsfw_content"`
+	SpoilerContent string `json:"spoiler_content"`
 }
@@ -0,0 +1,227 @@
+package repositories
+
+import (
+	"fmt"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// Scores used to sort activity by votes
+const (
+	postScoreSQL    = "(SELECT COALESCE(SUM(votes.vote), 0) FROM votes WHERE votes.post_id = posts.id AND votes.comment_id IS NULL)"
+	commentScoreSQL = "(SELECT COALESCE(SUM(votes.vote), 0) FROM votes WHERE votes.comment_id = comments.id)"
+)
+
+// IActivityRepository defines methods for a user's post, comment and vote history
+type IActivityRepository interface {
+	GetUserPosts(userID, viewerID uint, sort string, offset, limit int) ([]models.PostResponse, int64, error)
+	GetUserComments(userID, viewerID uint, sort string, offset, limit int) ([]models.UserCommentResponse, int64, error)
+	GetUpvotedPosts(userID uint, sort string, offset, limit int) ([]models.PostResponse, int64, error)
+	GetOverview(userID, viewerID uint, sort string, offset, limit int) ([]models.ActivityItemResponse, int64, error)
+}
+
+// ActivityRepository implements IActivityRepository
+type ActivityRepository struct{}
+
+// NewActivityRepository creates a new activity repository
+func NewActivityRepository() IActivityRepository {
+	return &ActivityRepository{}
+}
+
+// GetUserPosts returns one page of a user's posts that the viewer can see
+func (r *ActivityRepository) GetUserPosts(userID, viewerID uint, sort string, offset, limit int) ([]models.PostResponse, int64, error) {
+	settings := loadContentSettings([]uint{viewerID})
+	query := visiblePosts(viewerID, settings).Where("posts.user_id = ?", userID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch posts")
+	}
+
+	var posts []models.Post
+	if err := query.Preload("User").Order(activityOrder(sort, postScoreSQL, "posts")).Offset(offset).Limit(limit).Find(&posts).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch posts")
+	}
+	return formatViewerPosts(viewerID, settings, posts), total, nil
+}
+
+// GetUserComments returns one page of a user's comments on posts the viewer can see
+func (r *ActivityRepository) GetUserComments(userID, viewerID uint, sort string, offset, limit int) ([]models.UserCommentResponse, int64, error) {
+	query := visibleComments(viewerID).Where("comments.user_id = ?", userID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch comments")
+	}
+
+	var comments []models.Comment
+	if err := query.Preload("User").Order(activityOrder(sort, commentScoreSQL, "comments")).Offset(offset).Limit(limit).Find(&comments).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch comments")
+	}
+	return formatUserComments(comments), total, nil
+}
+
+// GetUpvotedPosts returns one page of the posts a user upvoted and can still see, most recently upvoted first
+// unless sorted by score. Posts by users they blocked are left out.
+func (r *ActivityRepository) GetUpvotedPosts(userID uint, sort string, offset, limit int) ([]models.PostResponse, int64, error) {
+	settings := loadContentSettings([]uint{userID})
+	query := visiblePosts(userID, settings).
+		Joins("JOIN votes ON votes.post_id = posts.id AND votes.comment_id IS NULL AND votes.vote = 1 AND votes.user_id = ?", userID).
+		Where("posts.user_id NOT IN (?)", db.DB.Model(&models.UserBlock{}).Select("blocked_id").Where("blocker_id = ?", userID))
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch posts")
+	}
+
+	// Votes aren't timestamped, so the most recent upvotes are the ones saved last
+	order := "votes.id DESC"
+	if sort == models.ActivitySortTop {
+		order = postScoreSQL + " DESC, votes.id DESC"
+	}
+	var posts []models.Post
+	if err := query.Preload("User").Order(order).Offset(offset).Limit(limit).Find(&posts).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch posts")
+	}
+	return formatViewerPosts(userID, settings, posts), total, nil
+}
+
+// GetOverview returns one page of a user's posts and comments that the viewer can see, sorted together
+func (r *ActivityRepository) GetOverview(userID, viewerID uint, sort string, offset, limit int) ([]models.ActivityItemResponse, int64, error) {
+	settings := loadContentSettings([]uint{viewerID})
+	posts := visiblePosts(viewerID, settings).Where("posts.user_id = ?", userID)
+	comments := visibleComments(viewerID).Where("comments.user_id = ?", userID)
+
+	var postCount, commentCount int64
+	if err := posts.Session(&gorm.Session{}).Count(&postCount).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch activity")
+	}
+	if err := comments.Session(&gorm.Session{}).Count(&commentCount).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch activity")
+	}
+
+	order := "created_at DESC, type DESC, id DESC"
+	if sort == models.ActivitySortTop {
+		order = "score DESC, " + order
+	}
+	var rows []struct {
+		Type string
+		ID   uint
+	}
+	if err := db.DB.Raw("SELECT type, id FROM ((?) UNION ALL (?)) AS activity ORDER BY "+order+" LIMIT ? OFFSET ?",
+		posts.Select("'"+models.ContentTypePost+"' AS type, posts.id, posts.created_at, "+postScoreSQL+" AS score"),
+		comments.Select("'"+models.ContentTypeComment+"' AS type, comments.id, comments.created_at, "+commentScoreSQL+" AS score"),
+		limit, offset).Scan(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch activity")
+	}
+
+	var postIDs, commentIDs []uint
+	for _, row := range rows {
+		if row.Type == models.ContentTypePost {
+			postIDs = append(postIDs, row.ID)
+		} else {
+			commentIDs = append(commentIDs, row.ID)
+		}
+	}
+
+	postsByID := map[uint]models.PostResponse{}
+	if len(postIDs) > 0 {
+		var found []models.Post
+		if err := db.DB.Preload("User").Where("id IN ?", postIDs).Find(&found).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to fetch activity")
+		}
+		for _, post := range formatViewerPosts(viewerID, settings, found) {
+			postsByID[post.ID] = post
+		}
+	}
+	commentsByID := map[uint]models.UserCommentResponse{}
+	if len(commentIDs) > 0 {
+		var found []models.Comment
+		if err := db.DB.Preload("User").Where("id IN ?", commentIDs).Find(&found).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to fetch activity")
+		}
+		for _, comment := range formatUserComments(found) {
+			commentsByID[comment.ID] = comment
+		}
+	}
+
+	items := []models.ActivityItemResponse{}
+	for _, row := range rows {
+		if post, ok := postsByID[row.ID]; ok && row.Type == models.ContentTypePost {
+			items = append(items, models.ActivityItemResponse{Type: row.Type, Post: &post})
+		} else if comment, ok := commentsByID[row.ID]; ok && row.Type == models.ContentTypeComment {
+			items = append(items, models.ActivityItemResponse{Type: row.Type, Comment: &comment})
+		}
+	}
+	return items, postCount + commentCount, nil
+}
+
+// visiblePosts starts a query over the published posts a viewer can see: posts in live subs that are public or
+// that the viewer owns or belongs to, without the sensitive posts the viewer chose to hide
+func visiblePosts(viewerID uint, settings contentSettings) *gorm.DB {
+	visibleSubs := db.DB.Model(&models.Sub{}).Select("id").
+		Where("private = ? OR owner_id = ? OR id IN (?)", false, viewerID,
+			db.DB.Model(&models.SubMembership{}).Select("sub_id").Where("user_id = ?", viewerID))
+	return db.DB.Model(&models.Post{}).Where("posts.sub_id IN (?)", visibleSubs).Scopes(PublishedPosts, hideSensitivePosts(settings))
+}
+
+// visibleComments starts a query over the comments on posts a viewer can see
+func visibleComments(viewerID uint) *gorm.DB {
+	return db.DB.Model(&models.Comment{}).
+		Where("comments.post_id IN (?)", visiblePosts(viewerID, loadContentSettings([]uint{viewerID})).Select("posts.id"))
+}
+
+// activityOrder sorts posts or comments newest first, or by score
+func activityOrder(sort, scoreSQL, table string) string {
+	order := table + ".created_at DESC, " + table + ".id DESC"
+	if sort == models.ActivitySortTop {
+		return scoreSQL + " DESC, " + order
+	}
+	return order
+}
+
+// formatViewerPosts formats posts with the flags that depend on who is viewing them
+func formatViewerPosts(viewerID uint, settings contentSettings, posts []models.Post) []models.PostResponse {
+	responses := formatPosts(posts)
+	markSensitivePosts(settings, responses)
+	markSavedPosts([]uint{viewerID}, responses)
+	markPollVotes([]uint{viewerID}, responses)
+	return responses
+}
+
+// formatUserComments formats comments with the title and sub of the post each one was made in
+func formatUserComments(comments []models.Comment) []models.UserCommentResponse {
+	postIDs := []uint{}
+	for _, comment := range comments {
+		postIDs = append(postIDs, comment.PostID)
+	}
+
+	var posts []struct {
+		ID      uint
+		Title   string
+		SubID   uint
+		SubName string
+	}
+	if len(postIDs) > 0 {
+		// The context is informational, so comments still load without it
+		db.DB.Table("posts").Select("posts.id, posts.title, posts.sub_id, subs.name AS sub_name").
+			Joins("JOIN subs ON subs.id = posts.sub_id").Where("posts.id IN ?", postIDs).Scan(&posts)
+	}
+	postIndex := map[uint]int{}
+	for i, post := range posts {
+		postIndex[post.ID] = i
+	}
+
+	mentions := getMentionResponses("comment_id", commentIDs(comments))
+	responses := []models.UserCommentResponse{}
+	for _, comment := range comments {
+		response := models.UserCommentResponse{CommentResponse: formatComment(comment, mentions), PostID: comment.PostID}
+		if i, ok := postIndex[comment.PostID]; ok {
+			response.PostTitle, response.SubID, response.SubName = posts[i].Title, posts[i].SubID, posts[i].SubName
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
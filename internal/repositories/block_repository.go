@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IBlockRepository defines methods for user blocks
+type IBlockRepository interface {
+	GetBlock(blockerID, blockedID uint) (*models.UserBlock, error)
+	CreateBlock(block *models.UserBlock) error
+	DeleteBlock(block *models.UserBlock) error
+	GetBlockedUsers(blockerID uint) ([]models.BlockResponse, error)
+	IsBlocked(blockerID, blockedID uint) (bool, error)
+	GetBlockerIDs(blockedID uint, userIDs []uint) ([]uint, error)
+}
+
+// BlockRepository implements IBlockRepository
+type BlockRepository struct{}
+
+// NewBlockRepository creates a new block repository
+func NewBlockRepository() IBlockRepository {
+	return &BlockRepository{}
+}
+
+func (r *BlockRepository) GetBlock(blockerID, blockedID uint) (*models.UserBlock, error) {
+	var block models.UserBlock
+	if err := db.DB.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).First(&block).Error; err != nil {
+		return nil, fmt.Errorf("block not found")
+	}
+	return &block, nil
+}
+
+// CreateBlock saves a block and drops any follows between the two users
+func (r *BlockRepository) CreateBlock(block *models.UserBlock) error {
+	block.CreatedAt = time.Now()
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(block).Error; err != nil {
+			return err
+		}
+		return tx.Where("(follower_id = ? AND following_id = ?) OR (follower_id = ? AND following_id = ?)",
+			block.BlockerID, block.BlockedID, block.BlockedID, block.BlockerID).Delete(&models.UserFollow{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to block user")
+	}
+	return nil
+}
+
+func (r *BlockRepository) DeleteBlock(block *models.UserBlock) error {
+	if err := db.DB.Delete(block).Error; err != nil {
+		return fmt.Errorf("failed to unblock user")
+	}
+	return nil
+}
+
+func (r *BlockRepository) GetBlockedUsers(blockerID uint) ([]models.BlockResponse, error) {
+	var rows []struct {
+		Username  string
+		CreatedAt time.Time
+	}
+	if err := db.DB.Table("user_blocks").
+		Select("users.username, user_blocks.created_at").
+		Joins("JOIN users ON users.id = user_blocks.blocked_id AND users.deleted_at IS NULL").
+		Where("user_blocks.blocker_id = ?", blockerID).
+		Order("user_blocks.created_at DESC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch blocked users")
+	}
+
+	blocks := []models.BlockResponse{}
+	for _, row := range rows {
+		blocks = append(blocks, models.BlockResponse{
+			Username:  row.Username,
+			BlockedAt: row.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return blocks, nil
+}
+
+func (r *BlockRepository) IsBlocked(blockerID, blockedID uint) (bool, error) {
+	var count int64
+	if err := db.DB.Model(&models.UserBlock{}).Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check blocks")
+	}
+	return count > 0, nil
+}
+
+// GetBlockerIDs returns which of the given users have blocked a user
+func (r *BlockRepository) GetBlockerIDs(blockedID uint, userIDs []uint) ([]uint, error) {
+	blockerIDs := []uint{}
+	if len(userIDs) == 0 {
+		return blockerIDs, nil
+	}
+	if err := db.DB.Model(&models.UserBlock{}).Where("blocked_id = ? AND blocker_id IN ?", blockedID, userIDs).
+		Pluck("blocker_id", &blockerIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to check blocks")
+	}
+	return blockerIDs, nil
+}
+
+// HideBlockedAuthors is a query scope that drops posts or comments written by users the viewer has blocked.
+// Every listing applies it so blocked content is filtered the same way everywhere.
+func HideBlockedAuthors(viewerUsername string) func(*gorm.DB) *gorm.DB {
+	if viewerUsername == "" {
+		return func(tx *gorm.DB) *gorm.DB { return tx }
+	}
+	return hideBlockedAuthors(db.DB.Model(&models.User{}).Select("id").Where("username = ?", viewerUsername))
+}
+
+// hideBlockedAuthors builds the scope for a viewer ID list or subquery
+func hideBlockedAuthors(viewerIDs interface{}) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("user_id NOT IN (?)", db.DB.Model(&models.UserBlock{}).Select("blocked_id").Where("blocker_id IN (?)", viewerIDs))
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"time"
 
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/markdown"
 	models "github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
 )
 
 // ICommentRepository defines methods for comment repository
 type ICommentRepository interface {
-	GetCommentsByPostID(postID string) (*[]models.CommentResponse, error)
+	GetCommentsByPostID(postID, username string) (*[]models.CommentResponse, error)
 	GetCommentByID(commentID uint) (*models.CommentResponse, error)
+	GetComment(commentID uint) (*models.Comment, error)
 	CreateComment(username string, commentReq models.CommentRequest, post models.Post) (*models.Comment, error)
 	UpdateComment(commentID uint, commentReq models.CommentUpdateRequest) (*models.Comment, error)
 	DeleteComment(commentID uint) error
@@ -25,32 +28,20 @@ func NewCommentRepository() ICommentRepository {
 	return &CommentRepository{}
 }
 
-func (r *CommentRepository) GetCommentsByPostID(postID string) (*[]models.CommentResponse, error) {
+func (r *CommentRepository) GetCommentsByPostID(postID, username string) (*[]models.CommentResponse, error) {
 	var comments []models.Comment
 
-	// Fetch comments and preload user details
-	if err := db.DB.Preload("User").Where("post_id = ?", postID).Order("created_at DESC").Find(&comments).Error; err != nil {
+	// Fetch comments and preload user details; deleted comments are included as tombstones
+	// and comments by users the viewer blocked are left out
+	if err := db.DB.Unscoped().Preload("User").Where("post_id = ?", postID).Scopes(HideBlockedAuthors(username)).Order("created_at DESC").Find(&comments).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch comments")
 	}
 
 	// Format response to exclude sensitive data
+	mentions := getMentionResponses("comment_id", commentIDs(comments))
 	var formattedComments []models.CommentResponse
 	for _, comment := range comments {
-		response := models.CommentResponse{
-			ID:        comment.ID,
-			Content:   comment.Content,
-			ImageURL:  comment.ImageURL,
-			ParentID:  comment.ParentID,
-			Username:  comment.User.Username, // Include only username, not full User object
-			CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
-		}
-
-		if comment.UpdatedAt != nil {
-			updatedAtStr := comment.UpdatedAt.Format("2006-01-02 15:04:05")
-			response.UpdatedAt = updatedAtStr
-		}
-
-		formattedComments = append(formattedComments, response)
+		formattedComments = append(formattedComments, formatComment(comment, mentions))
 	}
 
 	return &formattedComments, nil
@@ -65,21 +56,17 @@ func (r *CommentRepository) GetCommentByID(commentID uint) (*models.CommentRespo
 	}
 
 	// Format response
-	response := models.CommentResponse{
-		ID:        comment.ID,
-		Content:   comment.Content,
-		ImageURL:  comment.ImageURL,
-		ParentID:  comment.ParentID,
-		Username:  comment.User.Username,
-		CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
-	}
+	response := formatComment(comment, getMentionResponses("comment_id", []uint{comment.ID}))
+	return &response, nil
+}
 
-	if comment.UpdatedAt != nil {
-		updatedAtStr := comment.UpdatedAt.Format("2006-01-02 15:04:05")
-		response.UpdatedAt = updatedAtStr
+// GetComment returns a comment as stored, with its author's ID rather than their display name
+func (r *CommentRepository) GetComment(commentID uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := db.DB.First(&comment, commentID).Error; err != nil {
+		return nil, fmt.Errorf("comment not found")
 	}
-
-	return &response, nil
+	return &comment, nil
 }
 
 func (r *CommentRepository) CreateComment(username string, commentReq models.CommentRequest, post models.Post) (*models.Comment, error) {
@@ -92,11 +79,13 @@ func (r *CommentRepository) CreateComment(username string, commentReq models.Com
 
 	// Create the new comment with the correct user ID
 	comment := models.Comment{
-		PostID:   post.ID,
-		ParentID: commentReq.ParentID,
-		Content:  commentReq.Content,
-		ImageURL: commentReq.ImageURL,
-		UserID:   user.ID, // Assign the authenticated user's ID
+		PostID:      post.ID,
+		ParentID:    commentReq.ParentID,
+		Content:     commentReq.Content,
+		ContentHTML: markdown.Render(commentReq.Content),
+		ImageURL:    commentReq.ImageURL,
+		MediaID:     commentReq.MediaID,
+		UserID:      user.ID, // Assign the authenticated user's ID
 	}
 
 	// Save the comment to the database
@@ -118,17 +107,34 @@ func (r *CommentRepository) UpdateComment(commentID uint, commentReq models.Comm
 	// Update fields
 	now := time.Now()
 	comment.Content = commentReq.Content
+	comment.ContentHTML = markdown.Render(commentReq.Content)
 	comment.ImageURL = commentReq.ImageURL
+	comment.MediaID = commentReq.MediaID
 	comment.UpdatedAt = &now
 
-	// Save updates
-	if err := db.DB.Save(&comment).Error; err != nil {
+	// Save updates along with the new revision, so the edit history never misses a version
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		revision := models.Revision{
+			ContentType: models.ContentTypeComment,
+			ContentID:   comment.ID,
+			Content:     comment.Content,
+			ContentHTML: comment.ContentHTML,
+			ImageURL:    comment.ImageURL,
+			CreatedAt:   now,
+		}
+		if err := saveRevision(tx, revision); err != nil {
+			return err
+		}
+		return tx.Save(&comment).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &comment, nil
 }
 
+// DeleteComment soft-deletes a comment; it keeps rendering as a tombstone so replies stay threaded
 func (r *CommentRepository) DeleteComment(commentID uint) error {
 	// Delete the comment
 	if err := db.DB.Delete(&models.Comment{}, commentID).Error; err != nil {
@@ -138,27 +144,9 @@ func (r *CommentRepository) DeleteComment(commentID uint) error {
 	return nil
 }
 
-func GetCommentsByPostID(postID string) (*[]models.CommentResponse, error) {
-	var comments []models.Comment
-
-	// Fetch comments and preload user details
-	if err := db.DB.Preload("User").Where("post_id = ?", postID).Order("created_at DESC").Find(&comments).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch comments")
-	}
-
-	// Format response to exclude sensitive data
-	var formattedComments []models.CommentResponse
-	for _, comment := range comments {
-		formattedComments = append(formattedComments, models.CommentResponse{
-			ID:        comment.ID,
-			Content:   comment.Content,
-			ImageURL:  comment.ImageURL,
-			Username:  comment.User.Username, // Include only username, not full User object
-			CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
-		})
-	}
-
-	return &formattedComments, nil
+// GetCommentsByPostID lists a post's comments for a viewer, leaving out users they blocked
+func GetCommentsByPostID(postID, username string) (*[]models.CommentResponse, error) {
+	return NewCommentRepository().GetCommentsByPostID(postID, username)
 }
 
 func CreateComment(username string, commentReq models.CommentRequest, post models.Post) (*models.Comment, error) {
@@ -171,10 +159,12 @@ func CreateComment(username string, commentReq models.CommentRequest, post model
 
 	// Create the new comment with the correct user ID
 	comment := models.Comment{
-		PostID:   post.ID,
-		Content:  commentReq.Content,
-		ImageURL: commentReq.ImageURL,
-		UserID:   user.ID, // Assign the authenticated user's ID
+		PostID:      post.ID,
+		Content:     commentReq.Content,
+		ContentHTML: markdown.Render(commentReq.Content),
+		ImageURL:    commentReq.ImageURL,
+		MediaID:     commentReq.MediaID,
+		UserID:      user.ID, // Assign the authenticated user's ID
 	}
 
 	// Save the comment to the database
@@ -184,3 +174,42 @@ func CreateComment(username string, commentReq models.CommentRequest, post model
 
 	return &comment, nil
 }
+
+// commentIDs collects the IDs of a set of comments
+func commentIDs(comments []models.Comment) []uint {
+	ids := []uint{}
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	return ids
+}
+
+// formatComment converts a comment to its response format, rendering deleted comments as tombstones;
+// mentions holds the comment's mention spans as loaded by getMentionResponses
+func formatComment(comment models.Comment, mentions map[uint][]models.MentionResponse) models.CommentResponse {
+	response := models.CommentResponse{
+		ID:          comment.ID,
+		Content:     comment.Content,
+		ContentHTML: renderedContent(comment.Content, comment.ContentHTML),
+		Mentions:    mentions[comment.ID],
+		Image:       ImageFor(comment.MediaID, comment.ImageURL),
+		ParentID:    comment.ParentID,
+		Username:    authorName(comment.User), // Include only username, not full User object
+		CreatedAt:   comment.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	if comment.UpdatedAt != nil {
+		response.UpdatedAt = comment.UpdatedAt.Format("2006-01-02 15:04:05")
+	}
+
+	if comment.DeletedAt.Valid {
+		response.Content = models.DeletedPlaceholder
+		response.ContentHTML = markdown.Render(models.DeletedPlaceholder)
+		response.Image = nil
+		response.Mentions = nil
+		response.Username = models.DeletedPlaceholder
+		response.Deleted = true
+	}
+
+	return response
+}
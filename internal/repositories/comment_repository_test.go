@@ -54,7 +54,7 @@ func TestGetCommentsByPostID(t *testing.T) {
 	database.DB.Create(&comment2)
 
 	t.Run("get comments for existing post", func(t *testing.T) {
-		comments, err := GetCommentsByPostID("1") // Assuming ID is 1
+		comments, err := GetCommentsByPostID("1", "") // Assuming ID is 1
 
 		assert.NoError(t, err)
 		assert.NotNil(t, comments)
@@ -76,7 +76,7 @@ func TestGetCommentsByPostID(t *testing.T) {
 		}
 		database.DB.Create(&emptyPost)
 
-		comments, err := GetCommentsByPostID("2") // Assuming ID is 2
+		comments, err := GetCommentsByPostID("2", "") // Assuming ID is 2
 
 		assert.NoError(t, err)
 		assert.NotNil(t, comments)
@@ -85,7 +85,7 @@ func TestGetCommentsByPostID(t *testing.T) {
 	})
 
 	t.Run("get comments for non-existent post", func(t *testing.T) {
-		comments, err := GetCommentsByPostID("999")
+		comments, err := GetCommentsByPostID("999", "")
 
 		assert.NoError(t, err) // This should not error, just return empty
 		assert.NotNil(t, comments)
@@ -280,6 +280,22 @@ func TestUpdateComment(t *testing.T) {
 		assert.NotNil(t, updatedComment.UpdatedAt)
 	})
 
+	t.Run("update renders markdown for the new revision", func(t *testing.T) {
+		updateReq := models.CommentUpdateRequest{
+			Content: "**bold** <script>alert(1)</script>",
+		}
+
+		repo := NewCommentRepository()
+		updatedComment, err := repo.UpdateComment(comment.ID, updateReq)
+		assert.NoError(t, err)
+		assert.Contains(t, updatedComment.ContentHTML, "<strong>bold</strong>")
+		assert.NotContains(t, updatedComment.ContentHTML, "<script>")
+
+		response, err := repo.GetCommentByID(comment.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, updatedComment.ContentHTML, response.ContentHTML)
+	})
+
 	t.Run("update comment with invalid ID", func(t *testing.T) {
 		updateReq := models.CommentUpdateRequest{
 			Content: "Updated content for invalid ID",
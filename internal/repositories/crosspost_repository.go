@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"fmt"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+)
+
+// ICrosspostRepository defines methods for sharing posts in other subs
+type ICrosspostRepository interface {
+	GetPost(postID uint) (*models.Post, error)
+	HasCrossposted(userID, parentID, subID uint) (bool, error)
+	CreateCrosspost(post *models.Post) error
+}
+
+// CrosspostRepository implements ICrosspostRepository
+type CrosspostRepository struct{}
+
+// NewCrosspostRepository creates a new crosspost repository
+func NewCrosspostRepository() ICrosspostRepository {
+	return &CrosspostRepository{}
+}
+
+// GetPost returns a published post with its author
+func (r *CrosspostRepository) GetPost(postID uint) (*models.Post, error) {
+	var post models.Post
+	if err := db.DB.Preload("User").Scopes(PublishedPosts).First(&post, postID).Error; err != nil {
+		return nil, fmt.Errorf("post not found")
+	}
+	return &post, nil
+}
+
+// HasCrossposted checks whether a user already shared a post in a sub
+func (r *CrosspostRepository) HasCrossposted(userID, parentID, subID uint) (bool, error) {
+	var count int64
+	if err := db.DB.Model(&models.Post{}).Where("user_id = ? AND crosspost_parent_id = ? AND sub_id = ?", userID, parentID, subID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check crossposts")
+	}
+	return count > 0, nil
+}
+
+// CreateCrosspost saves a crosspost
+func (r *CrosspostRepository) CreateCrosspost(post *models.Post) error {
+	if err := db.DB.Create(post).Error; err != nil {
+		return fmt.Errorf("failed to create crosspost")
+	}
+	return nil
+}
+
+// setCrossposts fills in the shared post of each crosspost and how often each post has been crossposted
+func setCrossposts(posts []models.PostResponse) {
+	postIDs := []uint{}
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+
+	var counts []struct {
+		CrosspostParentID uint
+		Count             int64
+	}
+	db.DB.Model(&models.Post{}).Select("crosspost_parent_id, COUNT(*) AS count").
+		Where("crosspost_parent_id IN ?", postIDs).Scopes(PublishedPosts).Group("crosspost_parent_id").Scan(&counts)
+	crossposted := map[uint]int64{}
+	for _, count := range counts {
+		crossposted[count.CrosspostParentID] = count.Count
+	}
+
+	var parents []struct {
+		ID                uint
+		CrosspostParentID uint
+	}
+	db.DB.Unscoped().Model(&models.Post{}).Select("id, crosspost_parent_id").
+		Where("id IN ? AND crosspost_parent_id IS NOT NULL", postIDs).Scan(&parents)
+	parentIDs := map[uint]uint{}
+	for _, parent := range parents {
+		parentIDs[parent.ID] = parent.CrosspostParentID
+	}
+
+	crossposts := getCrossposts(parentIDs)
+	for i := range posts {
+		posts[i].CrosspostCount = crossposted[posts[i].ID]
+		if parentID, ok := parentIDs[posts[i].ID]; ok && !posts[i].Deleted {
+			posts[i].Crosspost = crossposts[parentID]
+		}
+	}
+}
+
+// getCrossposts builds the attributions of a set of crossposts, by the ID of the post each one shares. Deleted
+// originals, and originals in subs that have been deleted or made private since, only keep their ID.
+func getCrossposts(parentIDs map[uint]uint) map[uint]*models.CrosspostResponse {
+	responses := map[uint]*models.CrosspostResponse{}
+	if len(parentIDs) == 0 {
+		return responses
+	}
+
+	ids := []uint{}
+	for _, parentID := range parentIDs {
+		ids = append(ids, parentID)
+	}
+	var parents []models.Post
+	db.DB.Unscoped().Preload("User").Where("id IN ?", ids).Find(&parents)
+
+	subIDs := []uint{}
+	for _, parent := range parents {
+		subIDs = append(subIDs, parent.SubID)
+	}
+	subs := map[uint]models.Sub{}
+	if len(subIDs) > 0 {
+		var found []models.Sub
+		db.DB.Unscoped().Where("id IN ?", subIDs).Find(&found)
+		for _, sub := range found {
+			subs[sub.ID] = sub
+		}
+	}
+
+	images := GetImageResponses(postMediaIDs(parents))
+	links := getLinkPreviews(postURLs(parents))
+	for _, parentID := range ids {
+		responses[parentID] = &models.CrosspostResponse{PostID: parentID, Deleted: true}
+	}
+	for _, parent := range parents {
+		sub, ok := subs[parent.SubID]
+		if parent.DeletedAt.Valid || !ok || sub.DeletedAt.Valid {
+			continue
+		}
+		if sub.Private {
+			responses[parent.ID] = &models.CrosspostResponse{PostID: parent.ID, Unavailable: true}
+			continue
+		}
+
+		responses[parent.ID] = &models.CrosspostResponse{
+			PostID:      parent.ID,
+			SubID:       sub.ID,
+			SubName:     sub.Name,
+			Username:    authorName(parent.User),
+			Title:       parent.Title,
+			Content:     parent.Content,
+			ContentHTML: renderedContent(parent.Content, parent.ContentHTML),
+			Image:       imageForResponse(parent.MediaID, parent.ImageURL, images),
+			Link:        linkForResponse(parent, links),
+			CreatedAt:   parent.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+	return responses
+}
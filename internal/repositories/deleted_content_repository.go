@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+)
+
+// maxDeletedSummaryLength caps how much of a deleted comment is shown in the restore list
+const maxDeletedSummaryLength = 100
+
+// IDeletedContentRepository defines methods for listing and restoring soft-deleted content
+type IDeletedContentRepository interface {
+	GetDeleted(contentType string, since time.Time) ([]models.DeletedContentResponse, error)
+	Restore(contentType string, id uint, since time.Time) error
+}
+
+// DeletedContentRepository implements IDeletedContentRepository
+type DeletedContentRepository struct{}
+
+// NewDeletedContentRepository creates a new deleted content repository
+func NewDeletedContentRepository() IDeletedContentRepository {
+	return &DeletedContentRepository{}
+}
+
+// GetDeleted lists content of one type that was deleted after the given time, most recent first
+func (r *DeletedContentRepository) GetDeleted(contentType string, since time.Time) ([]models.DeletedContentResponse, error) {
+	model, summaryColumn, err := deletedContentModel(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ID        uint
+		Summary   string
+		DeletedAt time.Time
+	}
+	if err := db.DB.Unscoped().Model(model).Select("id, "+summaryColumn+" AS summary, deleted_at").
+		Where("deleted_at IS NOT NULL AND deleted_at > ?", since).Order("deleted_at DESC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch deleted content")
+	}
+
+	retention := db.DeletedRetention()
+	responses := []models.DeletedContentResponse{}
+	for _, row := range rows {
+		summary := row.Summary
+		if len(summary) > maxDeletedSummaryLength {
+			summary = summary[:maxDeletedSummaryLength] + "..."
+		}
+		responses = append(responses, models.DeletedContentResponse{
+			Type:            contentType,
+			ID:              row.ID,
+			Summary:         summary,
+			DeletedAt:       row.DeletedAt.Format("2006-01-02 15:04:05"),
+			RestorableUntil: row.DeletedAt.Add(retention).Format("2006-01-02 15:04:05"),
+		})
+	}
+	return responses, nil
+}
+
+// Restore undeletes an item, as long as it was deleted after the given time
+func (r *DeletedContentRepository) Restore(contentType string, id uint, since time.Time) error {
+	model, _, err := deletedContentModel(contentType)
+	if err != nil {
+		return err
+	}
+
+	result := db.DB.Unscoped().Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL AND deleted_at > ?", id, since).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore content")
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted content not found")
+	}
+	return nil
+}
+
+// deletedContentModel returns the model for a content type and the column used to summarize it
+func deletedContentModel(contentType string) (interface{}, string, error) {
+	switch contentType {
+	case models.ContentTypePost:
+		return &models.Post{}, "title", nil
+	case models.ContentTypeComment:
+		return &models.Comment{}, "content", nil
+	case models.ContentTypeSub:
+		return &models.Sub{}, "name", nil
+	case models.ContentTypeUser:
+		return &models.User{}, "username", nil
+	}
+	return nil, "", fmt.Errorf("content type must be post, comment, sub or user")
+}
+
+// authorName returns the username to show for a post or comment author, or a tombstone once the account is deleted
+func authorName(user models.User) string {
+	if user.ID == 0 || user.DeletedAt.Valid {
+		return models.DeletedPlaceholder
+	}
+	return user.Username
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAuthorName(t *testing.T) {
+	assert.Equal(t, "alice", authorName(models.User{ID: 1, Username: "alice"}))
+	assert.Equal(t, models.DeletedPlaceholder, authorName(models.User{}))
+	assert.Equal(t, models.DeletedPlaceholder, authorName(models.User{ID: 1, Username: "alice", DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}))
+}
+
+func TestDeletedContentRepository(t *testing.T) {
+	if !dbAvailable {
+		t.Skip("Database not available, skipping repository integration tests")
+		return
+	}
+
+	user := models.User{Username: "softdeleteuser", Password: "password"}
+	database.DB.Create(&user)
+	sub := models.Sub{Name: "softdeletesub", OwnerID: user.ID}
+	database.DB.Create(&sub)
+	post := models.Post{Title: "Thread", Content: "Content", UserID: user.ID, SubID: sub.ID}
+	database.DB.Create(&post)
+
+	parent := models.Comment{Content: "Parent", UserID: user.ID, PostID: post.ID}
+	database.DB.Create(&parent)
+	reply := models.Comment{Content: "Reply", UserID: user.ID, PostID: post.ID, ParentID: &parent.ID}
+	database.DB.Create(&reply)
+
+	commentRepo := NewCommentRepository()
+	repo := NewDeletedContentRepository()
+
+	t.Run("deleted comments render as tombstones", func(t *testing.T) {
+		assert.NoError(t, commentRepo.DeleteComment(parent.ID))
+
+		comments, err := commentRepo.GetCommentsByPostID(fmt.Sprintf("%d", post.ID), "")
+		assert.NoError(t, err)
+		assert.Len(t, *comments, 2)
+		for _, comment := range *comments {
+			if comment.ID == parent.ID {
+				assert.True(t, comment.Deleted)
+				assert.Equal(t, models.DeletedPlaceholder, comment.Content)
+				assert.Equal(t, models.DeletedPlaceholder, comment.Username)
+			} else {
+				assert.Equal(t, parent.ID, *comment.ParentID)
+			}
+		}
+	})
+
+	t.Run("deleted posts render as tombstones", func(t *testing.T) {
+		assert.NoError(t, NewPostModerationRepository().DeletePost(&post))
+
+		response, err := GetPostByID(fmt.Sprintf("%d", post.ID))
+		assert.NoError(t, err)
+		assert.True(t, response.Deleted)
+		assert.Equal(t, models.DeletedPlaceholder, response.Title)
+	})
+
+	t.Run("lists restorable content", func(t *testing.T) {
+		items, err := repo.GetDeleted(models.ContentTypeComment, time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, "Parent", items[0].Summary)
+	})
+
+	t.Run("content outside the restore window cannot be restored", func(t *testing.T) {
+		err := repo.Restore(models.ContentTypeComment, parent.ID, time.Now().Add(time.Hour))
+		assert.EqualError(t, err, "deleted content not found")
+	})
+
+	t.Run("restores content", func(t *testing.T) {
+		assert.NoError(t, repo.Restore(models.ContentTypeComment, parent.ID, time.Now().Add(-time.Hour)))
+		assert.NoError(t, repo.Restore(models.ContentTypePost, post.ID, time.Now().Add(-time.Hour)))
+
+		restored, err := commentRepo.GetCommentByID(parent.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Parent", restored.Content)
+	})
+
+	t.Run("unknown content type", func(t *testing.T) {
+		_, err := repo.GetDeleted("vote", time.Now())
+		assert.EqualError(t, err, "content type must be post, comment, sub or user")
+	})
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/markdown"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+)
+
+// IDraftRepository defines methods for draft and scheduled posts and publishing them
+type IDraftRepository interface {
+	GetDrafts(userID uint) ([]models.Post, error)
+	GetDraft(postID uint) (*models.Post, error)
+	UpdateDraft(post *models.Post, title, content string) error
+	SetSchedule(post *models.Post, scheduledAt *time.Time) error
+	DeleteDraft(post *models.Post) error
+	PublishPost(post *models.Post, now time.Time) (bool, error)
+	ReturnToDraft(post *models.Post, reason string) (bool, error)
+	GetDueScheduledPosts(now time.Time) ([]models.Post, error)
+}
+
+// DraftRepository implements IDraftRepository
+type DraftRepository struct{}
+
+// NewDraftRepository creates a new draft repository
+func NewDraftRepository() IDraftRepository {
+	return &DraftRepository{}
+}
+
+// GetDrafts returns a user's unpublished posts: scheduled posts by publishing time, then drafts, newest first
+func (r *DraftRepository) GetDrafts(userID uint) ([]models.Post, error) {
+	var posts []models.Post
+	if err := db.DB.Preload("User").Where("user_id = ? AND status IN ?", userID, []string{models.PostStatusDraft, models.PostStatusScheduled}).
+		Order("scheduled_at ASC NULLS LAST, created_at DESC").Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch drafts")
+	}
+	return posts, nil
+}
+
+// GetDraft returns a draft or scheduled post with its author
+func (r *DraftRepository) GetDraft(postID uint) (*models.Post, error) {
+	var post models.Post
+	if err := db.DB.Preload("User").Where("status IN ?", []string{models.PostStatusDraft, models.PostStatusScheduled}).
+		First(&post, postID).Error; err != nil {
+		return nil, fmt.Errorf("draft not found")
+	}
+	return &post, nil
+}
+
+// UpdateDraft replaces the title and content of an unpublished post; drafts don't keep revisions
+func (r *DraftRepository) UpdateDraft(post *models.Post, title, content string) error {
+	contentHTML := markdown.Render(content)
+	result := db.DB.Model(&models.Post{}).Where("id = ? AND status IN ?", post.ID, []string{models.PostStatusDraft, models.PostStatusScheduled}).
+		Updates(map[string]interface{}{"title": title, "content": content, "content_html": contentHTML})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update draft")
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("draft not found")
+	}
+	post.Title, post.Content, post.ContentHTML = title, content, contentHTML
+	return nil
+}
+
+// SetSchedule schedules an unpublished post, or makes it a draft again when scheduledAt is nil.
+// Posts the scheduler has published in the meantime are left alone.
+func (r *DraftRepository) SetSchedule(post *models.Post, scheduledAt *time.Time) error {
+	status := models.PostStatusDraft
+	if scheduledAt != nil {
+		status = models.PostStatusScheduled
+	}
+	result := db.DB.Model(&models.Post{}).Where("id = ? AND status IN ?", post.ID, []string{models.PostStatusDraft, models.PostStatusScheduled}).
+		Updates(map[string]interface{}{"status": status, "scheduled_at": scheduledAt, "publish_error": ""})
+	if result.Error != nil {
+		return fmt.Errorf("failed to schedule post")
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("draft not found")
+	}
+	post.Status, post.ScheduledAt, post.PublishError = status, scheduledAt, ""
+	return nil
+}
+
+// DeleteDraft discards an unpublished post
+func (r *DraftRepository) DeleteDraft(post *models.Post) error {
+	if err := db.DB.Delete(post).Error; err != nil {
+		return fmt.Errorf("failed to delete draft")
+	}
+	return nil
+}
+
+// PublishPost publishes a draft or scheduled post, dating it now. The update only applies while the post still has
+// the status and time it was loaded with, so a post is published once even when the scheduler and its author race
+// or a rescheduled post is picked up from a stale read; it reports whether this call published the post.
+func (r *DraftRepository) PublishPost(post *models.Post, now time.Time) (bool, error) {
+	query := db.DB.Model(&models.Post{}).Where("id = ? AND status = ?", post.ID, post.Status)
+	if post.ScheduledAt != nil {
+		query = query.Where("scheduled_at = ?", *post.ScheduledAt)
+	} else {
+		query = query.Where("scheduled_at IS NULL")
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"status": models.PostStatusPublished, "scheduled_at": nil, "publish_error": "", "created_at": now,
+	})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to publish post")
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	post.Status, post.ScheduledAt, post.PublishError, post.CreatedAt = models.PostStatusPublished, nil, "", now
+	return true, nil
+}
+
+// ReturnToDraft turns a scheduled post that can't be published back into a draft, recording why
+func (r *DraftRepository) ReturnToDraft(post *models.Post, reason string) (bool, error) {
+	result := db.DB.Model(&models.Post{}).
+		Where("id = ? AND status = ? AND scheduled_at = ?", post.ID, models.PostStatusScheduled, post.ScheduledAt).
+		Updates(map[string]interface{}{"status": models.PostStatusDraft, "scheduled_at": nil, "publish_error": reason})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update post")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetDueScheduledPosts returns scheduled posts whose publishing time has come, with their authors
+func (r *DraftRepository) GetDueScheduledPosts(now time.Time) ([]models.Post, error) {
+	var posts []models.Post
+	if err := db.DB.Preload("User").Where("status = ? AND scheduled_at <= ?", models.PostStatusScheduled, now).
+		Order("scheduled_at ASC").Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch scheduled posts")
+	}
+	return posts, nil
+}
+
+// FormatDrafts converts unpublished posts to the response format their author sees
+func FormatDrafts(posts []models.Post) []models.DraftResponse {
+	drafts := []models.DraftResponse{}
+	for i, post := range formatPosts(posts) {
+		draft := models.DraftResponse{PostResponse: post, Status: posts[i].Status, PublishError: posts[i].PublishError}
+		draft.NSFW, draft.Spoiler = posts[i].NSFW, posts[i].Spoiler
+		if posts[i].ScheduledAt != nil {
+			draft.ScheduledAt = posts[i].ScheduledAt.Format("2006-01-02 15:04:05")
+		}
+		drafts = append(drafts, draft)
+	}
+	return drafts
+}
@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IFlairRepository defines methods for sub flair templates and flair assignment
+type IFlairRepository interface {
+	GetFlairs(subID uint, flairType string) ([]models.SubFlair, error)
+	GetFlairByID(flairID uint) (*models.SubFlair, error)
+	CountFlairs(subID uint) (int64, error)
+	CreateFlair(flair *models.SubFlair) error
+	UpdateFlair(flair *models.SubFlair) error
+	DeleteFlair(flair *models.SubFlair) error
+	GetPost(postID uint) (*models.Post, error)
+	SetPostFlair(post *models.Post, flairID *uint) error
+	GetMembership(subID, userID uint) (*models.SubMembership, error)
+	SetMemberFlair(membership *models.SubMembership, flairID *uint) error
+}
+
+// FlairRepository implements IFlairRepository
+type FlairRepository struct{}
+
+// NewFlairRepository creates a new flair repository
+func NewFlairRepository() IFlairRepository {
+	return &FlairRepository{}
+}
+
+func (r *FlairRepository) GetFlairs(subID uint, flairType string) ([]models.SubFlair, error) {
+	query := db.DB.Where("sub_id = ?", subID)
+	if flairType != "" {
+		query = query.Where("type = ?", flairType)
+	}
+
+	var flairs []models.SubFlair
+	if err := query.Order("created_at ASC").Find(&flairs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch flairs")
+	}
+	return flairs, nil
+}
+
+func (r *FlairRepository) GetFlairByID(flairID uint) (*models.SubFlair, error) {
+	var flair models.SubFlair
+	if err := db.DB.First(&flair, flairID).Error; err != nil {
+		return nil, fmt.Errorf("flair not found")
+	}
+	return &flair, nil
+}
+
+func (r *FlairRepository) CountFlairs(subID uint) (int64, error) {
+	var count int64
+	if err := db.DB.Model(&models.SubFlair{}).Where("sub_id = ?", subID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch flairs")
+	}
+	return count, nil
+}
+
+func (r *FlairRepository) CreateFlair(flair *models.SubFlair) error {
+	flair.CreatedAt = time.Now()
+	if err := db.DB.Create(flair).Error; err != nil {
+		return fmt.Errorf("failed to create flair")
+	}
+	return nil
+}
+
+func (r *FlairRepository) UpdateFlair(flair *models.SubFlair) error {
+	if err := db.DB.Save(flair).Error; err != nil {
+		return fmt.Errorf("failed to update flair")
+	}
+	return nil
+}
+
+// DeleteFlair removes a flair template and clears it from every post and membership using it
+func (r *FlairRepository) DeleteFlair(flair *models.SubFlair) error {
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Post{}).Where("flair_id = ?", flair.ID).Update("flair_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SubMembership{}).Where("flair_id = ?", flair.ID).Update("flair_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(flair).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete flair")
+	}
+	return nil
+}
+
+func (r *FlairRepository) GetPost(postID uint) (*models.Post, error) {
+	var post models.Post
+	if err := db.DB.Scopes(PublishedPosts).First(&post, postID).Error; err != nil {
+		return nil, fmt.Errorf("post not found")
+	}
+	return &post, nil
+}
+
+func (r *FlairRepository) SetPostFlair(post *models.Post, flairID *uint) error {
+	if err := db.DB.Model(post).Update("flair_id", flairID).Error; err != nil {
+		return fmt.Errorf("failed to assign flair")
+	}
+	post.FlairID = flairID
+	return nil
+}
+
+func (r *FlairRepository) GetMembership(subID, userID uint) (*models.SubMembership, error) {
+	var membership models.SubMembership
+	if err := db.DB.Where("sub_id = ? AND user_id = ?", subID, userID).First(&membership).Error; err != nil {
+		return nil, fmt.Errorf("user is not a member of this sub")
+	}
+	return &membership, nil
+}
+
+func (r *FlairRepository) SetMemberFlair(membership *models.SubMembership, flairID *uint) error {
+	if err := db.DB.Model(membership).Update("flair_id", flairID).Error; err != nil {
+		return fmt.Errorf("failed to assign flair")
+	}
+	membership.FlairID = flairID
+	return nil
+}
+
+// GetFlairResponses loads the flairs with the given IDs, keyed by ID, for embedding in responses
+func GetFlairResponses(flairIDs []uint) map[uint]*models.FlairResponse {
+	responses := map[uint]*models.FlairResponse{}
+	if len(flairIDs) == 0 {
+		return responses
+	}
+
+	var flairs []models.SubFlair
+	db.DB.Where("id IN ?", flairIDs).Find(&flairs)
+	for _, flair := range flairs {
+		responses[flair.ID] = FormatFlair(flair)
+	}
+	return responses
+}
+
+// FormatFlair converts a flair template to its response format
+func FormatFlair(flair models.SubFlair) *models.FlairResponse {
+	return &models.FlairResponse{
+		ID:              flair.ID,
+		Type:            flair.Type,
+		Text:            flair.Text,
+		TextColor:       flair.TextColor,
+		BackgroundColor: flair.BackgroundColor,
+		ModOnly:         flair.ModOnly,
+	}
+}
+
+// flairForResponse looks up an optional flair ID in a set of loaded flairs
+func flairForResponse(flairID *uint, flairs map[uint]*models.FlairResponse) *models.FlairResponse {
+	if flairID == nil {
+		return nil
+	}
+	return flairs[*flairID]
+}
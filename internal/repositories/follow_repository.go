@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IFollowRepository defines methods for the user follow graph
+type IFollowRepository interface {
+	GetFollow(followerID, followingID uint) (*models.UserFollow, error)
+	GetFollowByID(followID uint) (*models.UserFollow, error)
+	CreateFollow(follow *models.UserFollow) error
+	AcceptFollow(follow *models.UserFollow) error
+	DeleteFollow(follow *models.UserFollow) error
+	CountFollows(userID uint) (followers int64, following int64, err error)
+	GetFollowedIDs(followerID uint, userIDs []uint) ([]uint, error)
+	GetFollowers(userID uint, offset, limit int) ([]models.FollowResponse, int64, error)
+	GetFollowing(userID uint, offset, limit int) ([]models.FollowResponse, int64, error)
+	GetPendingRequests(userID uint) ([]models.FollowRequestResponse, error)
+	GetFollowedPosts(userID uint, offset, limit int) ([]models.PostResponse, error)
+}
+
+// FollowRepository implements IFollowRepository
+type FollowRepository struct{}
+
+// NewFollowRepository creates a new follow repository
+func NewFollowRepository() IFollowRepository {
+	return &FollowRepository{}
+}
+
+func (r *FollowRepository) GetFollow(followerID, followingID uint) (*models.UserFollow, error) {
+	var follow models.UserFollow
+	if err := db.DB.Where("follower_id = ? AND following_id = ?", followerID, followingID).First(&follow).Error; err != nil {
+		return nil, fmt.Errorf("follow not found")
+	}
+	return &follow, nil
+}
+
+func (r *FollowRepository) GetFollowByID(followID uint) (*models.UserFollow, error) {
+	var follow models.UserFollow
+	if err := db.DB.First(&follow, followID).Error; err != nil {
+		return nil, fmt.Errorf("follow request not found")
+	}
+	return &follow, nil
+}
+
+func (r *FollowRepository) CreateFollow(follow *models.UserFollow) error {
+	follow.CreatedAt = time.Now()
+	if follow.Status == models.FollowStatusAccepted {
+		follow.AcceptedAt = &follow.CreatedAt
+	}
+	if err := db.DB.Create(follow).Error; err != nil {
+		return fmt.Errorf("failed to follow user")
+	}
+	return nil
+}
+
+func (r *FollowRepository) AcceptFollow(follow *models.UserFollow) error {
+	now := time.Now()
+	if err := db.DB.Model(follow).Updates(map[string]interface{}{"status": models.FollowStatusAccepted, "accepted_at": now}).Error; err != nil {
+		return fmt.Errorf("failed to update follow request")
+	}
+	follow.Status = models.FollowStatusAccepted
+	follow.AcceptedAt = &now
+	return nil
+}
+
+func (r *FollowRepository) DeleteFollow(follow *models.UserFollow) error {
+	if err := db.DB.Delete(follow).Error; err != nil {
+		return fmt.Errorf("failed to unfollow user")
+	}
+	return nil
+}
+
+// CountFollows returns how many accepted followers a user has and how many users they follow
+func (r *FollowRepository) CountFollows(userID uint) (int64, int64, error) {
+	var followers, following int64
+	if err := acceptedFollows("follower_id").Where("user_follows.following_id = ?", userID).Count(&followers).Error; err != nil {
+		return 0, 0, fmt.Errorf("failed to count follows")
+	}
+	if err := acceptedFollows("following_id").Where("user_follows.follower_id = ?", userID).Count(&following).Error; err != nil {
+		return 0, 0, fmt.Errorf("failed to count follows")
+	}
+	return followers, following, nil
+}
+
+// GetFollowedIDs returns which of the given users someone follows, counting accepted follows only
+func (r *FollowRepository) GetFollowedIDs(followerID uint, userIDs []uint) ([]uint, error) {
+	followedIDs := []uint{}
+	if len(userIDs) == 0 {
+		return followedIDs, nil
+	}
+	if err := db.DB.Model(&models.UserFollow{}).
+		Where("follower_id = ? AND following_id IN ? AND status = ?", followerID, userIDs, models.FollowStatusAccepted).
+		Pluck("following_id", &followedIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch follows")
+	}
+	return followedIDs, nil
+}
+
+// GetFollowers returns one page of a user's accepted followers, most recent first
+func (r *FollowRepository) GetFollowers(userID uint, offset, limit int) ([]models.FollowResponse, int64, error) {
+	return followPage(acceptedFollows("follower_id").Where("user_follows.following_id = ?", userID), offset, limit)
+}
+
+// GetFollowing returns one page of the users someone follows, most recent first
+func (r *FollowRepository) GetFollowing(userID uint, offset, limit int) ([]models.FollowResponse, int64, error) {
+	return followPage(acceptedFollows("following_id").Where("user_follows.follower_id = ?", userID), offset, limit)
+}
+
+func (r *FollowRepository) GetPendingRequests(userID uint) ([]models.FollowRequestResponse, error) {
+	var rows []struct {
+		ID        uint
+		Username  string
+		CreatedAt time.Time
+	}
+	if err := db.DB.Table("user_follows").
+		Select("user_follows.id, users.username, user_follows.created_at").
+		Joins("JOIN users ON users.id = user_follows.follower_id AND users.deleted_at IS NULL").
+		Where("user_follows.following_id = ? AND user_follows.status = ?", userID, models.FollowStatusPending).
+		Order("user_follows.created_at ASC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch follow requests")
+	}
+
+	requests := []models.FollowRequestResponse{}
+	for _, row := range rows {
+		requests = append(requests, models.FollowRequestResponse{
+			ID:        row.ID,
+			Username:  row.Username,
+			CreatedAt: row.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return requests, nil
+}
+
+// GetFollowedPosts returns one page of posts by the users someone follows, limited to subs they can see
+func (r *FollowRepository) GetFollowedPosts(userID uint, offset, limit int) ([]models.PostResponse, error) {
+	followed := db.DB.Model(&models.UserFollow{}).Select("following_id").
+		Where("follower_id = ? AND status = ?", userID, models.FollowStatusAccepted)
+	visibleSubs := db.DB.Model(&models.Sub{}).Select("id").
+		Where("private = ? OR owner_id = ? OR id IN (?)", false, userID,
+			db.DB.Model(&models.SubMembership{}).Select("sub_id").Where("user_id = ?", userID))
+
+	settings := loadContentSettings([]uint{userID})
+
+	var posts []models.Post
+	if err := db.DB.Preload("User").Where("user_id IN (?) AND sub_id IN (?)", followed, visibleSubs).
+		Scopes(PublishedPosts, hideBlockedAuthors([]uint{userID}), hideHiddenPosts([]uint{userID}), hideSensitivePosts(settings)).Order("created_at DESC").Offset(offset).Limit(limit).Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch posts")
+	}
+
+	responses := formatPosts(posts)
+	markSensitivePosts(settings, responses)
+	markSavedPosts([]uint{userID}, responses)
+	markPollVotes([]uint{userID}, responses)
+	return responses, nil
+}
+
+// acceptedFollows starts a query over accepted follows joined to the live user in the given column
+func acceptedFollows(userColumn string) *gorm.DB {
+	return db.DB.Table("user_follows").
+		Joins("JOIN users ON users.id = user_follows."+userColumn+" AND users.deleted_at IS NULL").
+		Where("user_follows.status = ?", models.FollowStatusAccepted)
+}
+
+// followPage counts and loads one page of a follow list query
+func followPage(query *gorm.DB, offset, limit int) ([]models.FollowResponse, int64, error) {
+	// A new session lets the same query be used for both the count and the page
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch follows")
+	}
+
+	var rows []struct {
+		Username    string
+		DisplayName string
+		AvatarURL   *string
+		AcceptedAt  *time.Time
+		CreatedAt   time.Time
+	}
+	if err := query.Select("users.username, users.display_name, users.avatar_url, user_follows.accepted_at, user_follows.created_at").
+		Order("user_follows.accepted_at DESC").Offset(offset).Limit(limit).Scan(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch follows")
+	}
+
+	users := []models.FollowResponse{}
+	for _, row := range rows {
+		followedAt := row.CreatedAt
+		if row.AcceptedAt != nil {
+			followedAt = *row.AcceptedAt
+		}
+		users = append(users, models.FollowResponse{
+			Username:    row.Username,
+			DisplayName: row.DisplayName,
+			AvatarURL:   row.AvatarURL,
+			FollowedAt:  followedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return users, total, nil
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// defaultInviteExpiry is used when SUB_INVITE_EXPIRY_HOURS is not set
+const defaultInviteExpiry = 7 * 24 * time.Hour
+
+// InviteExpiry returns how long new invitations stay valid, configurable with SUB_INVITE_EXPIRY_HOURS
+func InviteExpiry() time.Duration {
+	if hours, err := strconv.Atoi(os.Getenv("SUB_INVITE_EXPIRY_HOURS")); err == nil && hours > 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return defaultInviteExpiry
+}
+
+// IInviteRepository defines methods for the invitation lifecycle and invite links
+type IInviteRepository interface {
+	GetInvitationByID(inviteID uint) (*models.SubInvitation, error)
+	UpdateInvitationStatus(invitation *models.SubInvitation, status string) error
+	GetPendingInvitesForUser(userID uint) ([]models.UserInviteResponse, error)
+	CreateInviteLink(link *models.SubInviteLink) error
+	GetInviteLinkByID(linkID uint) (*models.SubInviteLink, error)
+	GetActiveInviteLinks(subID uint) ([]models.SubInviteLink, error)
+	RevokeInviteLink(link *models.SubInviteLink) error
+	RedeemInviteLink(token string, userID uint) (*models.SubMembership, error)
+}
+
+// InviteRepository implements IInviteRepository
+type InviteRepository struct{}
+
+// NewInviteRepository creates a new invite repository
+func NewInviteRepository() IInviteRepository {
+	return &InviteRepository{}
+}
+
+func (r *InviteRepository) GetInvitationByID(inviteID uint) (*models.SubInvitation, error) {
+	var invitation models.SubInvitation
+	if err := db.DB.First(&invitation, inviteID).Error; err != nil {
+		return nil, fmt.Errorf("invitation not found")
+	}
+	return &invitation, nil
+}
+
+func (r *InviteRepository) UpdateInvitationStatus(invitation *models.SubInvitation, status string) error {
+	now := time.Now()
+	invitation.Status = status
+	invitation.RespondedAt = &now
+
+	if err := db.DB.Save(invitation).Error; err != nil {
+		return fmt.Errorf("failed to update invitation")
+	}
+	return nil
+}
+
+func (r *InviteRepository) GetPendingInvitesForUser(userID uint) ([]models.UserInviteResponse, error) {
+	var rows []struct {
+		ID              uint
+		SubID           uint
+		SubName         string
+		InviterUsername string
+		CreatedAt       time.Time
+		ExpiresAt       *time.Time
+	}
+
+	err := db.DB.Table("sub_invitations").
+		Select("sub_invitations.id, sub_invitations.sub_id, subs.name AS sub_name, users.username AS inviter_username, sub_invitations.created_at, sub_invitations.expires_at").
+		Joins("JOIN subs ON subs.id = sub_invitations.sub_id").
+		Joins("JOIN users ON users.id = sub_invitations.inviter_id").
+		Where("sub_invitations.invitee_id = ? AND sub_invitations.status = ?", userID, models.InviteStatusPending).
+		Where("sub_invitations.expires_at IS NULL OR sub_invitations.expires_at > ?", time.Now()).
+		Order("sub_invitations.created_at DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invitations")
+	}
+
+	invites := []models.UserInviteResponse{}
+	for _, row := range rows {
+		invite := models.UserInviteResponse{
+			ID:              row.ID,
+			SubID:           row.SubID,
+			SubName:         row.SubName,
+			InviterUsername: row.InviterUsername,
+			CreatedAt:       row.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+		if row.ExpiresAt != nil {
+			invite.ExpiresAt = row.ExpiresAt.Format("2006-01-02 15:04:05")
+		}
+		invites = append(invites, invite)
+	}
+
+	return invites, nil
+}
+
+func (r *InviteRepository) CreateInviteLink(link *models.SubInviteLink) error {
+	token, err := GenerateToken()
+	if err != nil {
+		return fmt.Errorf("could not generate invite token")
+	}
+	link.Token = token
+	link.CreatedAt = time.Now()
+
+	if err := db.DB.Create(link).Error; err != nil {
+		return fmt.Errorf("failed to create invite link")
+	}
+	return nil
+}
+
+func (r *InviteRepository) GetInviteLinkByID(linkID uint) (*models.SubInviteLink, error) {
+	var link models.SubInviteLink
+	if err := db.DB.First(&link, linkID).Error; err != nil {
+		return nil, fmt.Errorf("invite link not found")
+	}
+	return &link, nil
+}
+
+func (r *InviteRepository) GetActiveInviteLinks(subID uint) ([]models.SubInviteLink, error) {
+	var links []models.SubInviteLink
+	err := db.DB.Where("sub_id = ? AND used_at IS NULL AND revoked_at IS NULL AND expires_at > ?", subID, time.Now()).
+		Order("created_at DESC").Find(&links).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invite links")
+	}
+	return links, nil
+}
+
+func (r *InviteRepository) RevokeInviteLink(link *models.SubInviteLink) error {
+	now := time.Now()
+	link.RevokedAt = &now
+	if err := db.DB.Save(link).Error; err != nil {
+		return fmt.Errorf("failed to revoke invite link")
+	}
+	return nil
+}
+
+// RedeemInviteLink consumes a link and creates the membership in a single transaction.
+// The conditional update guarantees a link can only be used once, even under concurrent redemption.
+func (r *InviteRepository) RedeemInviteLink(token string, userID uint) (*models.SubMembership, error) {
+	var membership models.SubMembership
+
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		var link models.SubInviteLink
+		if err := tx.Where("token = ?", token).First(&link).Error; err != nil {
+			return fmt.Errorf("invite link not found")
+		}
+
+		var memberCount int64
+		tx.Model(&models.SubMembership{}).Where("sub_id = ? AND user_id = ?", link.SubID, userID).Count(&memberCount)
+		if memberCount > 0 {
+			return fmt.Errorf("you are already a member of this sub")
+		}
+
+		now := time.Now()
+		result := tx.Model(&models.SubInviteLink{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL AND expires_at > ?", link.ID, now).
+			Updates(map[string]interface{}{"used_by_id": userID, "used_at": now})
+		if result.Error != nil {
+			return fmt.Errorf("failed to redeem invite link")
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("invite link is no longer valid")
+		}
+
+		membership = models.SubMembership{
+			SubID:    link.SubID,
+			UserID:   userID,
+			JoinedAt: now,
+		}
+		if err := tx.Create(&membership).Error; err != nil {
+			return fmt.Errorf("failed to join sub")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &membership, nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInviteRepository_PendingInvites(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping repository integration tests")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_invitations")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "inviteowner", Password: "password"}
+	invitee := models.User{Username: "invitee", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&invitee)
+
+	sub := models.Sub{Name: "invitesub", OwnerID: owner.ID, Private: true}
+	database.DB.Create(&sub)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	live := models.SubInvitation{SubID: sub.ID, InviterID: owner.ID, InviteeID: invitee.ID, Status: models.InviteStatusPending, ExpiresAt: &future}
+	expired := models.SubInvitation{SubID: sub.ID, InviterID: owner.ID, InviteeID: invitee.ID, Status: models.InviteStatusPending, ExpiresAt: &past}
+	database.DB.Create(&live)
+	database.DB.Create(&expired)
+
+	repo := NewInviteRepository()
+
+	t.Run("expired invites are not listed", func(t *testing.T) {
+		invites, err := repo.GetPendingInvitesForUser(invitee.ID)
+
+		assert.NoError(t, err)
+		assert.Len(t, invites, 1)
+		assert.Equal(t, live.ID, invites[0].ID)
+		assert.Equal(t, "invitesub", invites[0].SubName)
+		assert.Equal(t, "inviteowner", invites[0].InviterUsername)
+	})
+
+	t.Run("declined invites are not listed", func(t *testing.T) {
+		err := repo.UpdateInvitationStatus(&live, models.InviteStatusRejected)
+		assert.NoError(t, err)
+
+		invites, err := repo.GetPendingInvitesForUser(invitee.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, invites)
+	})
+}
+
+func TestInviteRepository_RedeemInviteLink(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping repository integration tests")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_invite_links")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "linkowner", Password: "password"}
+	first := models.User{Username: "linkfirst", Password: "password"}
+	second := models.User{Username: "linksecond", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&first)
+	database.DB.Create(&second)
+
+	sub := models.Sub{Name: "linksub", OwnerID: owner.ID, Private: true}
+	database.DB.Create(&sub)
+
+	repo := NewInviteRepository()
+
+	link := models.SubInviteLink{SubID: sub.ID, CreatorID: owner.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, repo.CreateInviteLink(&link))
+	assert.NotEmpty(t, link.Token)
+
+	t.Run("link joins the sub", func(t *testing.T) {
+		membership, err := repo.RedeemInviteLink(link.Token, first.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sub.ID, membership.SubID)
+	})
+
+	t.Run("link can only be used once", func(t *testing.T) {
+		_, err := repo.RedeemInviteLink(link.Token, second.ID)
+		assert.EqualError(t, err, "invite link is no longer valid")
+	})
+
+	t.Run("used links are not active", func(t *testing.T) {
+		links, err := repo.GetActiveInviteLinks(sub.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, links)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		_, err := repo.RedeemInviteLink("missing", second.ID)
+		assert.EqualError(t, err, "invite link not found")
+	})
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IJoinRequestRepository defines methods for requests to join private subs
+type IJoinRequestRepository interface {
+	CreateJoinRequest(request *models.SubJoinRequest) error
+	HasPendingJoinRequest(subID, userID uint) (bool, error)
+	GetJoinRequestByID(requestID uint) (*models.SubJoinRequest, error)
+	GetPendingJoinRequests(subID uint) ([]models.SubJoinRequest, error)
+	ApproveJoinRequest(request *models.SubJoinRequest, reviewerID uint) error
+	DenyJoinRequest(request *models.SubJoinRequest, reviewerID uint) error
+}
+
+// JoinRequestRepository implements IJoinRequestRepository
+type JoinRequestRepository struct{}
+
+// NewJoinRequestRepository creates a new join request repository
+func NewJoinRequestRepository() IJoinRequestRepository {
+	return &JoinRequestRepository{}
+}
+
+func (r *JoinRequestRepository) CreateJoinRequest(request *models.SubJoinRequest) error {
+	request.Status = models.JoinRequestStatusPending
+	request.CreatedAt = time.Now()
+	if err := db.DB.Create(request).Error; err != nil {
+		return fmt.Errorf("failed to create join request")
+	}
+	return nil
+}
+
+func (r *JoinRequestRepository) HasPendingJoinRequest(subID, userID uint) (bool, error) {
+	var count int64
+	err := db.DB.Model(&models.SubJoinRequest{}).
+		Where("sub_id = ? AND user_id = ? AND status = ?", subID, userID, models.JoinRequestStatusPending).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check join requests")
+	}
+	return count > 0, nil
+}
+
+func (r *JoinRequestRepository) GetJoinRequestByID(requestID uint) (*models.SubJoinRequest, error) {
+	var request models.SubJoinRequest
+	if err := db.DB.Preload("User").First(&request, requestID).Error; err != nil {
+		return nil, fmt.Errorf("join request not found")
+	}
+	return &request, nil
+}
+
+func (r *JoinRequestRepository) GetPendingJoinRequests(subID uint) ([]models.SubJoinRequest, error) {
+	var requests []models.SubJoinRequest
+	err := db.DB.Preload("User").
+		Where("sub_id = ? AND status = ?", subID, models.JoinRequestStatusPending).
+		Order("created_at ASC").Find(&requests).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch join requests")
+	}
+	return requests, nil
+}
+
+// ApproveJoinRequest marks the request approved and adds the requester to the sub in one transaction
+func (r *JoinRequestRepository) ApproveJoinRequest(request *models.SubJoinRequest, reviewerID uint) error {
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := r.review(tx, request, reviewerID, models.JoinRequestStatusApproved); err != nil {
+			return err
+		}
+
+		var memberCount int64
+		tx.Model(&models.SubMembership{}).Where("sub_id = ? AND user_id = ?", request.SubID, request.UserID).Count(&memberCount)
+		if memberCount > 0 {
+			return nil
+		}
+
+		membership := models.SubMembership{
+			SubID:    request.SubID,
+			UserID:   request.UserID,
+			JoinedAt: time.Now(),
+		}
+		return tx.Create(&membership).Error
+	})
+	if err != nil {
+		if err.Error() == "join request is no longer pending" {
+			return err
+		}
+		return fmt.Errorf("failed to approve join request")
+	}
+	return nil
+}
+
+func (r *JoinRequestRepository) DenyJoinRequest(request *models.SubJoinRequest, reviewerID uint) error {
+	err := r.review(db.DB, request, reviewerID, models.JoinRequestStatusDenied)
+	if err != nil && err.Error() != "join request is no longer pending" {
+		return fmt.Errorf("failed to deny join request")
+	}
+	return err
+}
+
+// review moves a pending request to its final status; the status condition stops two moderators reviewing it at once
+func (r *JoinRequestRepository) review(tx *gorm.DB, request *models.SubJoinRequest, reviewerID uint, status string) error {
+	now := time.Now()
+	result := tx.Model(&models.SubJoinRequest{}).
+		Where("id = ? AND status = ?", request.ID, models.JoinRequestStatusPending).
+		Updates(map[string]interface{}{"status": status, "reviewer_id": reviewerID, "reviewed_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("join request is no longer pending")
+	}
+
+	request.Status = status
+	request.ReviewerID = &reviewerID
+	request.ReviewedAt = &now
+	return nil
+}
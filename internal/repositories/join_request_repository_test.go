@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinRequestRepository(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping repository integration tests")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_join_requests")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "joinrepoowner", Password: "password"}
+	requester := models.User{Username: "joinreporequester", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&requester)
+
+	sub := models.Sub{Name: "joinreposub", OwnerID: owner.ID, Private: true, JoinMode: models.JoinModeRequest}
+	database.DB.Create(&sub)
+
+	repo := NewJoinRequestRepository()
+
+	request := models.SubJoinRequest{SubID: sub.ID, UserID: requester.ID, Message: "Let me in"}
+	assert.NoError(t, repo.CreateJoinRequest(&request))
+
+	t.Run("pending request is queued", func(t *testing.T) {
+		pending, err := repo.HasPendingJoinRequest(sub.ID, requester.ID)
+		assert.NoError(t, err)
+		assert.True(t, pending)
+
+		requests, err := repo.GetPendingJoinRequests(sub.ID)
+		assert.NoError(t, err)
+		assert.Len(t, requests, 1)
+		assert.Equal(t, "joinreporequester", requests[0].User.Username)
+	})
+
+	t.Run("approval adds membership", func(t *testing.T) {
+		err := repo.ApproveJoinRequest(&request, owner.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, models.JoinRequestStatusApproved, request.Status)
+
+		var count int64
+		database.DB.Model(&models.SubMembership{}).Where("sub_id = ? AND user_id = ?", sub.ID, requester.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("request can only be reviewed once", func(t *testing.T) {
+		err := repo.DenyJoinRequest(&request, owner.ID)
+		assert.EqualError(t, err, "join request is no longer pending")
+	})
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"fmt"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IKarmaRepository defines methods for keeping user karma in line with votes
+type IKarmaRepository interface {
+	Reconcile() (int64, error)
+}
+
+// KarmaRepository implements IKarmaRepository
+type KarmaRepository struct{}
+
+// NewKarmaRepository creates a new karma repository
+func NewKarmaRepository() IKarmaRepository {
+	return &KarmaRepository{}
+}
+
+// Reconcile recomputes every user's post and comment karma from the votes on their content, correcting karma
+// that drifted, for example when purged content took its votes with it. It returns how many users were corrected;
+// a vote cast while it runs is caught up with on the next run.
+func (r *KarmaRepository) Reconcile() (int64, error) {
+	result := db.DB.Exec(`
+		WITH karma AS (
+			SELECT users.id,
+				COALESCE((SELECT SUM(votes.vote) FROM votes JOIN posts ON posts.id = votes.post_id
+					WHERE votes.comment_id IS NULL AND posts.user_id = users.id), 0) AS post_karma,
+				COALESCE((SELECT SUM(votes.vote) FROM votes JOIN comments ON comments.id = votes.comment_id
+					WHERE comments.user_id = users.id), 0) AS comment_karma
+			FROM users
+		)
+		UPDATE users SET post_karma = karma.post_karma, comment_karma = karma.comment_karma
+		FROM karma
+		WHERE users.id = karma.id AND (users.post_karma <> karma.post_karma OR users.comment_karma <> karma.comment_karma)
+	`)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to reconcile karma")
+	}
+	return result.RowsAffected, nil
+}
+
+// AdjustKarma adds delta to the karma of the user who received a vote: comment karma for comment votes and post
+// karma otherwise. It runs in the transaction that changes the vote so karma moves together with it.
+func AdjustKarma(tx *gorm.DB, vote models.Vote, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+
+	var err error
+	if vote.CommentID != nil {
+		err = tx.Model(&models.User{}).Unscoped().Where("id = (?)", tx.Model(&models.Comment{}).Unscoped().Select("user_id").Where("id = ?", *vote.CommentID)).
+			Update("comment_karma", gorm.Expr("comment_karma + ?", delta)).Error
+	} else {
+		err = tx.Model(&models.User{}).Unscoped().Where("id = (?)", tx.Model(&models.Post{}).Unscoped().Select("user_id").Where("id = ?", vote.PostID)).
+			Update("post_karma", gorm.Expr("post_karma + ?", delta)).Error
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update karma")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKarma(t *testing.T) {
+	if !dbAvailable {
+		t.Skip("Database not available, skipping repository integration tests")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	author := models.User{Username: "karmaauthor", Password: "password"}
+	voter := models.User{Username: "karmavoter", Password: "password"}
+	other := models.User{Username: "karmaother", Password: "password"}
+	database.DB.Create(&author)
+	database.DB.Create(&voter)
+	database.DB.Create(&other)
+	sub := models.Sub{Name: "karmasub", OwnerID: author.ID}
+	database.DB.Create(&sub)
+	post := models.Post{Title: "Post", Content: "Content", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+	comment := models.Comment{Content: "Comment", PostID: post.ID, UserID: author.ID}
+	database.DB.Create(&comment)
+
+	karma := func() models.User {
+		var user models.User
+		require.NoError(t, database.DB.First(&user, author.ID).Error)
+		return user
+	}
+
+	t.Run("votes adjust post or comment karma", func(t *testing.T) {
+		require.NoError(t, AdjustKarma(database.DB, models.Vote{PostID: post.ID, Vote: 1}, 1))
+		require.NoError(t, AdjustKarma(database.DB, models.Vote{PostID: post.ID, CommentID: &comment.ID, Vote: -1}, -1))
+		user := karma()
+		assert.Equal(t, 1, user.PostKarma)
+		assert.Equal(t, -1, user.CommentKarma)
+		assert.Equal(t, 0, user.Karma())
+	})
+
+	t.Run("reconciliation recomputes karma from votes", func(t *testing.T) {
+		database.DB.Create(&models.Vote{UserID: voter.ID, PostID: post.ID, Vote: 1})
+		database.DB.Create(&models.Vote{UserID: other.ID, PostID: post.ID, Vote: 1})
+		database.DB.Create(&models.Vote{UserID: voter.ID, PostID: post.ID, CommentID: &comment.ID, Vote: -1})
+
+		corrected, err := NewKarmaRepository().Reconcile()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), corrected)
+		user := karma()
+		assert.Equal(t, 2, user.PostKarma)
+		assert.Equal(t, -1, user.CommentKarma)
+
+		corrected, err = NewKarmaRepository().Reconcile()
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), corrected)
+	})
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ILinkRepository defines methods for the cache of unfurled links
+type ILinkRepository interface {
+	GetLinkPreview(url string) (*models.LinkPreview, error)
+	SaveLinkPreview(preview *models.LinkPreview) error
+	GetPendingLinks() ([]string, error)
+}
+
+// LinkRepository implements ILinkRepository
+type LinkRepository struct{}
+
+// NewLinkRepository creates a new link repository
+func NewLinkRepository() ILinkRepository {
+	return &LinkRepository{}
+}
+
+// GetLinkPreview returns the cached preview of a URL
+func (r *LinkRepository) GetLinkPreview(url string) (*models.LinkPreview, error) {
+	var preview models.LinkPreview
+	if err := db.DB.Where("url = ?", url).First(&preview).Error; err != nil {
+		return nil, fmt.Errorf("link preview not found")
+	}
+	return &preview, nil
+}
+
+// SaveLinkPreview caches the preview of a URL, replacing the one fetched before
+func (r *LinkRepository) SaveLinkPreview(preview *models.LinkPreview) error {
+	err := db.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "url"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "site_name", "thumbnail_url", "failed", "fetched_at"}),
+	}).Create(preview).Error
+	if err != nil {
+		return fmt.Errorf("failed to save link preview")
+	}
+	return nil
+}
+
+// GetPendingLinks lists the links of posts that haven't been unfurled yet
+func (r *LinkRepository) GetPendingLinks() ([]string, error) {
+	var urls []string
+	if err := db.DB.Model(&models.Post{}).
+		Joins("LEFT JOIN link_previews ON link_previews.url = posts.url").
+		Where("posts.url IS NOT NULL AND posts.url <> '' AND link_previews.id IS NULL").
+		Distinct().Limit(100).Pluck("posts.url", &urls).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch pending links")
+	}
+	return urls, nil
+}
+
+// NormalizeDomain lowercases a host and drops a leading "www.", so links to a site match however they are written
+func NormalizeDomain(host string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(host)), "www.")
+}
+
+// FilterByDomain limits a post query to link posts to a domain; an empty domain doesn't filter
+func FilterByDomain(domain string) func(*gorm.DB) *gorm.DB {
+	domain = NormalizeDomain(domain)
+	if domain == "" {
+		return func(tx *gorm.DB) *gorm.DB { return tx }
+	}
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("posts.domain = ?", domain)
+	}
+}
+
+// linkFor builds the link of a link post, with its cached preview
+func linkFor(post models.Post) *models.LinkResponse {
+	return linkForResponse(post, getLinkPreviews(postURLs([]models.Post{post})))
+}
+
+// getLinkPreviews loads the cached previews of a set of URLs, by URL, including failed fetches
+func getLinkPreviews(urls []string) map[string]models.LinkPreview {
+	previews := map[string]models.LinkPreview{}
+	if len(urls) == 0 {
+		return previews
+	}
+
+	var found []models.LinkPreview
+	db.DB.Where("url IN ?", urls).Find(&found)
+	for _, preview := range found {
+		previews[preview.URL] = preview
+	}
+	return previews
+}
+
+// linkForResponse builds the link of a link post from previews loaded with getLinkPreviews
+func linkForResponse(post models.Post, previews map[string]models.LinkPreview) *models.LinkResponse {
+	if post.URL == nil || *post.URL == "" {
+		return nil
+	}
+
+	link := &models.LinkResponse{URL: *post.URL, Domain: post.Domain}
+	preview, ok := previews[*post.URL]
+	if !ok {
+		link.Pending = true
+	} else if !preview.Failed {
+		link.Title = preview.Title
+		link.Description = preview.Description
+		link.SiteName = preview.SiteName
+		link.ThumbnailURL = preview.ThumbnailURL
+	}
+	return link
+}
+
+// postURLs collects the links of a set of link posts
+func postURLs(posts []models.Post) []string {
+	var urls []string
+	for _, post := range posts {
+		if post.URL != nil && *post.URL != "" {
+			urls = append(urls, *post.URL)
+		}
+	}
+	return urls
+}
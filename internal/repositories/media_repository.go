@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MediaURL returns the address the full size of an uploaded image is served from. Files are served through
+// the API whatever the storage backend, prefixed with MEDIA_BASE_URL when the API is not on the same origin.
+func MediaURL(mediaID uint) string {
+	return fmt.Sprintf("%s/api/media/%d/file", strings.TrimRight(os.Getenv("MEDIA_BASE_URL"), "/"), mediaID)
+}
+
+// RenditionURL returns the address one size of an uploaded image is served from
+func RenditionURL(mediaID uint, name string) string {
+	return fmt.Sprintf("%s/api/media/%d/renditions/%s", strings.TrimRight(os.Getenv("MEDIA_BASE_URL"), "/"), mediaID, name)
+}
+
+// IMediaRepository defines methods for uploaded media records and their renditions
+type IMediaRepository interface {
+	CreateMedia(media *models.Media) error
+	GetMediaByID(mediaID uint) (*models.Media, error)
+	GetRenditions(mediaID uint) ([]models.MediaRendition, error)
+	GetRendition(mediaID uint, name string) (*models.MediaRendition, error)
+	ClaimMedia(mediaID uint, staleBefore time.Time) (bool, error)
+	GetUnprocessedMedia(staleBefore time.Time) ([]uint, error)
+	CompleteMedia(mediaID uint, width, height int, blurHash string, renditions []models.MediaRendition) error
+	FailMedia(mediaID uint) error
+}
+
+// MediaRepository implements IMediaRepository
+type MediaRepository struct{}
+
+// NewMediaRepository creates a new media repository
+func NewMediaRepository() IMediaRepository {
+	return &MediaRepository{}
+}
+
+// CreateMedia records an uploaded file
+func (r *MediaRepository) CreateMedia(media *models.Media) error {
+	if err := db.DB.Create(media).Error; err != nil {
+		return fmt.Errorf("failed to save media")
+	}
+	return nil
+}
+
+// GetMediaByID returns an uploaded file's record
+func (r *MediaRepository) GetMediaByID(mediaID uint) (*models.Media, error) {
+	var media models.Media
+	if err := db.DB.First(&media, mediaID).Error; err != nil {
+		return nil, fmt.Errorf("media not found")
+	}
+	return &media, nil
+}
+
+// GetRenditions lists the sizes a processed image is available in, smallest first
+func (r *MediaRepository) GetRenditions(mediaID uint) ([]models.MediaRendition, error) {
+	var renditions []models.MediaRendition
+	if err := db.DB.Where("media_id = ?", mediaID).Order("width ASC, id ASC").Find(&renditions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch media")
+	}
+	return renditions, nil
+}
+
+// GetRendition returns one size of a processed image
+func (r *MediaRepository) GetRendition(mediaID uint, name string) (*models.MediaRendition, error) {
+	var rendition models.MediaRendition
+	if err := db.DB.Where("media_id = ? AND name = ?", mediaID, name).First(&rendition).Error; err != nil {
+		return nil, fmt.Errorf("rendition not found")
+	}
+	return &rendition, nil
+}
+
+// ClaimMedia marks an upload as being processed, reporting whether this caller got it. Uploads that are
+// pending, or whose worker started more than a while ago and presumably died, can be claimed.
+func (r *MediaRepository) ClaimMedia(mediaID uint, staleBefore time.Time) (bool, error) {
+	result := db.DB.Model(&models.Media{}).
+		Where("id = ? AND (status = ? OR (status = ? AND processing_started_at < ?))",
+			mediaID, models.MediaStatusPending, models.MediaStatusProcessing, staleBefore).
+		Updates(map[string]interface{}{"status": models.MediaStatusProcessing, "processing_started_at": time.Now()})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim media")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetUnprocessedMedia lists uploads that are waiting to be processed or whose processing stalled, oldest first
+func (r *MediaRepository) GetUnprocessedMedia(staleBefore time.Time) ([]uint, error) {
+	var ids []uint
+	if err := db.DB.Model(&models.Media{}).
+		Where("status = ? OR (status = ? AND processing_started_at < ?)",
+			models.MediaStatusPending, models.MediaStatusProcessing, staleBefore).
+		Order("id ASC").Limit(100).Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch media")
+	}
+	return ids, nil
+}
+
+// CompleteMedia stores the renditions of a processed image and marks it ready
+func (r *MediaRepository) CompleteMedia(mediaID uint, width, height int, blurHash string, renditions []models.MediaRendition) error {
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		for _, rendition := range renditions {
+			rendition.MediaID = mediaID
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "media_id"}, {Name: "name"}},
+				DoUpdates: clause.AssignmentColumns([]string{"storage_key", "content_type", "width", "height", "size"}),
+			}).Create(&rendition).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Media{}).Where("id = ?", mediaID).Updates(map[string]interface{}{
+			"status":    models.MediaStatusReady,
+			"width":     width,
+			"height":    height,
+			"blur_hash": blurHash,
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save media")
+	}
+	return nil
+}
+
+// FailMedia marks an upload that couldn't be processed
+func (r *MediaRepository) FailMedia(mediaID uint) error {
+	if err := db.DB.Model(&models.Media{}).Where("id = ?", mediaID).Update("status", models.MediaStatusFailed).Error; err != nil {
+		return fmt.Errorf("failed to save media")
+	}
+	return nil
+}
+
+// FormatMedia converts an uploaded file's record and renditions to the response format
+func FormatMedia(media models.Media, renditions []models.MediaRendition) *models.MediaResponse {
+	return &models.MediaResponse{
+		ID:          media.ID,
+		URL:         MediaURL(media.ID),
+		ContentType: media.ContentType,
+		Size:        media.Size,
+		Status:      media.Status,
+		Width:       media.Width,
+		Height:      media.Height,
+		BlurHash:    media.BlurHash,
+		Renditions:  renditionResponses(media.ID, renditions),
+		CreatedAt:   media.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// ImageFor builds the image of a post or comment: the renditions of an uploaded image, or the single
+// original of an image linked from elsewhere
+func ImageFor(mediaID *uint, imageURL *string) *models.ImageResponse {
+	var mediaIDs []uint
+	if mediaID != nil {
+		mediaIDs = append(mediaIDs, *mediaID)
+	}
+	return imageForResponse(mediaID, imageURL, GetImageResponses(mediaIDs))
+}
+
+// GetImageResponses loads the images of a set of uploads with their renditions, by media ID
+func GetImageResponses(mediaIDs []uint) map[uint]*models.ImageResponse {
+	responses := map[uint]*models.ImageResponse{}
+	if len(mediaIDs) == 0 {
+		return responses
+	}
+
+	var media []models.Media
+	db.DB.Where("id IN ?", mediaIDs).Find(&media)
+	var renditions []models.MediaRendition
+	db.DB.Where("media_id IN ?", mediaIDs).Order("width ASC, id ASC").Find(&renditions)
+	renditionsByMedia := map[uint][]models.MediaRendition{}
+	for _, rendition := range renditions {
+		renditionsByMedia[rendition.MediaID] = append(renditionsByMedia[rendition.MediaID], rendition)
+	}
+
+	for _, upload := range media {
+		mediaID := upload.ID
+		responses[mediaID] = &models.ImageResponse{
+			MediaID:    &mediaID,
+			Status:     upload.Status,
+			Width:      upload.Width,
+			Height:     upload.Height,
+			BlurHash:   upload.BlurHash,
+			Renditions: renditionResponses(mediaID, renditionsByMedia[mediaID]),
+		}
+	}
+	return responses
+}
+
+// imageForResponse builds the image of a post or comment from images loaded with GetImageResponses
+func imageForResponse(mediaID *uint, imageURL *string, images map[uint]*models.ImageResponse) *models.ImageResponse {
+	if mediaID == nil {
+		if imageURL == nil || *imageURL == "" {
+			return nil
+		}
+		return &models.ImageResponse{Renditions: []models.RenditionResponse{{Name: models.RenditionOriginal, URL: *imageURL}}}
+	}
+	return images[*mediaID]
+}
+
+// postMediaIDs collects the uploads used by a set of posts
+func postMediaIDs(posts []models.Post) []uint {
+	var mediaIDs []uint
+	for _, post := range posts {
+		if post.MediaID != nil {
+			mediaIDs = append(mediaIDs, *post.MediaID)
+		}
+	}
+	return mediaIDs
+}
+
+// renditionResponses lists the sizes of a processed image with the URLs they are served from
+func renditionResponses(mediaID uint, renditions []models.MediaRendition) []models.RenditionResponse {
+	responses := []models.RenditionResponse{}
+	for _, rendition := range renditions {
+		responses = append(responses, models.RenditionResponse{
+			Name:        rendition.Name,
+			URL:         RenditionURL(mediaID, rendition.Name),
+			ContentType: rendition.ContentType,
+			Width:       rendition.Width,
+			Height:      rendition.Height,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"fmt"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IMentionRepository defines methods for the mentions stored with posts and comments
+type IMentionRepository interface {
+	ReplaceMentions(postID, commentID *uint, mentions []models.Mention) error
+	GetMentionedUserIDs(postID, commentID *uint) ([]uint, error)
+}
+
+// MentionRepository implements IMentionRepository
+type MentionRepository struct{}
+
+// NewMentionRepository creates a new mention repository
+func NewMentionRepository() IMentionRepository {
+	return &MentionRepository{}
+}
+
+// ReplaceMentions swaps the stored mentions of a post (commentID nil) or a comment for a new set
+func (r *MentionRepository) ReplaceMentions(postID, commentID *uint, mentions []models.Mention) error {
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := mentionSource(tx, postID, commentID).Delete(&models.Mention{}).Error; err != nil {
+			return err
+		}
+		for i := range mentions {
+			mentions[i].PostID, mentions[i].CommentID = nil, nil
+			if commentID != nil {
+				mentions[i].CommentID = commentID
+			} else {
+				mentions[i].PostID = postID
+			}
+		}
+		if len(mentions) == 0 {
+			return nil
+		}
+		return tx.Create(&mentions).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save mentions")
+	}
+	return nil
+}
+
+// GetMentionedUserIDs returns the users currently mentioned in a post (commentID nil) or a comment
+func (r *MentionRepository) GetMentionedUserIDs(postID, commentID *uint) ([]uint, error) {
+	var userIDs []uint
+	if err := mentionSource(db.DB.Model(&models.Mention{}), postID, commentID).
+		Where("type = ?", models.MentionTypeUser).Distinct().Pluck("target_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch mentions")
+	}
+	return userIDs, nil
+}
+
+// mentionSource limits a mention query to one post's or one comment's mentions
+func mentionSource(tx *gorm.DB, postID, commentID *uint) *gorm.DB {
+	if commentID != nil {
+		return tx.Where("comment_id = ?", *commentID)
+	}
+	return tx.Where("post_id = ?", *postID)
+}
+
+// getMentionResponses loads the mention spans stored for a set of posts ("post_id") or comments ("comment_id")
+// in one query, keyed by post or comment ID and in content order
+func getMentionResponses(column string, ids []uint) map[uint][]models.MentionResponse {
+	responses := map[uint][]models.MentionResponse{}
+	if len(ids) == 0 {
+		return responses
+	}
+
+	var mentions []models.Mention
+	db.DB.Where(column+" IN ?", ids).Order("start_offset ASC").Find(&mentions)
+	for _, mention := range mentions {
+		contentID := mention.PostID
+		if column == "comment_id" {
+			contentID = mention.CommentID
+		}
+		if contentID == nil {
+			continue
+		}
+		responses[*contentID] = append(responses[*contentID], models.MentionResponse{
+			Type:  mention.Type,
+			Name:  mention.Name,
+			Start: mention.StartOffset,
+			End:   mention.EndOffset,
+		})
+	}
+	return responses
+}
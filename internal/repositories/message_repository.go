@@ -0,0 +1,294 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IMessageRepository defines methods for private messages and modmail
+type IMessageRepository interface {
+	CreateConversation(conversation *models.Conversation, participantIDs []uint, message *models.Message) error
+	GetConversation(conversationID uint) (*models.Conversation, error)
+	IsParticipant(conversationID, userID uint) (bool, error)
+	GetParticipantIDs(conversationID uint) ([]uint, error)
+	AddMessage(message *models.Message) error
+	MarkRead(conversationID, userID uint) error
+	GetMessages(conversationID uint, offset, limit int) ([]models.MessageResponse, error)
+	FormatConversation(conversation models.Conversation, viewerID uint) (*models.ConversationResponse, error)
+	GetUserConversations(userID uint, offset, limit int) ([]models.ConversationResponse, int64, error)
+	GetSubConversations(subID, viewerID uint, offset, limit int) ([]models.ConversationResponse, int64, error)
+	CountUnread(userID uint) (int64, error)
+}
+
+// MessageRepository implements IMessageRepository
+type MessageRepository struct{}
+
+// NewMessageRepository creates a new message repository
+func NewMessageRepository() IMessageRepository {
+	return &MessageRepository{}
+}
+
+// CreateConversation saves a conversation with its participants and first message; the sender has read it
+func (r *MessageRepository) CreateConversation(conversation *models.Conversation, participantIDs []uint, message *models.Message) error {
+	now := time.Now()
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		conversation.CreatedAt = now
+		conversation.UpdatedAt = now
+		if err := tx.Create(conversation).Error; err != nil {
+			return err
+		}
+
+		for _, userID := range participantIDs {
+			participant := models.ConversationParticipant{ConversationID: conversation.ID, UserID: userID}
+			if userID == message.SenderID {
+				participant.LastReadAt = &now
+			}
+			if err := tx.Create(&participant).Error; err != nil {
+				return err
+			}
+		}
+
+		message.ConversationID = conversation.ID
+		message.CreatedAt = now
+		return tx.Create(message).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create conversation")
+	}
+	return nil
+}
+
+func (r *MessageRepository) GetConversation(conversationID uint) (*models.Conversation, error) {
+	var conversation models.Conversation
+	if err := db.DB.First(&conversation, conversationID).Error; err != nil {
+		return nil, fmt.Errorf("conversation not found")
+	}
+	return &conversation, nil
+}
+
+func (r *MessageRepository) IsParticipant(conversationID, userID uint) (bool, error) {
+	var count int64
+	if err := db.DB.Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check conversation participants")
+	}
+	return count > 0, nil
+}
+
+func (r *MessageRepository) GetParticipantIDs(conversationID uint) ([]uint, error) {
+	var userIDs []uint
+	if err := db.DB.Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ?", conversationID).Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to check conversation participants")
+	}
+	return userIDs, nil
+}
+
+// AddMessage saves a reply, joining the sender to the conversation if needed (moderators answering modmail)
+func (r *MessageRepository) AddMessage(message *models.Message) error {
+	now := time.Now()
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		message.CreatedAt = now
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		if err := markRead(tx, message.ConversationID, message.SenderID, now); err != nil {
+			return err
+		}
+		return tx.Model(&models.Conversation{}).Where("id = ?", message.ConversationID).Update("updated_at", now).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send message")
+	}
+	return nil
+}
+
+// MarkRead marks everything in a conversation as read by the user
+func (r *MessageRepository) MarkRead(conversationID, userID uint) error {
+	if err := markRead(db.DB, conversationID, userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark conversation as read")
+	}
+	return nil
+}
+
+// GetMessages returns one page of a conversation's messages, newest first
+func (r *MessageRepository) GetMessages(conversationID uint, offset, limit int) ([]models.MessageResponse, error) {
+	var messages []models.Message
+	if err := db.DB.Where("conversation_id = ?", conversationID).
+		Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&messages).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch messages")
+	}
+
+	participants, err := conversationParticipants(conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []models.MessageResponse{}
+	for _, message := range messages {
+		responses = append(responses, formatMessage(message, participants))
+	}
+	return responses, nil
+}
+
+// FormatConversation builds a conversation summary from the viewer's point of view
+func (r *MessageRepository) FormatConversation(conversation models.Conversation, viewerID uint) (*models.ConversationResponse, error) {
+	participants, err := conversationParticipants(conversation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.ConversationResponse{
+		ID:           conversation.ID,
+		Subject:      conversation.Subject,
+		SubID:        conversation.SubID,
+		Participants: []string{},
+		UpdatedAt:    conversation.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for _, participant := range participants {
+		response.Participants = append(response.Participants, participant.username)
+	}
+
+	if conversation.SubID != nil {
+		var sub models.Sub
+		if err := db.DB.Unscoped().Select("name").First(&sub, *conversation.SubID).Error; err == nil {
+			response.SubName = sub.Name
+		}
+	}
+
+	var last models.Message
+	if err := db.DB.Where("conversation_id = ?", conversation.ID).Order("created_at DESC, id DESC").First(&last).Error; err == nil {
+		message := formatMessage(last, participants)
+		response.LastMessage = &message
+	}
+
+	if err := unreadMessages(viewerID).Where("messages.conversation_id = ?", conversation.ID).Count(&response.Unread).Error; err != nil {
+		return nil, fmt.Errorf("failed to count unread messages")
+	}
+
+	return &response, nil
+}
+
+// GetUserConversations returns one page of the conversations a user takes part in, most recently active first
+func (r *MessageRepository) GetUserConversations(userID uint, offset, limit int) ([]models.ConversationResponse, int64, error) {
+	query := db.DB.Model(&models.Conversation{}).
+		Where("id IN (?)", db.DB.Model(&models.ConversationParticipant{}).Select("conversation_id").Where("user_id = ?", userID))
+	return r.conversationPage(query, userID, offset, limit)
+}
+
+// GetSubConversations returns one page of a sub's modmail, most recently active first
+func (r *MessageRepository) GetSubConversations(subID, viewerID uint, offset, limit int) ([]models.ConversationResponse, int64, error) {
+	query := db.DB.Model(&models.Conversation{}).Where("sub_id = ?", subID)
+	return r.conversationPage(query, viewerID, offset, limit)
+}
+
+// CountUnread counts unread messages across all conversations a user takes part in
+func (r *MessageRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	if err := unreadMessages(userID).Where("cp.id IS NOT NULL").Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count unread messages")
+	}
+	return count, nil
+}
+
+// conversationPage counts and formats one page of a conversation query
+func (r *MessageRepository) conversationPage(query *gorm.DB, viewerID uint, offset, limit int) ([]models.ConversationResponse, int64, error) {
+	// A new session lets the same query be used for both the count and the page
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch conversations")
+	}
+
+	var conversations []models.Conversation
+	if err := query.Order("updated_at DESC").Offset(offset).Limit(limit).Find(&conversations).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch conversations")
+	}
+
+	responses := []models.ConversationResponse{}
+	for _, conversation := range conversations {
+		response, err := r.FormatConversation(conversation, viewerID)
+		if err != nil {
+			return nil, 0, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, total, nil
+}
+
+// participantState is a conversation participant's name and read position
+type participantState struct {
+	userID     uint
+	username   string
+	lastReadAt *time.Time
+}
+
+// conversationParticipants loads the participants of a conversation in the order they joined
+func conversationParticipants(conversationID uint) ([]participantState, error) {
+	var rows []struct {
+		UserID     uint
+		Username   string
+		DeletedAt  gorm.DeletedAt
+		LastReadAt *time.Time
+	}
+	if err := db.DB.Table("conversation_participants").
+		Select("conversation_participants.user_id, users.username, users.deleted_at, conversation_participants.last_read_at").
+		Joins("JOIN users ON users.id = conversation_participants.user_id").
+		Where("conversation_participants.conversation_id = ?", conversationID).
+		Order("conversation_participants.id ASC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to check conversation participants")
+	}
+
+	participants := []participantState{}
+	for _, row := range rows {
+		participants = append(participants, participantState{
+			userID:     row.UserID,
+			username:   authorName(models.User{ID: row.UserID, Username: row.Username, DeletedAt: row.DeletedAt}),
+			lastReadAt: row.LastReadAt,
+		})
+	}
+	return participants, nil
+}
+
+// formatMessage builds a message response, with read receipts from the other participants
+func formatMessage(message models.Message, participants []participantState) models.MessageResponse {
+	response := models.MessageResponse{
+		ID:        message.ID,
+		Sender:    models.DeletedPlaceholder,
+		Body:      message.Body,
+		ReadBy:    []string{},
+		CreatedAt: message.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for _, participant := range participants {
+		if participant.userID == message.SenderID {
+			response.Sender = participant.username
+			continue
+		}
+		if participant.lastReadAt != nil && !participant.lastReadAt.Before(message.CreatedAt) {
+			response.ReadBy = append(response.ReadBy, participant.username)
+		}
+	}
+	return response
+}
+
+// unreadMessages starts a query over messages the user has not sent and not yet read.
+// Conversations the user has never opened (modmail for moderators) count as entirely unread.
+func unreadMessages(userID uint) *gorm.DB {
+	return db.DB.Table("messages").
+		Joins("LEFT JOIN conversation_participants cp ON cp.conversation_id = messages.conversation_id AND cp.user_id = ?", userID).
+		Where("messages.sender_id <> ? AND (cp.last_read_at IS NULL OR messages.created_at > cp.last_read_at)", userID)
+}
+
+// markRead moves a user's read position in a conversation, adding them as a participant if needed
+func markRead(tx *gorm.DB, conversationID, userID uint, at time.Time) error {
+	participant := models.ConversationParticipant{ConversationID: conversationID, UserID: userID}
+	if err := tx.Where("conversation_id = ? AND user_id = ?", conversationID, userID).FirstOrCreate(&participant).Error; err != nil {
+		return err
+	}
+	return tx.Model(&participant).Update("last_read_at", at).Error
+}
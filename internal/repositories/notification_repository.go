@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// INotificationRepository defines methods for storing user notifications
+type INotificationRepository interface {
+	CreateNotification(notification *models.Notification) error
+	NotificationExists(notification *models.Notification) (bool, error)
+	GetNotification(notificationID uint) (*models.Notification, error)
+	GetNotifications(userID uint, unreadOnly bool, offset, limit int) ([]models.NotificationResponse, int64, error)
+	CountUnread(userID uint) (int64, error)
+	SetRead(notification *models.Notification, read bool) error
+	MarkAllRead(userID uint) error
+	GetPreferences(userID uint) (map[string]bool, error)
+	SetPreference(userID uint, notificationType string, enabled bool) error
+}
+
+// NotificationRepository implements INotificationRepository
+type NotificationRepository struct{}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository() INotificationRepository {
+	return &NotificationRepository{}
+}
+
+// CreateNotification stores a notification, unless the user turned off notifications of its type
+func (r *NotificationRepository) CreateNotification(notification *models.Notification) error {
+	var disabled int64
+	if err := db.DB.Model(&models.NotificationPreference{}).
+		Where("user_id = ? AND type = ? AND enabled = ?", notification.UserID, notification.Type, false).
+		Count(&disabled).Error; err != nil {
+		return fmt.Errorf("failed to create notification")
+	}
+	if disabled > 0 {
+		return nil
+	}
+
+	notification.CreatedAt = time.Now()
+	if err := db.DB.Create(notification).Error; err != nil {
+		return fmt.Errorf("failed to create notification")
+	}
+	return nil
+}
+
+// NotificationExists reports whether the user already has a notification of the same type and milestone about the same post
+func (r *NotificationRepository) NotificationExists(notification *models.Notification) (bool, error) {
+	query := db.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ? AND milestone = ?", notification.UserID, notification.Type, notification.Milestone)
+	if notification.PostID != nil {
+		query = query.Where("post_id = ?", *notification.PostID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to fetch notifications")
+	}
+	return count > 0, nil
+}
+
+func (r *NotificationRepository) GetNotification(notificationID uint) (*models.Notification, error) {
+	var notification models.Notification
+	if err := db.DB.First(&notification, notificationID).Error; err != nil {
+		return nil, fmt.Errorf("notification not found")
+	}
+	return &notification, nil
+}
+
+// GetNotifications returns one page of a user's notifications, newest first
+func (r *NotificationRepository) GetNotifications(userID uint, unreadOnly bool, offset, limit int) ([]models.NotificationResponse, int64, error) {
+	query := db.DB.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read = ?", false)
+	}
+	// A new session lets the same query be used for both the count and the page
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch notifications")
+	}
+
+	var notifications []models.Notification
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch notifications")
+	}
+
+	responses := []models.NotificationResponse{}
+	for _, notification := range notifications {
+		responses = append(responses, models.NotificationResponse{
+			ID:        notification.ID,
+			Type:      notification.Type,
+			Message:   notification.Message,
+			SubID:     notification.SubID,
+			PostID:    notification.PostID,
+			CommentID: notification.CommentID,
+			Milestone: notification.Milestone,
+			Read:      notification.Read,
+			CreatedAt: notification.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return responses, total, nil
+}
+
+func (r *NotificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	if err := db.DB.Model(&models.Notification{}).Where("user_id = ? AND read = ?", userID, false).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch notifications")
+	}
+	return count, nil
+}
+
+func (r *NotificationRepository) SetRead(notification *models.Notification, read bool) error {
+	if err := db.DB.Model(notification).Update("read", read).Error; err != nil {
+		return fmt.Errorf("failed to update notification")
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(userID uint) error {
+	if err := db.DB.Model(&models.Notification{}).Where("user_id = ? AND read = ?", userID, false).Update("read", true).Error; err != nil {
+		return fmt.Errorf("failed to update notification")
+	}
+	return nil
+}
+
+// GetPreferences returns the notification types a user has explicitly turned on or off
+func (r *NotificationRepository) GetPreferences(userID uint) (map[string]bool, error) {
+	var preferences []models.NotificationPreference
+	if err := db.DB.Where("user_id = ?", userID).Find(&preferences).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch notification preferences")
+	}
+
+	result := map[string]bool{}
+	for _, preference := range preferences {
+		result[preference.Type] = preference.Enabled
+	}
+	return result, nil
+}
+
+func (r *NotificationRepository) SetPreference(userID uint, notificationType string, enabled bool) error {
+	preference := models.NotificationPreference{UserID: userID, Type: notificationType}
+	err := db.DB.Where("user_id = ? AND type = ?", userID, notificationType).
+		Assign(map[string]interface{}{"enabled": enabled}).FirstOrCreate(&preference).Error
+	if err != nil {
+		return fmt.Errorf("failed to update notification preferences")
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IPollRepository defines methods for the polls of poll posts and their votes
+type IPollRepository interface {
+	GetPollByPostID(postID uint) (*models.Poll, error)
+	CreateVote(vote *models.PollVote) error
+	ClosePoll(poll *models.Poll) error
+}
+
+// PollRepository implements IPollRepository
+type PollRepository struct{}
+
+// NewPollRepository creates a new poll repository
+func NewPollRepository() IPollRepository {
+	return &PollRepository{}
+}
+
+// GetPollByPostID returns the poll of a post with its options in order
+func (r *PollRepository) GetPollByPostID(postID uint) (*models.Poll, error) {
+	var poll models.Poll
+	if err := db.DB.Preload("Options", func(tx *gorm.DB) *gorm.DB { return tx.Order("position ASC") }).
+		Where("post_id = ?", postID).First(&poll).Error; err != nil {
+		return nil, fmt.Errorf("poll not found")
+	}
+	return &poll, nil
+}
+
+// CreateVote records a user's vote; the unique index keeps it to one vote per user even when requests race
+func (r *PollRepository) CreateVote(vote *models.PollVote) error {
+	result := db.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(vote)
+	if result.Error != nil {
+		return fmt.Errorf("failed to save vote")
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("you have already voted in this poll")
+	}
+	return nil
+}
+
+// ClosePoll ends voting in a poll now
+func (r *PollRepository) ClosePoll(poll *models.Poll) error {
+	now := time.Now()
+	if err := db.DB.Model(poll).Update("closed_at", now).Error; err != nil {
+		return fmt.Errorf("failed to close poll")
+	}
+	poll.ClosedAt = &now
+	return nil
+}
+
+// createPoll saves the poll of a new post with its options
+func createPoll(tx *gorm.DB, postID uint, req models.PollRequest) error {
+	poll := models.Poll{PostID: postID, ClosesAt: req.ClosesAt, HideResults: req.HideResults}
+	for i, text := range req.Options {
+		poll.Options = append(poll.Options, models.PollOption{Position: i, Text: text})
+	}
+	return tx.Create(&poll).Error
+}
+
+// PollFor builds the poll of a post with its results; counts are left out until a poll with hidden results closes
+func PollFor(postID uint) *models.PollResponse {
+	return GetPollResponses([]uint{postID})[postID]
+}
+
+// GetPollResponses builds the polls of a set of posts with their results, by post ID. Posts without a poll are left out.
+func GetPollResponses(postIDs []uint) map[uint]*models.PollResponse {
+	responses := map[uint]*models.PollResponse{}
+	if len(postIDs) == 0 {
+		return responses
+	}
+
+	var polls []models.Poll
+	db.DB.Preload("Options", func(tx *gorm.DB) *gorm.DB { return tx.Order("position ASC") }).
+		Where("post_id IN ?", postIDs).Find(&polls)
+	if len(polls) == 0 {
+		return responses
+	}
+	pollIDs := []uint{}
+	for _, poll := range polls {
+		pollIDs = append(pollIDs, poll.ID)
+	}
+
+	var counts []struct {
+		OptionID uint
+		Votes    int64
+	}
+	db.DB.Model(&models.PollVote{}).Select("option_id, COUNT(*) AS votes").Where("poll_id IN ?", pollIDs).
+		Group("option_id").Scan(&counts)
+	votes := map[uint]int64{}
+	for _, count := range counts {
+		votes[count.OptionID] = count.Votes
+	}
+
+	now := time.Now()
+	for _, poll := range polls {
+		responses[poll.PostID] = formatPoll(poll, votes, now)
+	}
+	return responses
+}
+
+// formatPoll converts a poll to the response format with the vote counts of its options
+func formatPoll(poll models.Poll, votes map[uint]int64, now time.Time) *models.PollResponse {
+	response := &models.PollResponse{
+		Options: []models.PollOptionResponse{},
+		Closed:  poll.Closed(now),
+	}
+	response.ResultsHidden = poll.HideResults && !response.Closed
+	if poll.ClosesAt != nil {
+		response.ClosesAt = poll.ClosesAt.Format("2006-01-02 15:04:05")
+	}
+	for _, option := range poll.Options {
+		optionResponse := models.PollOptionResponse{ID: option.ID, Text: option.Text}
+		if !response.ResultsHidden {
+			count := votes[option.ID]
+			optionResponse.Votes = &count
+		}
+		response.TotalVotes += votes[option.ID]
+		response.Options = append(response.Options, optionResponse)
+	}
+	return response
+}
+
+// MarkPollVotes sets the option the viewer voted for on the polls of a set of posts
+func MarkPollVotes(viewerUsername string, posts []models.PostResponse) {
+	if viewerUsername == "" {
+		return
+	}
+	markPollVotes(db.DB.Model(&models.User{}).Select("id").Where("username = ?", viewerUsername), posts)
+}
+
+// markPollVotes sets the viewer's votes for a viewer ID list or subquery
+func markPollVotes(viewerIDs interface{}, posts []models.PostResponse) {
+	postIDs := []uint{}
+	for _, post := range posts {
+		if post.Poll != nil {
+			postIDs = append(postIDs, post.ID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+
+	var votes []struct {
+		PostID   uint
+		OptionID uint
+	}
+	if err := db.DB.Model(&models.PollVote{}).Select("polls.post_id, poll_votes.option_id").
+		Joins("JOIN polls ON polls.id = poll_votes.poll_id").
+		Where("poll_votes.user_id IN (?) AND polls.post_id IN ?", viewerIDs, postIDs).Scan(&votes).Error; err != nil {
+		// Like the saved flag, the viewer's vote is informational
+		return
+	}
+
+	voted := map[uint]uint{}
+	for _, vote := range votes {
+		voted[vote.PostID] = vote.OptionID
+	}
+	for i := range posts {
+		if optionID, ok := voted[posts[i].ID]; ok && posts[i].Poll != nil {
+			posts[i].Poll.VotedOptionID = &optionID
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+)
+
+// IPostModerationRepository defines methods for pinning, locking and deleting posts
+type IPostModerationRepository interface {
+	GetPost(postID uint) (*models.Post, error)
+	CountPinned(subID uint) (int64, error)
+	SetPinned(post *models.Post, pinned bool) error
+	SetLocked(post *models.Post, locked bool) error
+	SetFlags(post *models.Post, nsfw, spoiler bool) error
+	DeletePost(post *models.Post) error
+}
+
+// PostModerationRepository implements IPostModerationRepository
+type PostModerationRepository struct{}
+
+// NewPostModerationRepository creates a new post moderation repository
+func NewPostModerationRepository() IPostModerationRepository {
+	return &PostModerationRepository{}
+}
+
+func (r *PostModerationRepository) GetPost(postID uint) (*models.Post, error) {
+	var post models.Post
+	if err := db.DB.Scopes(PublishedPosts).First(&post, postID).Error; err != nil {
+		return nil, fmt.Errorf("post not found")
+	}
+	return &post, nil
+}
+
+func (r *PostModerationRepository) CountPinned(subID uint) (int64, error) {
+	var count int64
+	if err := db.DB.Model(&models.Post{}).Where("sub_id = ? AND pinned = ?", subID, true).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch pinned posts")
+	}
+	return count, nil
+}
+
+// SetPinned pins or unpins a post; pinned posts remember when they were pinned to keep their order
+func (r *PostModerationRepository) SetPinned(post *models.Post, pinned bool) error {
+	var pinnedAt *time.Time
+	if pinned {
+		now := time.Now()
+		pinnedAt = &now
+	}
+
+	if err := db.DB.Model(post).Updates(map[string]interface{}{"pinned": pinned, "pinned_at": pinnedAt}).Error; err != nil {
+		return fmt.Errorf("failed to update post")
+	}
+	post.Pinned = pinned
+	post.PinnedAt = pinnedAt
+	return nil
+}
+
+func (r *PostModerationRepository) SetLocked(post *models.Post, locked bool) error {
+	if err := db.DB.Model(post).Update("locked", locked).Error; err != nil {
+		return fmt.Errorf("failed to update post")
+	}
+	post.Locked = locked
+	return nil
+}
+
+// SetFlags marks a post as NSFW or a spoiler, or clears the marks
+func (r *PostModerationRepository) SetFlags(post *models.Post, nsfw, spoiler bool) error {
+	if err := db.DB.Model(post).Updates(map[string]interface{}{"nsfw": nsfw, "spoiler": spoiler}).Error; err != nil {
+		return fmt.Errorf("failed to update post")
+	}
+	post.NSFW, post.Spoiler = nsfw, spoiler
+	return nil
+}
+
+// DeletePost soft-deletes a post; it renders as a tombstone until the purge job removes it
+func (r *PostModerationRepository) DeletePost(post *models.Post) error {
+	if err := db.DB.Delete(post).Error; err != nil {
+		return fmt.Errorf("failed to delete post")
+	}
+	return nil
+}
@@ -5,15 +5,18 @@ import (
 	"fmt"
 
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/markdown"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
 )
 
 // FindPostByID retrieves a single post from the database by ID
 func GetPostByID(postID string) (*models.PostResponse, error) {
 	var upvotes, downvotes int64
 
+	// Deleted posts are still returned as tombstones so links to them and their comment threads keep working
 	var post models.Post
-	if err := db.DB.Preload("User").Where("id = ?", postID).First(&post).Error; err != nil {
+	if err := db.DB.Unscoped().Preload("User").Where("id = ?", postID).Scopes(PublishedPosts).First(&post).Error; err != nil {
 		return nil, errors.New("post not found")
 	}
 
@@ -24,23 +27,46 @@ func GetPostByID(postID string) (*models.PostResponse, error) {
 
 	// ✅ Return a properly formatted PostResponse
 	postResponse := models.PostResponse{
-		ID:        post.ID,
-		Title:     post.Title,
-		Content:   post.Content,
-		ImageURL:  post.ImageURL,
-		Upvotes:   int(upvotes),
-		Downvotes: int(downvotes),
-		Username:  post.User.Username, // ✅ Ensure "User" is preloaded
-		CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
+		ID:          post.ID,
+		Title:       post.Title,
+		Content:     post.Content,
+		ContentHTML: renderedContent(post.Content, post.ContentHTML),
+		Image:       ImageFor(post.MediaID, post.ImageURL),
+		Link:        linkFor(post),
+		Poll:        PollFor(post.ID),
+		Upvotes:     int(upvotes),
+		Downvotes:   int(downvotes),
+		Username:    authorName(post.User), // ✅ Ensure "User" is preloaded
+		SubID:       post.SubID,
+		Flair:       flairForResponse(post.FlairID, GetFlairResponses(postFlairIDs([]models.Post{post}))),
+		Mentions:    getMentionResponses("post_id", []uint{post.ID})[post.ID],
+		Pinned:      post.Pinned,
+		Locked:      post.Locked,
+		Archived:    post.Archived,
+		CreatedAt:   post.CreatedAt.Format("2006-01-02 15:04:05"),
 	}
 
-	return &postResponse, nil
+	if post.DeletedAt.Valid {
+		postResponse.Title = models.DeletedPlaceholder
+		postResponse.Content = models.DeletedPlaceholder
+		postResponse.ContentHTML = markdown.Render(models.DeletedPlaceholder)
+		postResponse.Image = nil
+		postResponse.Link = nil
+		postResponse.Poll = nil
+		postResponse.Mentions = nil
+		postResponse.Username = models.DeletedPlaceholder
+		postResponse.Deleted = true
+	}
+
+	responses := []models.PostResponse{postResponse}
+	setCrossposts(responses)
+	return &responses[0], nil
 }
 
-// FindAllPosts retrieves all posts
+// FindAllPosts retrieves all published posts
 func FindAllPosts() ([]models.Post, error) {
 	var posts []models.Post
-	if err := db.DB.Find(&posts).Error; err != nil {
+	if err := db.DB.Scopes(PublishedPosts).Find(&posts).Error; err != nil {
 		return nil, err
 	}
 	return posts, nil
@@ -60,61 +86,177 @@ func CreatePost(username string, post models.Post) (*models.Post, error) {
 		return nil, fmt.Errorf("sub not found")
 	}
 
-	// Save post to the database
-	if err := db.DB.Create(&post).Error; err != nil {
+	// The rendered HTML is stored with each revision of the content; anything the client sent is replaced
+	post.ContentHTML = markdown.Render(post.Content)
+
+	// Save post to the database, with its poll if it has one
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&post).Error; err != nil {
+			return err
+		}
+		if post.Poll != nil {
+			return createPoll(tx, post.ID, *post.Poll)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to create post")
 	}
 
 	return &post, nil
 }
 
-func GetPosts() (*[]models.PostResponse, error) {
-	var posts []models.Post
+// UpdatePost saves a new revision of a post's content
+func UpdatePost(postID uint, req models.PostUpdateRequest) (*models.Post, error) {
+	var post models.Post
+	if err := db.DB.First(&post, postID).Error; err != nil {
+		return nil, fmt.Errorf("post not found")
+	}
 
-	// Fetch posts and preload user details
-	if err := db.DB.Preload("User").Order("created_at DESC").Find(&posts).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch posts")
+	post.Content = req.Content
+	post.ContentHTML = markdown.Render(req.Content)
+
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		revision := models.Revision{
+			ContentType: models.ContentTypePost,
+			ContentID:   post.ID,
+			Content:     post.Content,
+			ContentHTML: post.ContentHTML,
+			ImageURL:    post.ImageURL,
+		}
+		if err := saveRevision(tx, revision); err != nil {
+			return err
+		}
+		return tx.Model(&post).Updates(map[string]interface{}{"content": post.Content, "content_html": post.ContentHTML}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update post")
 	}
 
-	// Format response to include votes and comments for each post
-	var formattedPosts []models.PostResponse
-	for _, post := range posts {
-		var upvotes, downvotes int64
+	return &post, nil
+}
 
-		// Count upvotes (vote = 1)
-		db.DB.Model(&models.Vote{}).Where("post_id = ? AND vote = 1", post.ID).Count(&upvotes)
+// GetPosts lists posts outside deleted subs; domain optionally limits them to link posts to that domain
+func GetPosts(username, domain string) (*[]models.PostResponse, error) {
+	var posts []models.Post
 
-		// Count downvotes (vote = -1)
-		db.DB.Model(&models.Vote{}).Where("post_id = ? AND vote = -1", post.ID).Count(&downvotes)
+	// Fetch published posts and preload user details, skipping posts in deleted subs, private subs the viewer can't see,
+	// posts by users the viewer blocked, posts they hid and sensitive posts they chose not to see
+	deletedSubs := db.DB.Unscoped().Model(&models.Sub{}).Select("id").Where("deleted_at IS NOT NULL")
+	if err := db.DB.Preload("User").Where("sub_id NOT IN (?)", deletedSubs).
+		Scopes(PublishedPosts, HidePrivateSubPosts(username), HideBlockedAuthors(username), HideHiddenPosts(username), HideSensitivePosts(username), FilterByDomain(domain)).
+		Order("created_at DESC").Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch posts")
+	}
 
-		// Fetch comments for the post
+	// Format response to include comments for each post, including deleted ones as tombstones
+	formattedPosts := formatPosts(posts)
+	for i := range formattedPosts {
 		var comments []models.Comment
-		db.DB.Preload("User").Where("post_id = ?", post.ID).Order("created_at ASC").Find(&comments)
+		db.DB.Unscoped().Preload("User").Where("post_id = ?", formattedPosts[i].ID).Scopes(HideBlockedAuthors(username)).
+			Order("created_at ASC").Find(&comments)
 
-		// Convert comments into formatted response
-		var formattedComments []models.CommentResponse
+		mentions := getMentionResponses("comment_id", commentIDs(comments))
 		for _, comment := range comments {
-			formattedComments = append(formattedComments, models.CommentResponse{
-				ID:        comment.ID,
-				Content:   comment.Content,
-				ImageURL:  comment.ImageURL,
-				Username:  comment.User.Username,
-				CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
-			})
+			formattedPosts[i].Comments = append(formattedPosts[i].Comments, formatComment(comment, mentions))
+		}
+	}
+
+	MarkSensitivePosts(username, formattedPosts)
+	MarkSavedPosts(username, formattedPosts)
+	MarkPollVotes(username, formattedPosts)
+
+	return &formattedPosts, nil
+}
+
+// PublishedPosts is a query scope that leaves out drafts and scheduled posts, which only their author sees
+func PublishedPosts(tx *gorm.DB) *gorm.DB {
+	return tx.Where("posts.status = ?", models.PostStatusPublished)
+}
+
+// HidePrivateSubPosts is a query scope that leaves out posts in private subs the viewer neither owns nor belongs to;
+// logged-out viewers only see posts in public subs
+func HidePrivateSubPosts(viewerUsername string) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		viewerIDs := func() *gorm.DB { return db.DB.Model(&models.User{}).Select("id").Where("username = ?", viewerUsername) }
+		return tx.Where("posts.sub_id IN (?)", db.DB.Model(&models.Sub{}).Select("id").
+			Where("private = ? OR owner_id IN (?) OR id IN (?)", false, viewerIDs(),
+				db.DB.Model(&models.SubMembership{}).Select("sub_id").Where("user_id IN (?)", viewerIDs())))
+	}
+}
+
+// postIDs collects the IDs of a set of posts
+func postIDs(posts []models.Post) []uint {
+	ids := []uint{}
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	return ids
+}
+
+// postFlairIDs collects the flair IDs used by a set of posts
+func postFlairIDs(posts []models.Post) []uint {
+	var flairIDs []uint
+	for _, post := range posts {
+		if post.FlairID != nil {
+			flairIDs = append(flairIDs, *post.FlairID)
 		}
+	}
+	return flairIDs
+}
+
+// formatPosts converts posts with their preloaded authors to the response format, without comments
+func formatPosts(posts []models.Post) []models.PostResponse {
+	flairs := GetFlairResponses(postFlairIDs(posts))
+	images := GetImageResponses(postMediaIDs(posts))
+	links := getLinkPreviews(postURLs(posts))
+	polls := GetPollResponses(postIDs(posts))
+	mentions := getMentionResponses("post_id", postIDs(posts))
+
+	formattedPosts := []models.PostResponse{}
+	for _, post := range posts {
+		var upvotes, downvotes int64
+		db.DB.Model(&models.Vote{}).Where("post_id = ? AND vote = 1", post.ID).Count(&upvotes)
+		db.DB.Model(&models.Vote{}).Where("post_id = ? AND vote = -1", post.ID).Count(&downvotes)
 
 		formattedPosts = append(formattedPosts, models.PostResponse{
-			ID:        post.ID,
-			Title:     post.Title,
-			Content:   post.Content,
-			ImageURL:  post.ImageURL,
-			Username:  post.User.Username,
-			Upvotes:   int(upvotes),
-			Downvotes: int(downvotes),
-			Comments:  formattedComments,
-			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
+			ID:          post.ID,
+			Title:       post.Title,
+			Content:     post.Content,
+			ContentHTML: renderedContent(post.Content, post.ContentHTML),
+			Image:       imageForResponse(post.MediaID, post.ImageURL, images),
+			Link:        linkForResponse(post, links),
+			Poll:        polls[post.ID],
+			Username:    authorName(post.User),
+			Upvotes:     int(upvotes),
+			Downvotes:   int(downvotes),
+			SubID:       post.SubID,
+			Flair:       flairForResponse(post.FlairID, flairs),
+			Mentions:    mentions[post.ID],
+			Pinned:      post.Pinned,
+			Locked:      post.Locked,
+			Archived:    post.Archived,
+			CreatedAt:   post.CreatedAt.Format("2006-01-02 15:04:05"),
 		})
 	}
+	setCrossposts(formattedPosts)
+	return formattedPosts
+}
 
-	return &formattedPosts, nil
+// GetPostScore returns a post's upvotes minus its downvotes
+func GetPostScore(postID uint) (int64, error) {
+	var score int64
+	if err := db.DB.Model(&models.Vote{}).Select("COALESCE(SUM(vote), 0)").Where("post_id = ?", postID).Scan(&score).Error; err != nil {
+		return 0, fmt.Errorf("failed to count votes")
+	}
+	return score, nil
+}
+
+// renderedContent returns the HTML stored for a revision of some content, rendering content saved before HTML was stored.
+// Stored HTML is sanitized again with the current allowed tags, which may be stricter than when it was rendered.
+func renderedContent(content, contentHTML string) string {
+	if contentHTML != "" {
+		return markdown.Sanitize(contentHTML)
+	}
+	return markdown.Render(content)
 }
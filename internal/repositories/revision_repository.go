@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"fmt"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IRevisionRepository defines methods for the edit history of posts and comments
+type IRevisionRepository interface {
+	GetRevisions(contentType string, contentID uint) ([]models.Revision, error)
+	GetContentOwner(contentType string, contentID uint) (userID, subID uint, err error)
+}
+
+// RevisionRepository implements IRevisionRepository
+type RevisionRepository struct{}
+
+// NewRevisionRepository creates a new revision repository
+func NewRevisionRepository() IRevisionRepository {
+	return &RevisionRepository{}
+}
+
+// GetRevisions returns every version of a post or comment, oldest first. Content that was never edited
+// has no stored revisions and is returned as its only version. Deleted content keeps its history for moderators.
+func (r *RevisionRepository) GetRevisions(contentType string, contentID uint) ([]models.Revision, error) {
+	var revisions []models.Revision
+	if err := db.DB.Where("content_type = ? AND content_id = ?", contentType, contentID).
+		Order("number ASC").Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch revisions")
+	}
+	if len(revisions) > 0 {
+		return revisions, nil
+	}
+
+	current, err := currentRevision(db.DB, contentType, contentID)
+	if err != nil {
+		return nil, err
+	}
+	return []models.Revision{*current}, nil
+}
+
+// GetContentOwner returns the author of a post or comment and the sub it was posted in
+func (r *RevisionRepository) GetContentOwner(contentType string, contentID uint) (uint, uint, error) {
+	switch contentType {
+	case models.ContentTypePost:
+		var post models.Post
+		if err := db.DB.Unscoped().Scopes(PublishedPosts).First(&post, contentID).Error; err != nil {
+			return 0, 0, fmt.Errorf("post not found")
+		}
+		return post.UserID, post.SubID, nil
+	case models.ContentTypeComment:
+		var row struct {
+			UserID uint
+			SubID  uint
+		}
+		if err := db.DB.Table("comments").Select("comments.user_id, posts.sub_id").
+			Joins("JOIN posts ON posts.id = comments.post_id").
+			Where("comments.id = ?", contentID).Take(&row).Error; err != nil {
+			return 0, 0, fmt.Errorf("comment not found")
+		}
+		return row.UserID, row.SubID, nil
+	}
+	return 0, 0, fmt.Errorf("content type must be post or comment")
+}
+
+// saveRevision records an edit inside the transaction that saves it, and must run before the new content is
+// written. The first edit also records the version being replaced, so the history starts with the original content.
+func saveRevision(tx *gorm.DB, revised models.Revision) error {
+	var latest models.Revision
+	if err := tx.Where("content_type = ? AND content_id = ?", revised.ContentType, revised.ContentID).
+		Order("number DESC").Limit(1).Find(&latest).Error; err != nil {
+		return err
+	}
+
+	if latest.ID == 0 {
+		original, err := currentRevision(tx, revised.ContentType, revised.ContentID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(original).Error; err != nil {
+			return err
+		}
+		latest = *original
+	}
+
+	revised.Number = latest.Number + 1
+	return tx.Create(&revised).Error
+}
+
+// currentRevision builds the current version of a post or comment as a revision
+func currentRevision(tx *gorm.DB, contentType string, contentID uint) (*models.Revision, error) {
+	revision := models.Revision{ContentType: contentType, ContentID: contentID, Number: 1}
+	switch contentType {
+	case models.ContentTypePost:
+		var post models.Post
+		if err := tx.Unscoped().First(&post, contentID).Error; err != nil {
+			return nil, fmt.Errorf("post not found")
+		}
+		revision.Content, revision.ContentHTML, revision.ImageURL = post.Content, post.ContentHTML, post.ImageURL
+		revision.CreatedAt = post.CreatedAt
+	case models.ContentTypeComment:
+		var comment models.Comment
+		if err := tx.Unscoped().First(&comment, contentID).Error; err != nil {
+			return nil, fmt.Errorf("comment not found")
+		}
+		revision.Content, revision.ContentHTML, revision.ImageURL = comment.Content, comment.ContentHTML, comment.ImageURL
+		revision.CreatedAt = comment.CreatedAt
+		if comment.UpdatedAt != nil {
+			revision.CreatedAt = *comment.UpdatedAt
+		}
+	default:
+		return nil, fmt.Errorf("content type must be post or comment")
+	}
+	revision.ContentHTML = renderedContent(revision.Content, revision.ContentHTML)
+	return &revision, nil
+}
@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"fmt"
+	"strconv"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ISavedRepository defines methods for saved posts and comments and hidden posts
+type ISavedRepository interface {
+	ContentExists(contentType string, contentID uint) error
+	SaveItem(item *models.SavedItem) error
+	DeleteSavedItem(userID uint, contentType string, contentID uint) (bool, error)
+	GetSavedItems(userID uint, contentType, category string, offset, limit int) ([]models.SavedItemResponse, int64, error)
+	GetCategories(userID uint) ([]string, error)
+	HidePost(userID, postID uint) error
+	UnhidePost(userID, postID uint) (bool, error)
+	GetHiddenPosts(userID uint, offset, limit int) ([]models.PostResponse, int64, error)
+}
+
+// SavedRepository implements ISavedRepository
+type SavedRepository struct{}
+
+// NewSavedRepository creates a new saved repository
+func NewSavedRepository() ISavedRepository {
+	return &SavedRepository{}
+}
+
+// ContentExists checks that a post or comment exists and hasn't been deleted
+func (r *SavedRepository) ContentExists(contentType string, contentID uint) error {
+	switch contentType {
+	case models.ContentTypePost:
+		if err := db.DB.Select("id").Scopes(PublishedPosts).First(&models.Post{}, contentID).Error; err != nil {
+			return fmt.Errorf("post not found")
+		}
+		return nil
+	case models.ContentTypeComment:
+		if err := db.DB.Select("id").First(&models.Comment{}, contentID).Error; err != nil {
+			return fmt.Errorf("comment not found")
+		}
+		return nil
+	}
+	return fmt.Errorf("type must be post or comment")
+}
+
+// SaveItem saves a post or comment for a user; saving it again only changes its category
+func (r *SavedRepository) SaveItem(item *models.SavedItem) error {
+	err := db.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "content_type"}, {Name: "content_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"category"}),
+	}).Create(item).Error
+	if err != nil {
+		return fmt.Errorf("failed to save item")
+	}
+	return nil
+}
+
+// DeleteSavedItem removes a saved post or comment, reporting whether it was saved
+func (r *SavedRepository) DeleteSavedItem(userID uint, contentType string, contentID uint) (bool, error) {
+	result := db.DB.Where("user_id = ? AND content_type = ? AND content_id = ?", userID, contentType, contentID).
+		Delete(&models.SavedItem{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to unsave item")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetSavedItems returns one page of a user's saved items, most recently saved first. Saved content that was
+// deleted later is listed as a tombstone; content that was purged is left out.
+func (r *SavedRepository) GetSavedItems(userID uint, contentType, category string, offset, limit int) ([]models.SavedItemResponse, int64, error) {
+	query := db.DB.Model(&models.SavedItem{}).Where("user_id = ?", userID).Where(
+		"(content_type = ? AND content_id IN (?)) OR (content_type = ? AND content_id IN (?))",
+		models.ContentTypePost, db.DB.Unscoped().Model(&models.Post{}).Select("id"),
+		models.ContentTypeComment, db.DB.Unscoped().Model(&models.Comment{}).Select("id"))
+	if contentType != "" {
+		query = query.Where("content_type = ?", contentType)
+	}
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch saved items")
+	}
+
+	var items []models.SavedItem
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&items).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch saved items")
+	}
+
+	settings := loadContentSettings([]uint{userID})
+	responses := []models.SavedItemResponse{}
+	for _, item := range items {
+		response := models.SavedItemResponse{
+			Type:     item.ContentType,
+			Category: item.Category,
+			SavedAt:  item.CreatedAt.Format("2006-01-02 15:04:05"),
+		}
+
+		if item.ContentType == models.ContentTypePost {
+			post, err := GetPostByID(strconv.FormatUint(uint64(item.ContentID), 10))
+			if err != nil {
+				continue
+			}
+			post.Saved = true
+			posts := []models.PostResponse{*post}
+			markSensitivePosts(settings, posts)
+			markPollVotes([]uint{userID}, posts)
+			response.PostID = post.ID
+			response.Post = &posts[0]
+		} else {
+			var comment models.Comment
+			if err := db.DB.Unscoped().Preload("User").First(&comment, item.ContentID).Error; err != nil {
+				continue
+			}
+			formatted := formatComment(comment, getMentionResponses("comment_id", []uint{comment.ID}))
+			response.PostID = comment.PostID
+			response.Comment = &formatted
+		}
+		responses = append(responses, response)
+	}
+	return responses, total, nil
+}
+
+// GetCategories lists the categories a user has filed saved items under, alphabetically
+func (r *SavedRepository) GetCategories(userID uint) ([]string, error) {
+	categories := []string{}
+	if err := db.DB.Model(&models.SavedItem{}).Where("user_id = ? AND category <> ''", userID).
+		Distinct().Order("category ASC").Pluck("category", &categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch saved items")
+	}
+	return categories, nil
+}
+
+// HidePost hides a post from a user's listings; hiding it again does nothing
+func (r *SavedRepository) HidePost(userID, postID uint) error {
+	hidden := models.HiddenPost{UserID: userID, PostID: postID}
+	if err := db.DB.Where("user_id = ? AND post_id = ?", userID, postID).FirstOrCreate(&hidden).Error; err != nil {
+		return fmt.Errorf("failed to hide post")
+	}
+	return nil
+}
+
+// UnhidePost shows a hidden post again, reporting whether it was hidden
+func (r *SavedRepository) UnhidePost(userID, postID uint) (bool, error) {
+	result := db.DB.Where("user_id = ? AND post_id = ?", userID, postID).Delete(&models.HiddenPost{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to unhide post")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetHiddenPosts returns one page of the posts a user has hidden, most recently hidden first
+func (r *SavedRepository) GetHiddenPosts(userID uint, offset, limit int) ([]models.PostResponse, int64, error) {
+	query := db.DB.Model(&models.Post{}).Joins("JOIN hidden_posts ON hidden_posts.post_id = posts.id").
+		Where("hidden_posts.user_id = ?", userID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch hidden posts")
+	}
+
+	var posts []models.Post
+	if err := query.Preload("User").Order("hidden_posts.created_at DESC").Offset(offset).Limit(limit).Find(&posts).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch hidden posts")
+	}
+
+	responses := formatPosts(posts)
+	markSensitivePosts(loadContentSettings([]uint{userID}), responses)
+	markSavedPosts([]uint{userID}, responses)
+	markPollVotes([]uint{userID}, responses)
+	return responses, total, nil
+}
+
+// HideHiddenPosts is a query scope over posts that drops the ones the viewer has hidden.
+// Post listings and feeds apply it together with HideBlockedAuthors.
+func HideHiddenPosts(viewerUsername string) func(*gorm.DB) *gorm.DB {
+	if viewerUsername == "" {
+		return func(tx *gorm.DB) *gorm.DB { return tx }
+	}
+	return hideHiddenPosts(db.DB.Model(&models.User{}).Select("id").Where("username = ?", viewerUsername))
+}
+
+// hideHiddenPosts builds the scope for a viewer ID list or subquery
+func hideHiddenPosts(viewerIDs interface{}) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("posts.id NOT IN (?)", db.DB.Model(&models.HiddenPost{}).Select("post_id").Where("user_id IN (?)", viewerIDs))
+	}
+}
+
+// MarkSavedPosts sets the saved flag on the posts the viewer has saved
+func MarkSavedPosts(viewerUsername string, posts []models.PostResponse) {
+	if viewerUsername == "" {
+		return
+	}
+	markSavedPosts(db.DB.Model(&models.User{}).Select("id").Where("username = ?", viewerUsername), posts)
+}
+
+// markSavedPosts sets the saved flag for a viewer ID list or subquery
+func markSavedPosts(viewerIDs interface{}, posts []models.PostResponse) {
+	if len(posts) == 0 {
+		return
+	}
+
+	postIDs := make([]uint, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+
+	var savedIDs []uint
+	if err := db.DB.Model(&models.SavedItem{}).
+		Where("user_id IN (?) AND content_type = ? AND content_id IN ?", viewerIDs, models.ContentTypePost, postIDs).
+		Pluck("content_id", &savedIDs).Error; err != nil {
+		// The flag is informational, so listings still load without it
+		return
+	}
+
+	saved := map[uint]bool{}
+	for _, id := range savedIDs {
+		saved[id] = true
+	}
+	for i := range posts {
+		posts[i].Saved = saved[posts[i].ID]
+	}
+}
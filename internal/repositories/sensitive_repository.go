@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// contentSettings is how a viewer wants NSFW posts and spoilers shown
+type contentSettings struct {
+	NSFWContent    string
+	SpoilerContent string
+}
+
+// viewerContentSettings loads a viewer's settings for sensitive content. Logged-out viewers get the defaults,
+// which hide NSFW posts and subs and blur spoilers.
+func viewerContentSettings(viewerUsername string) contentSettings {
+	if viewerUsername == "" {
+		return loadContentSettings(nil)
+	}
+	return loadContentSettings(db.DB.Model(&models.User{}).Select("id").Where("username = ?", viewerUsername))
+}
+
+// loadContentSettings loads the settings for a viewer ID list or subquery
+func loadContentSettings(viewerIDs interface{}) contentSettings {
+	settings := contentSettings{NSFWContent: models.ContentHide, SpoilerContent: models.ContentBlur}
+	if viewerIDs == nil {
+		return settings
+	}
+
+	var user models.User
+	if err := db.DB.Select("nsfw_content", "spoiler_content").Where("id IN (?)", viewerIDs).First(&user).Error; err != nil {
+		return settings
+	}
+	if user.NSFWContent != "" {
+		settings.NSFWContent = user.NSFWContent
+	}
+	if user.SpoilerContent != "" {
+		settings.SpoilerContent = user.SpoilerContent
+	}
+	return settings
+}
+
+// HideSensitivePosts is a query scope that drops NSFW posts, including posts in NSFW subs, and spoilers
+// from listings for viewers who chose to hide them. Crossposts also take the flags of the post they share.
+func HideSensitivePosts(viewerUsername string) func(*gorm.DB) *gorm.DB {
+	return hideSensitivePosts(viewerContentSettings(viewerUsername))
+}
+
+// hideSensitivePosts builds the scope for a viewer's settings
+func hideSensitivePosts(settings contentSettings) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if settings.NSFWContent == models.ContentHide {
+			nsfwSubs := func() *gorm.DB { return db.DB.Unscoped().Model(&models.Sub{}).Select("id").Where("nsfw = ?", true) }
+			tx = tx.Where("posts.nsfw = ? AND posts.sub_id NOT IN (?)", false, nsfwSubs()).
+				Where("(posts.crosspost_parent_id IS NULL OR posts.crosspost_parent_id NOT IN (?))",
+					db.DB.Table("posts AS parents").Select("parents.id").Where("parents.nsfw = ? OR parents.sub_id IN (?)", true, nsfwSubs()))
+		}
+		if settings.SpoilerContent == models.ContentHide {
+			tx = tx.Where("posts.spoiler = ?", false).
+				Where("(posts.crosspost_parent_id IS NULL OR posts.crosspost_parent_id NOT IN (?))",
+					db.DB.Table("posts AS parents").Select("parents.id").Where("parents.spoiler = ?", true))
+		}
+		return tx
+	}
+}
+
+// MarkSensitivePosts sets the NSFW and spoiler flags on a set of posts, and whether the viewer wants them blurred
+func MarkSensitivePosts(viewerUsername string, posts []models.PostResponse) {
+	markSensitivePosts(viewerContentSettings(viewerUsername), posts)
+}
+
+// markSensitivePosts marks posts for a viewer's settings. Posts the viewer hides from listings are blurred
+// where they still appear, such as behind a direct link. Crossposts are flagged whenever the post they share
+// or its sub is, including when the flag was set after the crosspost was made.
+func markSensitivePosts(settings contentSettings, posts []models.PostResponse) {
+	postIDs := []uint{}
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+
+	var flags []struct {
+		ID      uint
+		NSFW    bool
+		Spoiler bool
+	}
+	if err := db.DB.Table("posts").
+		Select("posts.id, posts.nsfw OR subs.nsfw OR COALESCE(parents.nsfw OR parent_subs.nsfw, false) AS nsfw, "+
+			"posts.spoiler OR COALESCE(parents.spoiler, false) AS spoiler").
+		Joins("JOIN subs ON subs.id = posts.sub_id").
+		Joins("LEFT JOIN posts AS parents ON parents.id = posts.crosspost_parent_id").
+		Joins("LEFT JOIN subs AS parent_subs ON parent_subs.id = parents.sub_id").
+		Where("posts.id IN ?", postIDs).Scan(&flags).Error; err != nil {
+		return
+	}
+
+	flagged := map[uint]int{}
+	for i, flag := range flags {
+		flagged[flag.ID] = i
+	}
+	for i := range posts {
+		index, ok := flagged[posts[i].ID]
+		if !ok || posts[i].Deleted {
+			continue
+		}
+		posts[i].NSFW, posts[i].Spoiler = flags[index].NSFW, flags[index].Spoiler
+		posts[i].Blurred = (posts[i].NSFW && settings.NSFWContent != models.ContentShow) ||
+			(posts[i].Spoiler && settings.SpoilerContent != models.ContentShow)
+	}
+}
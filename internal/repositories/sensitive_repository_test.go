@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSensitiveContent(t *testing.T) {
+	if !dbAvailable {
+		t.Skip("Database not available, skipping repository integration tests")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	author := models.User{Username: "sensitiveauthor", Password: "password"}
+	cautious := models.User{Username: "sensitivecautious", Password: "password", NSFWContent: models.ContentHide, SpoilerContent: models.ContentHide}
+	relaxed := models.User{Username: "sensitiverelaxed", Password: "password", NSFWContent: models.ContentShow, SpoilerContent: models.ContentShow}
+	database.DB.Create(&author)
+	database.DB.Create(&cautious)
+	database.DB.Create(&relaxed)
+
+	general := models.Sub{Name: "sensitivegeneral", OwnerID: author.ID}
+	adult := models.Sub{Name: "sensitiveadult", OwnerID: author.ID, NSFW: true}
+	database.DB.Create(&general)
+	database.DB.Create(&adult)
+
+	plain := models.Post{Title: "Plain", Content: "Text", SubID: general.ID, UserID: author.ID}
+	nsfw := models.Post{Title: "NSFW", Content: "Text", SubID: general.ID, UserID: author.ID, NSFW: true}
+	spoiler := models.Post{Title: "Spoiler", Content: "Text", SubID: general.ID, UserID: author.ID, Spoiler: true}
+	inAdult := models.Post{Title: "In NSFW sub", Content: "Text", SubID: adult.ID, UserID: author.ID}
+	for _, post := range []*models.Post{&plain, &nsfw, &spoiler, &inAdult} {
+		require.NoError(t, database.DB.Create(post).Error)
+	}
+
+	titles := func(posts *[]models.PostResponse) map[string]models.PostResponse {
+		byTitle := map[string]models.PostResponse{}
+		for _, post := range *posts {
+			byTitle[post.Title] = post
+		}
+		return byTitle
+	}
+
+	t.Run("logged-out viewers get NSFW content hidden and spoilers blurred", func(t *testing.T) {
+		posts, err := GetPosts("", "")
+		require.NoError(t, err)
+		listed := titles(posts)
+		assert.Len(t, listed, 2)
+		assert.False(t, listed["Plain"].Blurred)
+		assert.True(t, listed["Spoiler"].Spoiler)
+		assert.True(t, listed["Spoiler"].Blurred)
+
+		subs, err := GetSubs("")
+		require.NoError(t, err)
+		for _, sub := range *subs {
+			assert.NotEqual(t, adult.ID, sub.ID)
+		}
+
+		_, err = ListSubPosts(fmt.Sprint(adult.ID), "", "", "")
+		assert.EqualError(t, err, "this sub is marked NSFW")
+	})
+
+	t.Run("viewers can hide spoilers too", func(t *testing.T) {
+		posts, err := GetPosts(cautious.Username, "")
+		require.NoError(t, err)
+		listed := titles(posts)
+		assert.Len(t, listed, 1)
+		assert.Contains(t, listed, "Plain")
+	})
+
+	t.Run("viewers who show sensitive content see it unblurred", func(t *testing.T) {
+		posts, err := GetPosts(relaxed.Username, "")
+		require.NoError(t, err)
+		listed := titles(posts)
+		assert.Len(t, listed, 4)
+		assert.True(t, listed["NSFW"].NSFW)
+		assert.False(t, listed["NSFW"].Blurred)
+		// Posts in NSFW subs count as NSFW
+		assert.True(t, listed["In NSFW sub"].NSFW)
+
+		subPosts, err := ListSubPosts(fmt.Sprint(adult.ID), relaxed.Username, "", "")
+		require.NoError(t, err)
+		assert.Len(t, *subPosts, 1)
+	})
+
+	t.Run("crossposts follow flags set on the original later", func(t *testing.T) {
+		original := models.Post{Title: "Original", Content: "Text", SubID: adult.ID, UserID: author.ID}
+		require.NoError(t, database.DB.Create(&original).Error)
+		crosspost := models.Post{Title: "Shared", SubID: general.ID, UserID: author.ID, CrosspostParentID: &original.ID}
+		require.NoError(t, database.DB.Create(&crosspost).Error)
+
+		// The original's sub is NSFW, so the crosspost is too
+		posts, err := GetPosts("", "")
+		require.NoError(t, err)
+		assert.NotContains(t, titles(posts), "Shared")
+
+		database.DB.Model(&adult).Update("nsfw", false)
+		database.DB.Model(&original).Update("spoiler", true)
+		posts, err = GetPosts("", "")
+		require.NoError(t, err)
+		assert.True(t, titles(posts)["Shared"].Spoiler)
+		assert.False(t, titles(posts)["Shared"].NSFW)
+
+		posts, err = GetPosts(cautious.Username, "")
+		require.NoError(t, err)
+		assert.NotContains(t, titles(posts), "Shared")
+
+		database.DB.Model(&original).Update("nsfw", true)
+		posts, err = GetPosts(relaxed.Username, "")
+		require.NoError(t, err)
+		assert.True(t, titles(posts)["Shared"].NSFW)
+	})
+
+	t.Run("the public feed leaves out private subs", func(t *testing.T) {
+		secret := models.Sub{Name: "sensitivesecret", OwnerID: author.ID, Private: true}
+		require.NoError(t, database.DB.Create(&secret).Error)
+		require.NoError(t, database.DB.Create(&models.Post{Title: "Secret", Content: "Text", SubID: secret.ID, UserID: author.ID}).Error)
+
+		posts, err := GetPosts("", "")
+		require.NoError(t, err)
+		assert.NotContains(t, titles(posts), "Secret")
+		subs, err := GetSubs("")
+		require.NoError(t, err)
+		for _, sub := range *subs {
+			assert.NotEqual(t, secret.ID, sub.ID)
+		}
+
+		posts, err = GetPosts(relaxed.Username, "")
+		require.NoError(t, err)
+		assert.NotContains(t, titles(posts), "Secret")
+
+		posts, err = GetPosts(author.Username, "")
+		require.NoError(t, err)
+		assert.Contains(t, titles(posts), "Secret")
+	})
+}
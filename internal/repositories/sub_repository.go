@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
@@ -12,8 +13,8 @@ func GetSubs(username string) (*[]models.Sub, error) {
 	var subs []models.Sub
 
 	if username == "" {
-		// ✅ User is not authenticated → Return only public subs
-		if err := db.DB.Where("private = ?", false).Order("created_at DESC").Find(&subs).Error; err != nil {
+		// ✅ User is not authenticated → Return only public subs, leaving out NSFW subs
+		if err := db.DB.Where("private = ? AND nsfw = ?", false, false).Order("created_at DESC").Find(&subs).Error; err != nil {
 			return nil, fmt.Errorf("failed to fetch subs")
 		}
 	} else {
@@ -23,16 +24,18 @@ func GetSubs(username string) (*[]models.Sub, error) {
 			return nil, fmt.Errorf("user not found")
 		}
 
-		// ✅ Fetch public subs + private subs where user is the owner or a member
+		// ✅ Fetch public subs + private subs where user is the owner or a member, leaving out NSFW subs they don't own unless they show NSFW content
+		showNSFW := user.NSFWContent != "" && user.NSFWContent != models.ContentHide
 		if err := db.DB.Raw(`
 			SELECT DISTINCT subs.* 
 			FROM subs
 			LEFT JOIN sub_memberships ON subs.id = sub_memberships.sub_id
-			WHERE subs.private = false
+			WHERE (subs.private = false
 			OR subs.owner_id = ?
-			OR (sub_memberships.user_id = ? AND sub_memberships.sub_id IS NOT NULL)
+			OR (sub_memberships.user_id = ? AND sub_memberships.sub_id IS NOT NULL))
+			AND (? OR subs.nsfw = false OR subs.owner_id = ?)
 			ORDER BY subs.created_at DESC
-		`, user.ID, user.ID).Scan(&subs).Error; err != nil {
+		`, user.ID, user.ID, showNSFW, user.ID).Scan(&subs).Error; err != nil {
 			return nil, fmt.Errorf("failed to fetch subs")
 		}
 	}
@@ -47,9 +50,9 @@ func CreateSub(username string, subRequest models.SubRequest) (*models.Sub, erro
 		return nil, fmt.Errorf("user not found")
 	}
 
-	// Ensure the sub name is unique
+	// Ensure the sub name is unique, ignoring case
 	var existingSub models.Sub
-	if err := db.DB.Where("name = ?", subRequest.Name).First(&existingSub).Error; err == nil {
+	if err := db.DB.Where("LOWER(name) = LOWER(?)", subRequest.Name).First(&existingSub).Error; err == nil {
 		return nil, fmt.Errorf("sub name already taken")
 	}
 
@@ -67,6 +70,15 @@ func CreateSub(username string, subRequest models.SubRequest) (*models.Sub, erro
 	return &newSub, nil
 }
 
+// GetSubByName looks up a sub by name, ignoring case
+func GetSubByName(name string) (*models.Sub, error) {
+	var sub models.Sub
+	if err := db.DB.Preload("Owner").Where("LOWER(name) = LOWER(?)", name).First(&sub).Error; err != nil {
+		return nil, fmt.Errorf("sub not found")
+	}
+	return &sub, nil
+}
+
 func JoinSub(username, subID string) (*models.SubMembership, error) {
 	// Fetch user ID
 	var user models.User
@@ -83,12 +95,15 @@ func JoinSub(username, subID string) (*models.SubMembership, error) {
 	// ✅ If the sub is private, check for an invitation
 	if sub.Private {
 		var invitation models.SubInvitation
-		if err := db.DB.Where("sub_id = ? AND invitee_id = ? AND status = ?", sub.ID, user.ID, "pending").First(&invitation).Error; err != nil {
+		if err := db.DB.Where("sub_id = ? AND invitee_id = ? AND status = ?", sub.ID, user.ID, models.InviteStatusPending).
+			Where("expires_at IS NULL OR expires_at > ?", time.Now()).First(&invitation).Error; err != nil {
 			return nil, fmt.Errorf("you need an invitation to join this private sub")
 		}
 
 		// ✅ Mark the invitation as accepted
-		invitation.Status = "accepted"
+		now := time.Now()
+		invitation.Status = models.InviteStatusAccepted
+		invitation.RespondedAt = &now
 		db.DB.Save(&invitation)
 	}
 
@@ -133,19 +148,29 @@ func InviteUser(subID, username string, inviteRequest models.InviteRequest) erro
 		return fmt.Errorf("invitee user not found")
 	}
 
-	// ✅ Check if an invitation already exists
+	// ✅ Check if the invitee is already a member
+	var memberCount int64
+	db.DB.Model(&models.SubMembership{}).Where("sub_id = ? AND user_id = ?", sub.ID, invitee.ID).Count(&memberCount)
+	if memberCount > 0 {
+		return fmt.Errorf("user is already a member")
+	}
+
+	// ✅ Check if a live invitation already exists (declined, revoked or expired ones can be re-sent)
 	var existingInvite models.SubInvitation
-	if err := db.DB.Where("sub_id = ? AND invitee_id = ?", sub.ID, invitee.ID).First(&existingInvite).Error; err == nil {
+	if err := db.DB.Where("sub_id = ? AND invitee_id = ? AND status = ?", sub.ID, invitee.ID, models.InviteStatusPending).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).First(&existingInvite).Error; err == nil {
 		return fmt.Errorf("user is already invited")
 	}
 
 	// ✅ Create invitation
+	expiresAt := time.Now().Add(InviteExpiry())
 	newInvite := models.SubInvitation{
 		SubID:     sub.ID,
 		InviterID: inviter.ID,
 		InviteeID: invitee.ID,
-		Status:    "pending",
+		Status:    models.InviteStatusPending,
 		CreatedAt: time.Now(),
+		ExpiresAt: &expiresAt,
 	}
 	db.DB.Create(&newInvite)
 
@@ -170,8 +195,18 @@ func AcceptInvite(inviteID, username string) error {
 		return fmt.Errorf("you are not the invitee for this invitation")
 	}
 
+	// ✅ Only pending, unexpired invitations can be accepted
+	if invitation.Status != models.InviteStatusPending {
+		return fmt.Errorf("invitation is no longer pending")
+	}
+	if invitation.IsExpired() {
+		return fmt.Errorf("invitation has expired")
+	}
+
 	// ✅ Accept invitation
-	invitation.Status = "accepted"
+	now := time.Now()
+	invitation.Status = models.InviteStatusAccepted
+	invitation.RespondedAt = &now
 	db.DB.Save(&invitation)
 
 	// ✅ Add user to sub_memberships
@@ -185,7 +220,9 @@ func AcceptInvite(inviteID, username string) error {
 	return nil
 }
 
-func ListSubPosts(subID, username string) (*[]models.PostResponse, error) {
+// ListSubPosts lists a sub's posts; flair optionally filters by post flair ID or (case-insensitive) flair text,
+// and domain to link posts to that domain
+func ListSubPosts(subID, username, flair, domain string) (*[]models.PostResponse, error) {
 	// Check if the sub exists
 	var sub models.Sub
 	if err := db.DB.First(&sub, subID).Error; err != nil {
@@ -210,32 +247,34 @@ func ListSubPosts(subID, username string) (*[]models.PostResponse, error) {
 		}
 	}
 
-	// Fetch posts from the sub
+	// NSFW subs are closed to viewers who hide NSFW content, including logged-out viewers
+	settings := viewerContentSettings(username)
+	if sub.NSFW && settings.NSFWContent == models.ContentHide {
+		return nil, fmt.Errorf("this sub is marked NSFW")
+	}
+
+	// Fetch published posts from the sub, leaving out posts by users the viewer blocked, posts they hid and sensitive posts they chose not to see
+	query := db.DB.Preload("User").Where("sub_id = ?", subID).
+		Scopes(PublishedPosts, HideBlockedAuthors(username), HideHiddenPosts(username), hideSensitivePosts(settings), FilterByDomain(domain))
+	if flair != "" {
+		if flairID, err := strconv.ParseUint(flair, 10, 64); err == nil {
+			query = query.Where("flair_id = ?", flairID)
+		} else {
+			query = query.Where("flair_id IN (?)", db.DB.Model(&models.SubFlair{}).Select("id").
+				Where("sub_id = ? AND type = ? AND LOWER(text) = LOWER(?)", sub.ID, models.FlairTypePost, flair))
+		}
+	}
+
 	var posts []models.Post
-	if err := db.DB.Preload("User").Where("sub_id = ?", subID).Order("created_at DESC").Find(&posts).Error; err != nil {
+	// Pinned posts are listed first, in the order they were pinned
+	if err := query.Order("pinned DESC, pinned_at ASC, created_at DESC").Find(&posts).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch posts")
 	}
 
-	// Convert posts to response format
-	var formattedPosts []models.PostResponse
-	for _, post := range posts {
-		var upvotes, downvotes int64
-
-		// Count upvotes and downvotes
-		db.DB.Model(&models.Vote{}).Where("post_id = ? AND vote = 1", post.ID).Count(&upvotes)
-		db.DB.Model(&models.Vote{}).Where("post_id = ? AND vote = -1", post.ID).Count(&downvotes)
-
-		formattedPosts = append(formattedPosts, models.PostResponse{
-			ID:        post.ID,
-			Title:     post.Title,
-			Content:   post.Content,
-			Username:  post.User.Username,
-			Upvotes:   int(upvotes),
-			Downvotes: int(downvotes),
-			SubID:     post.SubID,
-			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
-		})
-	}
+	formattedPosts := formatPosts(posts)
+	markSensitivePosts(settings, formattedPosts)
+	MarkSavedPosts(username, formattedPosts)
+	MarkPollVotes(username, formattedPosts)
 
 	return &formattedPosts, nil
 }
@@ -295,7 +334,7 @@ func GetPostCountPerSub(subID, username string) (int, error) {
 
 	// Fetch posts from the sub
 	var posts []models.Post
-	if err := db.DB.Preload("User").Where("sub_id = ?", subID).Order("created_at DESC").Find(&posts).Error; err != nil {
+	if err := db.DB.Preload("User").Where("sub_id = ?", subID).Scopes(PublishedPosts).Order("created_at DESC").Find(&posts).Error; err != nil {
 		return -1, fmt.Errorf("failed to fetch posts")
 	}
 
@@ -340,7 +379,7 @@ func DeleteSub(subID, ownerID uint) error {
 		return fmt.Errorf("only the sub owner can delete the sub")
 	}
 
-	// Delete the sub (cascade delete will handle related records)
+	// Soft-delete the sub; its posts and community data are removed by the purge job after the retention period
 	if err := db.DB.Delete(&sub).Error; err != nil {
 		return fmt.Errorf("failed to delete sub")
 	}
@@ -378,12 +417,25 @@ func GetSubMembers(subID string, userID uint, isOwner bool) ([]models.SubMemberR
 		return nil, fmt.Errorf("failed to fetch sub members")
 	}
 
+	var flairIDs []uint
+	for _, membership := range memberships {
+		if membership.FlairID != nil {
+			flairIDs = append(flairIDs, *membership.FlairID)
+		}
+	}
+	flairs := GetFlairResponses(flairIDs)
+
 	// Convert to response format
 	var memberResponses []models.SubMemberResponse
 	for _, membership := range memberships {
+		// Deleted accounts aren't preloaded and drop out of the member list
+		if membership.User.ID == 0 {
+			continue
+		}
 		memberResponses = append(memberResponses, models.SubMemberResponse{
 			Username: membership.User.Username,
 			JoinedAt: membership.JoinedAt.Format("2006-01-02 15:04:05"),
+			Flair:    flairForResponse(membership.FlairID, flairs),
 		})
 	}
 
@@ -402,19 +454,25 @@ func GetPendingInvites(subID string, ownerID uint) ([]models.InviteResponse, err
 		return nil, fmt.Errorf("only the sub owner can view pending invites")
 	}
 
-	// Get all pending invites for this sub
+	// Get all pending, unexpired invites for this sub
 	var invites []models.SubInvitation
-	if err := db.DB.Preload("Invitee").Where("sub_id = ? AND status = ?", subID, "pending").Order("created_at ASC").Find(&invites).Error; err != nil {
+	if err := db.DB.Preload("Invitee").Where("sub_id = ? AND status = ?", subID, models.InviteStatusPending).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).Order("created_at ASC").Find(&invites).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch pending invites")
 	}
 
 	// Convert to response format
 	var inviteResponses []models.InviteResponse
 	for _, invite := range invites {
-		inviteResponses = append(inviteResponses, models.InviteResponse{
+		response := models.InviteResponse{
+			ID:              invite.ID,
 			InviteeUsername: invite.Invitee.Username,
 			CreatedAt:       invite.CreatedAt.Format("2006-01-02 15:04:05"),
-		})
+		}
+		if invite.ExpiresAt != nil {
+			response.ExpiresAt = invite.ExpiresAt.Format("2006-01-02 15:04:05")
+		}
+		inviteResponses = append(inviteResponses, response)
 	}
 
 	return inviteResponses, nil
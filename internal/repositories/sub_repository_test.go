@@ -225,7 +225,7 @@ func TestListSubPosts(t *testing.T) {
 	database.DB.Create(&publicPost)
 
 	t.Run("list posts from public sub", func(t *testing.T) {
-		posts, err := ListSubPosts(fmt.Sprintf("%d", publicSub.ID), "")
+		posts, err := ListSubPosts(fmt.Sprintf("%d", publicSub.ID), "", "", "")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, posts)
@@ -237,7 +237,7 @@ func TestListSubPosts(t *testing.T) {
 	})
 
 	t.Run("list posts from private sub without membership", func(t *testing.T) {
-		posts, err := ListSubPosts(fmt.Sprintf("%d", privateSub.ID), "")
+		posts, err := ListSubPosts(fmt.Sprintf("%d", privateSub.ID), "", "", "")
 
 		assert.Error(t, err)
 		assert.Nil(t, posts)
@@ -245,7 +245,7 @@ func TestListSubPosts(t *testing.T) {
 	})
 
 	t.Run("list posts from non-existent sub", func(t *testing.T) {
-		posts, err := ListSubPosts("999", "")
+		posts, err := ListSubPosts("999", "", "", "")
 
 		assert.Error(t, err)
 		assert.Nil(t, posts)
@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ISubSettingsRepository defines methods for sub settings and posting restrictions
+type ISubSettingsRepository interface {
+	GetSubByID(subID uint) (*models.Sub, error)
+	GetRules(subID uint) ([]models.SubRule, error)
+	UpdateSettings(sub *models.Sub, rules *[]models.SubRule) error
+	IsMember(subID, userID uint) (bool, error)
+	IsApprovedSubmitter(subID, userID uint) (bool, error)
+	GetApprovedSubmitters(subID uint) ([]models.SubApprovedSubmitter, error)
+	AddApprovedSubmitter(subID, userID uint) error
+	RemoveApprovedSubmitter(subID, userID uint) error
+	IsModerator(subID, userID uint) (bool, error)
+	GetModerators(subID uint) ([]models.SubModerator, error)
+	AddModerator(subID, userID uint) error
+	RemoveModerator(subID, userID uint) error
+}
+
+// SubSettingsRepository implements ISubSettingsRepository
+type SubSettingsRepository struct{}
+
+// NewSubSettingsRepository creates a new sub settings repository
+func NewSubSettingsRepository() ISubSettingsRepository {
+	return &SubSettingsRepository{}
+}
+
+func (r *SubSettingsRepository) GetSubByID(subID uint) (*models.Sub, error) {
+	var sub models.Sub
+	if err := db.DB.First(&sub, subID).Error; err != nil {
+		return nil, fmt.Errorf("sub not found")
+	}
+	return &sub, nil
+}
+
+func (r *SubSettingsRepository) GetRules(subID uint) ([]models.SubRule, error) {
+	var rules []models.SubRule
+	if err := db.DB.Where("sub_id = ?", subID).Order("position ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch sub rules")
+	}
+	return rules, nil
+}
+
+// UpdateSettings saves the sub's settings columns and, when rules is non-nil,
+// replaces the sub's rule list in the same transaction
+func (r *SubSettingsRepository) UpdateSettings(sub *models.Sub, rules *[]models.SubRule) error {
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(sub).Select("Sidebar", "AllowedPostTypes", "MinAccountAgeDays", "MinKarma", "RestrictedPosting", "NSFW", "JoinMode", "ArchiveAfterDays").Updates(sub).Error; err != nil {
+			return err
+		}
+
+		if rules == nil {
+			return nil
+		}
+
+		if err := tx.Where("sub_id = ?", sub.ID).Delete(&models.SubRule{}).Error; err != nil {
+			return err
+		}
+
+		for i := range *rules {
+			(*rules)[i].SubID = sub.ID
+			(*rules)[i].Position = i + 1
+		}
+
+		if len(*rules) > 0 {
+			if err := tx.Create(rules).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update sub settings")
+	}
+
+	return nil
+}
+
+func (r *SubSettingsRepository) IsMember(subID, userID uint) (bool, error) {
+	var count int64
+	if err := db.DB.Model(&models.SubMembership{}).Where("sub_id = ? AND user_id = ?", subID, userID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check membership")
+	}
+	return count > 0, nil
+}
+
+func (r *SubSettingsRepository) IsApprovedSubmitter(subID, userID uint) (bool, error) {
+	var count int64
+	if err := db.DB.Model(&models.SubApprovedSubmitter{}).Where("sub_id = ? AND user_id = ?", subID, userID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check approved submitters")
+	}
+	return count > 0, nil
+}
+
+func (r *SubSettingsRepository) GetApprovedSubmitters(subID uint) ([]models.SubApprovedSubmitter, error) {
+	var submitters []models.SubApprovedSubmitter
+	if err := db.DB.Preload("User").Where("sub_id = ?", subID).Order("created_at ASC").Find(&submitters).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch approved submitters")
+	}
+	return submitters, nil
+}
+
+func (r *SubSettingsRepository) AddApprovedSubmitter(subID, userID uint) error {
+	approved, err := r.IsApprovedSubmitter(subID, userID)
+	if err != nil {
+		return err
+	}
+	if approved {
+		return fmt.Errorf("user is already an approved submitter")
+	}
+
+	submitter := models.SubApprovedSubmitter{
+		SubID:     subID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+	if err := db.DB.Create(&submitter).Error; err != nil {
+		return fmt.Errorf("failed to add approved submitter")
+	}
+
+	return nil
+}
+
+func (r *SubSettingsRepository) RemoveApprovedSubmitter(subID, userID uint) error {
+	result := db.DB.Where("sub_id = ? AND user_id = ?", subID, userID).Delete(&models.SubApprovedSubmitter{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove approved submitter")
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user is not an approved submitter")
+	}
+
+	return nil
+}
+
+func (r *SubSettingsRepository) IsModerator(subID, userID uint) (bool, error) {
+	var count int64
+	if err := db.DB.Model(&models.SubModerator{}).Where("sub_id = ? AND user_id = ?", subID, userID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check moderators")
+	}
+	return count > 0, nil
+}
+
+func (r *SubSettingsRepository) GetModerators(subID uint) ([]models.SubModerator, error) {
+	var moderators []models.SubModerator
+	if err := db.DB.Preload("User").Where("sub_id = ?", subID).Order("created_at ASC").Find(&moderators).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch moderators")
+	}
+	return moderators, nil
+}
+
+func (r *SubSettingsRepository) AddModerator(subID, userID uint) error {
+	isModerator, err := r.IsModerator(subID, userID)
+	if err != nil {
+		return err
+	}
+	if isModerator {
+		return fmt.Errorf("user is already a moderator")
+	}
+
+	moderator := models.SubModerator{
+		SubID:     subID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}
+	if err := db.DB.Create(&moderator).Error; err != nil {
+		return fmt.Errorf("failed to add moderator")
+	}
+
+	return nil
+}
+
+func (r *SubSettingsRepository) RemoveModerator(subID, userID uint) error {
+	result := db.DB.Where("sub_id = ? AND user_id = ?", subID, userID).Delete(&models.SubModerator{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove moderator")
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user is not a moderator")
+	}
+
+	return nil
+}
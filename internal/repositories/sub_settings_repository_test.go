@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubSettingsRepository_UpdateSettings(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping repository integration tests")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_rules")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "rulesowner", Password: "password"}
+	database.DB.Create(&owner)
+
+	sub := models.Sub{Name: "rulessub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	repo := NewSubSettingsRepository()
+
+	t.Run("defaults allow every post type", func(t *testing.T) {
+		stored, err := repo.GetSubByID(sub.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "text,image,link,poll", stored.AllowedPostTypes)
+	})
+
+	t.Run("save settings and ordered rules", func(t *testing.T) {
+		sub.Sidebar = "sidebar"
+		sub.RestrictedPosting = true
+		rules := []models.SubRule{{Title: "One"}, {Title: "Two"}}
+
+		err := repo.UpdateSettings(&sub, &rules)
+		assert.NoError(t, err)
+
+		stored, _ := repo.GetSubByID(sub.ID)
+		assert.Equal(t, "sidebar", stored.Sidebar)
+		assert.True(t, stored.RestrictedPosting)
+
+		storedRules, err := repo.GetRules(sub.ID)
+		assert.NoError(t, err)
+		assert.Len(t, storedRules, 2)
+		assert.Equal(t, "One", storedRules[0].Title)
+		assert.Equal(t, 2, storedRules[1].Position)
+	})
+
+	t.Run("nil rules keep the existing list", func(t *testing.T) {
+		sub.RestrictedPosting = false
+		err := repo.UpdateSettings(&sub, nil)
+		assert.NoError(t, err)
+
+		storedRules, _ := repo.GetRules(sub.ID)
+		assert.Len(t, storedRules, 2)
+
+		stored, _ := repo.GetSubByID(sub.ID)
+		assert.False(t, stored.RestrictedPosting)
+	})
+}
+
+func TestSubSettingsRepository_ApprovedSubmitters(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping repository integration tests")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_approved_submitters")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "approvalowner", Password: "password"}
+	submitter := models.User{Username: "approvalsubmitter", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&submitter)
+
+	sub := models.Sub{Name: "approvalsub", OwnerID: owner.ID, RestrictedPosting: true}
+	database.DB.Create(&sub)
+
+	repo := NewSubSettingsRepository()
+
+	assert.NoError(t, repo.AddApprovedSubmitter(sub.ID, submitter.ID))
+	assert.EqualError(t, repo.AddApprovedSubmitter(sub.ID, submitter.ID), "user is already an approved submitter")
+
+	approved, err := repo.IsApprovedSubmitter(sub.ID, submitter.ID)
+	assert.NoError(t, err)
+	assert.True(t, approved)
+
+	submitters, err := repo.GetApprovedSubmitters(sub.ID)
+	assert.NoError(t, err)
+	assert.Len(t, submitters, 1)
+	assert.Equal(t, "approvalsubmitter", submitters[0].User.Username)
+
+	assert.NoError(t, repo.RemoveApprovedSubmitter(sub.ID, submitter.ID))
+	assert.EqualError(t, repo.RemoveApprovedSubmitter(sub.ID, submitter.ID), "user is not an approved submitter")
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ISubTransferRepository defines methods for sub ownership transfers
+type ISubTransferRepository interface {
+	CreateTransfer(transfer *models.SubOwnershipTransfer) error
+	GetTransferByID(transferID uint) (*models.SubOwnershipTransfer, error)
+	GetPendingTransferForSub(subID uint) (*models.SubOwnershipTransfer, error)
+	GetPendingTransfersForUser(userID uint) ([]models.TransferResponse, error)
+	UpdateTransferStatus(transfer *models.SubOwnershipTransfer, status string) error
+	CompleteTransfer(transfer *models.SubOwnershipTransfer) error
+}
+
+// SubTransferRepository implements ISubTransferRepository
+type SubTransferRepository struct{}
+
+// NewSubTransferRepository creates a new sub transfer repository
+func NewSubTransferRepository() ISubTransferRepository {
+	return &SubTransferRepository{}
+}
+
+func (r *SubTransferRepository) CreateTransfer(transfer *models.SubOwnershipTransfer) error {
+	transfer.Status = models.TransferStatusPending
+	transfer.CreatedAt = time.Now()
+	if err := db.DB.Create(transfer).Error; err != nil {
+		return fmt.Errorf("failed to create ownership transfer")
+	}
+	return nil
+}
+
+func (r *SubTransferRepository) GetTransferByID(transferID uint) (*models.SubOwnershipTransfer, error) {
+	var transfer models.SubOwnershipTransfer
+	if err := db.DB.First(&transfer, transferID).Error; err != nil {
+		return nil, fmt.Errorf("ownership transfer not found")
+	}
+	return &transfer, nil
+}
+
+func (r *SubTransferRepository) GetPendingTransferForSub(subID uint) (*models.SubOwnershipTransfer, error) {
+	var transfer models.SubOwnershipTransfer
+	if err := db.DB.Where("sub_id = ? AND status = ?", subID, models.TransferStatusPending).First(&transfer).Error; err != nil {
+		return nil, fmt.Errorf("ownership transfer not found")
+	}
+	return &transfer, nil
+}
+
+func (r *SubTransferRepository) GetPendingTransfersForUser(userID uint) ([]models.TransferResponse, error) {
+	var rows []struct {
+		ID           uint
+		SubID        uint
+		SubName      string
+		FromUsername string
+		Status       string
+		CreatedAt    time.Time
+	}
+
+	err := db.DB.Table("sub_ownership_transfers").
+		Select("sub_ownership_transfers.id, sub_ownership_transfers.sub_id, subs.name AS sub_name, users.username AS from_username, sub_ownership_transfers.status, sub_ownership_transfers.created_at").
+		Joins("JOIN subs ON subs.id = sub_ownership_transfers.sub_id").
+		Joins("JOIN users ON users.id = sub_ownership_transfers.from_user_id").
+		Where("sub_ownership_transfers.to_user_id = ? AND sub_ownership_transfers.status = ?", userID, models.TransferStatusPending).
+		Order("sub_ownership_transfers.created_at DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ownership transfers")
+	}
+
+	transfers := []models.TransferResponse{}
+	for _, row := range rows {
+		transfers = append(transfers, models.TransferResponse{
+			ID:           row.ID,
+			SubID:        row.SubID,
+			SubName:      row.SubName,
+			FromUsername: row.FromUsername,
+			Status:       row.Status,
+			CreatedAt:    row.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return transfers, nil
+}
+
+func (r *SubTransferRepository) UpdateTransferStatus(transfer *models.SubOwnershipTransfer, status string) error {
+	now := time.Now()
+	transfer.Status = status
+	transfer.RespondedAt = &now
+
+	if err := db.DB.Save(transfer).Error; err != nil {
+		return fmt.Errorf("failed to update ownership transfer")
+	}
+	return nil
+}
+
+// CompleteTransfer hands the sub to the recipient in a single transaction. The previous owner
+// stays on as a member and moderator; the new owner no longer needs a separate moderator entry.
+func (r *SubTransferRepository) CompleteTransfer(transfer *models.SubOwnershipTransfer) error {
+	now := time.Now()
+
+	err := db.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Sub{}).Where("id = ? AND owner_id = ?", transfer.SubID, transfer.FromUserID).Update("owner_id", transfer.ToUserID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("the sub owner has changed since this transfer was offered")
+		}
+
+		if err := tx.Model(transfer).Updates(map[string]interface{}{"status": models.TransferStatusAccepted, "responded_at": now}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("sub_id = ? AND user_id = ?", transfer.SubID, transfer.ToUserID).Delete(&models.SubModerator{}).Error; err != nil {
+			return err
+		}
+
+		moderator := models.SubModerator{SubID: transfer.SubID, UserID: transfer.FromUserID}
+		if err := tx.Where(moderator).Attrs(models.SubModerator{CreatedAt: now}).FirstOrCreate(&moderator).Error; err != nil {
+			return err
+		}
+
+		for _, userID := range []uint{transfer.FromUserID, transfer.ToUserID} {
+			membership := models.SubMembership{SubID: transfer.SubID, UserID: userID}
+			if err := tx.Where(membership).Attrs(models.SubMembership{JoinedAt: now}).FirstOrCreate(&membership).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if err.Error() == "the sub owner has changed since this transfer was offered" {
+			return err
+		}
+		return fmt.Errorf("failed to transfer sub ownership")
+	}
+
+	transfer.Status = models.TransferStatusAccepted
+	transfer.RespondedAt = &now
+	return nil
+}
@@ -45,7 +45,7 @@ func UpdateUser(id uint, updates models.UserUpdateRequest) (*models.User, error)
 	return repo.UpdateUser(id, updates)
 }
 
-// DeleteUser permanently deletes a user account
+// DeleteUser soft-deletes a user account
 func DeleteUser(id uint) error {
 	repo := NewUserRepository()
 	return repo.DeleteUser(id)
@@ -89,10 +89,17 @@ func (r *UserRepository) UpdateUser(id uint, updates models.UserUpdateRequest) (
 	}
 	if updates.AvatarURL != nil {
 		user.AvatarURL = updates.AvatarURL
+		user.AvatarMediaID = updates.AvatarMediaID
 	}
 	if updates.IsPrivate != nil {
 		user.IsPrivate = *updates.IsPrivate
 	}
+	if updates.NSFWContent != nil {
+		user.NSFWContent = *updates.NSFWContent
+	}
+	if updates.SpoilerContent != nil {
+		user.SpoilerContent = *updates.SpoilerContent
+	}
 
 	// Update the timestamp
 	user.UpdatedAt = time.Now()
@@ -113,7 +120,10 @@ func (r *UserRepository) DeleteUser(id uint) error {
 		return err
 	}
 
-	// Delete the user (cascade delete will handle related records)
+	// Sign the user out everywhere before soft-deleting the account; it is anonymized after the retention period
+	if err := db.DB.Model(&models.User{}).Where("id = ?", id).Update("refresh_token", nil).Error; err != nil {
+		return fmt.Errorf("failed to delete user: %v", err)
+	}
 	if err := db.DB.Delete(&models.User{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete user: %v", err)
 	}
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"github.com/CodeAndCraft-Online/cortex-api/internal/handlers"
+	middleware "github.com/CodeAndCraft-Online/cortex-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterAdminRoutes sets up routes for site administration
+func RegisterAdminRoutes(router *gin.RouterGroup) {
+	adminRoutes := router.Group("/admin")
+	adminRoutes.Use(middleware.AuthMiddleware())
+	{
+		adminRoutes.GET("/deleted/:type", handlers.GetDeletedContent)
+		adminRoutes.POST("/deleted/:type/:id/restore", handlers.RestoreDeletedContent)
+	}
+}
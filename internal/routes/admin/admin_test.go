@@ -0,0 +1,19 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterAdminRoutes(t *testing.T) {
+	router := gin.New()
+	api := router.Group("/api")
+
+	assert.NotPanics(t, func() {
+		RegisterAdminRoutes(api)
+	})
+
+	assert.NotNil(t, api)
+}
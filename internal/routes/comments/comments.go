@@ -15,6 +15,14 @@ func RegisterCommentsRoutes(router *gin.RouterGroup) {
 		comments.PUT("/:id", handlers.UpdateComment)    // Update comment (author only, handled in handler)
 		comments.DELETE("/:id", handlers.DeleteComment) // Delete comment (author only, handled in handler)
 
+		// Edit history (author and sub moderators only)
+		comments.GET("/:id/revisions", handlers.GetCommentRevisions)
+		comments.GET("/:id/revisions/diff", handlers.GetCommentRevisionDiff)
+
+		// Saving comments for later
+		comments.POST("/:id/save", handlers.SaveComment)
+		comments.DELETE("/:id/save", handlers.UnsaveComment)
+
 		// Legacy routes (keep for backward compatibility)
 		comments.POST("/comments", handlers.CreateComment) // Create comment via post endpoint
 	}
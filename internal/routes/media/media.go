@@ -0,0 +1,22 @@
+package media
+
+import (
+	"github.com/CodeAndCraft-Online/cortex-api/internal/handlers"
+	middleware "github.com/CodeAndCraft-Online/cortex-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterMediaRoutes sets up routes for uploading and serving media
+func RegisterMediaRoutes(router *gin.RouterGroup) {
+	// Files are public so they can be embedded wherever the posts and profiles using them are shown
+	mediaRoutes := router.Group("/media")
+	mediaRoutes.GET("/:id", handlers.GetMedia)
+	mediaRoutes.GET("/:id/file", handlers.GetMediaFile)
+	mediaRoutes.GET("/:id/renditions/:name", handlers.GetMediaRendition)
+
+	protectedMediaRoutes := router.Group("/media")
+	protectedMediaRoutes.Use(middleware.AuthMiddleware())
+	{
+		protectedMediaRoutes.POST("", handlers.UploadMedia)
+	}
+}
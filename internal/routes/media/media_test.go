@@ -0,0 +1,19 @@
+package media
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterMediaRoutes(t *testing.T) {
+	router := gin.New()
+	api := router.Group("/api")
+
+	assert.NotPanics(t, func() {
+		RegisterMediaRoutes(api)
+	})
+
+	assert.NotNil(t, api)
+}
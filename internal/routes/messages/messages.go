@@ -0,0 +1,25 @@
+package messages
+
+import (
+	"github.com/CodeAndCraft-Online/cortex-api/internal/handlers"
+	middleware "github.com/CodeAndCraft-Online/cortex-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterMessageRoutes sets up routes for private messages and modmail
+func RegisterMessageRoutes(router *gin.RouterGroup) {
+	messageRoutes := router.Group("/messages")
+	messageRoutes.Use(middleware.AuthMiddleware())
+	{
+		messageRoutes.GET("", handlers.GetInbox)
+		messageRoutes.POST("", handlers.StartConversation)
+		messageRoutes.GET("/unread", handlers.GetUnreadMessageCount)
+		messageRoutes.GET("/:conversationID", handlers.GetConversation)
+		messageRoutes.POST("/:conversationID", handlers.ReplyToConversation)
+		messageRoutes.POST("/:conversationID/read", handlers.MarkConversationRead)
+
+		// Modmail addressed to a sub's moderators
+		messageRoutes.GET("/modmail/:subID", handlers.GetModmail)
+		messageRoutes.POST("/modmail/:subID", handlers.StartModmail)
+	}
+}
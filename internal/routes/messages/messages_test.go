@@ -0,0 +1,19 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterMessageRoutes(t *testing.T) {
+	router := gin.New()
+	api := router.Group("/api")
+
+	assert.NotPanics(t, func() {
+		RegisterMessageRoutes(api)
+	})
+
+	assert.NotNil(t, api)
+}
@@ -0,0 +1,21 @@
+package notifications
+
+import (
+	"github.com/CodeAndCraft-Online/cortex-api/internal/handlers"
+	middleware "github.com/CodeAndCraft-Online/cortex-api/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterNotificationRoutes sets up routes for the notification inbox and preferences
+func RegisterNotificationRoutes(router *gin.RouterGroup) {
+	notificationRoutes := router.Group("/notifications")
+	notificationRoutes.Use(middleware.AuthMiddleware())
+	{
+		notificationRoutes.GET("", handlers.GetNotifications)
+		notificationRoutes.POST("/read-all", handlers.MarkAllNotificationsRead)
+		notificationRoutes.POST("/:notificationID/read", handlers.MarkNotificationRead)
+		notificationRoutes.POST("/:notificationID/unread", handlers.MarkNotificationUnread)
+		notificationRoutes.GET("/preferences", handlers.GetNotificationPreferences)
+		notificationRoutes.PUT("/preferences", handlers.UpdateNotificationPreferences)
+	}
+}
@@ -0,0 +1,19 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterNotificationRoutes(t *testing.T) {
+	router := gin.New()
+	api := router.Group("/api")
+
+	assert.NotPanics(t, func() {
+		RegisterNotificationRoutes(api)
+	})
+
+	assert.NotNil(t, api)
+}
@@ -8,15 +8,33 @@ import (
 
 // RegisterPostRoutes sets up routes for posts
 func RegisterPostRoutes(router *gin.RouterGroup) {
+	// The front page feed is public; a token adds the viewer's private subs and content settings
+	router.GET("/feed/all", middleware.OptionalAuthMiddleware(), handlers.GetPosts)
+
 	posts := router.Group("/posts")
 	posts.Use(middleware.AuthMiddleware())
 	{
 		posts.GET("/:id", handlers.GetPostByID)
+		posts.PUT("/:id/flair", handlers.SetPostFlair)
+		posts.POST("/:id/pin", handlers.PinPost)
+		posts.DELETE("/:id/pin", handlers.UnpinPost)
+		posts.POST("/:id/lock", handlers.LockPost)
+		posts.DELETE("/:id/lock", handlers.UnlockPost)
+		posts.PUT("/:id/flags", handlers.SetPostFlags)
+		posts.POST("/:id/poll/vote", handlers.VotePoll)
+		posts.POST("/:id/poll/close", handlers.ClosePoll)
+		posts.POST("/:id/crosspost", handlers.CrosspostPost)
 		posts.POST("/", handlers.CreatePost)
 		posts.GET("/", handlers.GetPosts)
 		posts.POST("/posts/:postID", handlers.GetPostByID)
 		posts.GET("/posts/:postID/comments", handlers.GetCommentsByPostID)
-		// posts.PUT("/:id", handlers.UpdatePost)
-		// posts.DELETE("/:id", handlers.DeletePost)
+		posts.PUT("/:id", handlers.UpdatePost)
+		posts.GET("/:id/revisions", handlers.GetPostRevisions)
+		posts.GET("/:id/revisions/diff", handlers.GetPostRevisionDiff)
+		posts.POST("/:id/save", handlers.SavePost)
+		posts.DELETE("/:id/save", handlers.UnsavePost)
+		posts.POST("/:id/hide", handlers.HidePost)
+		posts.DELETE("/:id/hide", handlers.UnhidePost)
+		posts.DELETE("/:id", handlers.DeletePost)
 	}
 }
@@ -1,8 +1,12 @@
 package routes
 
 import (
+	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/admin"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/auth"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/comments"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/media"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/messages"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/notifications"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/posts"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/subs"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/routes/users"
@@ -24,4 +28,8 @@ func BuildRouteGroups(router *gin.Engine) {
 	users.RegisterUserRoutes(api)
 	comments.RegisterCommentsRoutes(api)
 	votes.RegisterVotesRoutes(api)
+	admin.RegisterAdminRoutes(api)
+	messages.RegisterMessageRoutes(api)
+	notifications.RegisterNotificationRoutes(api)
+	media.RegisterMediaRoutes(api)
 }
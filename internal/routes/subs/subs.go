@@ -7,10 +7,12 @@ import (
 )
 
 func RegisterSubRoutes(router *gin.RouterGroup) {
+	// The community list is public; a token adds the viewer's private subs and the NSFW subs they chose to see
+	router.GET("/sub/", middleware.OptionalAuthMiddleware(), handlers.GetSubs)
+
 	subRoutes := router.Group("/sub")
 	subRoutes.Use(middleware.AuthMiddleware())
 	{
-		subRoutes.GET("/", handlers.GetSubs)
 		subRoutes.GET("/sub/:subID/postCount", handlers.GetPostCountPerSub)
 		subRoutes.POST("/sub", handlers.CreateSub)
 		subRoutes.POST("/sub/:subID/join", handlers.JoinSub)
@@ -25,6 +27,51 @@ func RegisterSubRoutes(router *gin.RouterGroup) {
 		// New management queries (Phase 2)
 		subRoutes.GET("/:subID/members", handlers.GetSubMembers)
 		subRoutes.GET("/:subID/pending-invites", handlers.GetPendingInvites)
+
+		// Sub settings and posting restrictions
+		subRoutes.GET("/:subID/settings", handlers.GetSubSettings)
+		subRoutes.PATCH("/:subID/settings", handlers.UpdateSubSettings)
+		subRoutes.GET("/:subID/approved-submitters", handlers.GetApprovedSubmitters)
+		subRoutes.POST("/:subID/approved-submitters", handlers.AddApprovedSubmitter)
+		subRoutes.DELETE("/:subID/approved-submitters/:username", handlers.RemoveApprovedSubmitter)
+
+		// Invitation lifecycle and shareable invite links
+		subRoutes.DELETE("/:subID/invites/:inviteID", handlers.RevokeInvite)
+		subRoutes.GET("/:subID/invite-links", handlers.GetInviteLinks)
+		subRoutes.POST("/:subID/invite-links", handlers.CreateInviteLink)
+		subRoutes.DELETE("/:subID/invite-links/:linkID", handlers.RevokeInviteLink)
+
+		// Moderators and join requests
+		subRoutes.GET("/:subID/moderators", handlers.GetSubModerators)
+		subRoutes.POST("/:subID/moderators", handlers.AddSubModerator)
+		subRoutes.DELETE("/:subID/moderators/:username", handlers.RemoveSubModerator)
+		subRoutes.GET("/:subID/join-requests", handlers.GetJoinRequests)
+		subRoutes.POST("/:subID/join-requests", handlers.RequestToJoinSub)
+		subRoutes.POST("/:subID/join-requests/:requestID/approve", handlers.ApproveJoinRequest)
+		subRoutes.POST("/:subID/join-requests/:requestID/deny", handlers.DenyJoinRequest)
+
+		// Ownership transfer
+		subRoutes.POST("/:subID/transfer", handlers.InitiateSubTransfer)
+		subRoutes.DELETE("/:subID/transfer", handlers.CancelSubTransfer)
+
+		// Flair templates and member flair
+		subRoutes.GET("/:subID/flairs", handlers.GetSubFlairs)
+		subRoutes.POST("/:subID/flairs", handlers.CreateSubFlair)
+		subRoutes.PATCH("/:subID/flairs/:flairID", handlers.UpdateSubFlair)
+		subRoutes.DELETE("/:subID/flairs/:flairID", handlers.DeleteSubFlair)
+		subRoutes.PUT("/:subID/members/:username/flair", handlers.SetMemberFlair)
+	}
+
+	// Name-based routes; ResolveSubName maps :name to the :subID used by the handlers
+	nameRoutes := router.Group("/r/:name")
+	nameRoutes.Use(middleware.AuthMiddleware(), handlers.ResolveSubName)
+	{
+		nameRoutes.GET("", handlers.GetSubByName)
+		nameRoutes.GET("/posts", handlers.ListSubPosts)
+		nameRoutes.GET("/members", handlers.GetSubMembers)
+		nameRoutes.GET("/settings", handlers.GetSubSettings)
+		nameRoutes.POST("/join", handlers.JoinSub)
+		nameRoutes.POST("/leave", handlers.LeaveSub)
 	}
 }
 
@@ -8,12 +8,14 @@ import (
 
 func RegisterUserRoutes(router *gin.RouterGroup) {
 	userRoutes := router.Group("/user")
-	{
-		userRoutes.POST("/invite/:inviteID/accept", handlers.AcceptInvite)
-	}
 
-	// Public user profile routes
-	userRoutes.GET("/:username", handlers.GetUserProfile)
+	// Public user profile routes; a token is optional and lets private profiles show more to their followers
+	userRoutes.GET("/:username", middleware.OptionalAuthMiddleware(), handlers.GetUserProfile)
+
+	// Users' post and comment history is public too; a token lets members see posts in their private subs
+	userRoutes.GET("/:username/posts", middleware.OptionalAuthMiddleware(), handlers.GetUserPosts)
+	userRoutes.GET("/:username/comments", middleware.OptionalAuthMiddleware(), handlers.GetUserComments)
+	userRoutes.GET("/:username/overview", middleware.OptionalAuthMiddleware(), handlers.GetUserOverview)
 
 	// Protected user profile management routes (require authentication)
 	protectedUserRoutes := router.Group("/user")
@@ -22,5 +24,46 @@ func RegisterUserRoutes(router *gin.RouterGroup) {
 		protectedUserRoutes.GET("/profile", handlers.GetCurrentUserProfile)
 		protectedUserRoutes.PUT("/profile", handlers.UpdateUserProfile)
 		protectedUserRoutes.DELETE("/profile", handlers.DeleteUserAccount)
+
+		// Sub invitations addressed to the current user
+		protectedUserRoutes.GET("/invites", handlers.GetMyInvites)
+		protectedUserRoutes.POST("/invite/:inviteID/accept", handlers.AcceptInvite)
+		protectedUserRoutes.POST("/invite/:inviteID/decline", handlers.DeclineInvite)
+		protectedUserRoutes.POST("/invite-links/:token/redeem", handlers.RedeemInviteLink)
+
+		// Sub ownership transfers offered to the current user
+		protectedUserRoutes.GET("/sub-transfers", handlers.GetMySubTransfers)
+		protectedUserRoutes.POST("/sub-transfers/:transferID/accept", handlers.AcceptSubTransfer)
+		protectedUserRoutes.POST("/sub-transfers/:transferID/decline", handlers.DeclineSubTransfer)
+
+		// Following other users
+		protectedUserRoutes.POST("/:username/follow", handlers.FollowUser)
+		protectedUserRoutes.DELETE("/:username/follow", handlers.UnfollowUser)
+		protectedUserRoutes.GET("/:username/followers", handlers.GetFollowers)
+		protectedUserRoutes.GET("/:username/following", handlers.GetFollowing)
+		protectedUserRoutes.GET("/follow-requests", handlers.GetFollowRequests)
+		protectedUserRoutes.POST("/follow-requests/:requestID/approve", handlers.ApproveFollowRequest)
+		protectedUserRoutes.POST("/follow-requests/:requestID/deny", handlers.DenyFollowRequest)
+		protectedUserRoutes.GET("/feed", handlers.GetFollowingFeed)
+
+		// Users' vote history, which only they can see
+		protectedUserRoutes.GET("/:username/upvoted", handlers.GetUserUpvoted)
+
+		// Blocking other users
+		protectedUserRoutes.GET("/blocks", handlers.GetBlockedUsers)
+		protectedUserRoutes.POST("/blocks/:username", handlers.BlockUser)
+		protectedUserRoutes.DELETE("/blocks/:username", handlers.UnblockUser)
+
+		// Saved posts and comments, and hidden posts
+		protectedUserRoutes.GET("/saved", handlers.GetSavedItems)
+		protectedUserRoutes.GET("/hidden", handlers.GetHiddenPosts)
+
+		// Drafts and scheduled posts
+		protectedUserRoutes.GET("/drafts", handlers.GetDrafts)
+		protectedUserRoutes.PUT("/drafts/:draftID", handlers.UpdateDraft)
+		protectedUserRoutes.DELETE("/drafts/:draftID", handlers.DeleteDraft)
+		protectedUserRoutes.POST("/drafts/:draftID/publish", handlers.PublishDraft)
+		protectedUserRoutes.PUT("/drafts/:draftID/schedule", handlers.ScheduleDraft)
+		protectedUserRoutes.DELETE("/drafts/:draftID/schedule", handlers.CancelScheduledPost)
 	}
 }
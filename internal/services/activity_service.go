@@ -0,0 +1,117 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// ActivityService handles users' post, comment and vote history
+type ActivityService struct {
+	activityRepo repositories.IActivityRepository
+	userRepo     repositories.IUserRepository
+	visibility   *ProfileVisibilityPolicy
+}
+
+// NewActivityService creates a new activity service with dependency injection
+func NewActivityService(activityRepo repositories.IActivityRepository, blockRepo repositories.IBlockRepository, followRepo repositories.IFollowRepository, userRepo repositories.IUserRepository) *ActivityService {
+	return &ActivityService{
+		activityRepo: activityRepo,
+		userRepo:     userRepo,
+		visibility:   NewProfileVisibilityPolicy(blockRepo, followRepo),
+	}
+}
+
+// GetPosts returns one page of a user's posts
+func (s *ActivityService) GetPosts(targetUsername, viewerUsername, sort string, page, limit int) (*models.UserPostListResponse, error) {
+	target, viewer, err := s.getVisibleUser(targetUsername, viewerUsername, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, total, err := s.activityRepo.GetUserPosts(target.ID, viewer.ID, sort, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserPostListResponse{Posts: posts, Page: page, Limit: limit, Total: total}, nil
+}
+
+// GetComments returns one page of a user's comments
+func (s *ActivityService) GetComments(targetUsername, viewerUsername, sort string, page, limit int) (*models.UserCommentListResponse, error) {
+	target, viewer, err := s.getVisibleUser(targetUsername, viewerUsername, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, total, err := s.activityRepo.GetUserComments(target.ID, viewer.ID, sort, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserCommentListResponse{Comments: comments, Page: page, Limit: limit, Total: total}, nil
+}
+
+// GetUpvoted returns one page of the posts a user upvoted; only they can see it
+func (s *ActivityService) GetUpvoted(targetUsername, viewerUsername, sort string, page, limit int) (*models.UserPostListResponse, error) {
+	target, viewer, err := s.getVisibleUser(targetUsername, viewerUsername, sort)
+	if err != nil {
+		return nil, err
+	}
+	if viewer.ID == 0 || target.ID != viewer.ID {
+		return nil, errors.New("you can only see your own upvoted posts")
+	}
+
+	posts, total, err := s.activityRepo.GetUpvotedPosts(viewer.ID, sort, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserPostListResponse{Posts: posts, Page: page, Limit: limit, Total: total}, nil
+}
+
+// GetOverview returns one page of a user's posts and comments together
+func (s *ActivityService) GetOverview(targetUsername, viewerUsername, sort string, page, limit int) (*models.UserOverviewResponse, error) {
+	target, viewer, err := s.getVisibleUser(targetUsername, viewerUsername, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.activityRepo.GetOverview(target.ID, viewer.ID, sort, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserOverviewResponse{Items: items, Page: page, Limit: limit, Total: total}, nil
+}
+
+// getVisibleUser checks the sort order, loads the user whose history is requested and checks the viewer may see it.
+// The profile visibility policy decides whether the viewer may see it. Logged-out viewers have an empty username
+// and are returned as a user with no ID, who only sees public subs.
+func (s *ActivityService) getVisibleUser(targetUsername, viewerUsername, sort string) (*models.User, *models.User, error) {
+	if sort != models.ActivitySortNew && sort != models.ActivitySortTop {
+		return nil, nil, errors.New("sort must be new or top")
+	}
+
+	target, err := s.userRepo.GetUserByUsername(targetUsername)
+	if err != nil {
+		return nil, nil, err
+	}
+	viewer, viewerID := &models.User{}, (*uint)(nil)
+	if viewerUsername != "" {
+		viewer, err = s.userRepo.GetUserByUsername(viewerUsername)
+		if err != nil {
+			return nil, nil, err
+		}
+		viewerID = &viewer.ID
+	}
+
+	visibility, err := s.visibility.For(target, viewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if visibility.Relationship == RelationshipBlocked {
+		return nil, nil, errors.New("you cannot interact with this user")
+	}
+	if !visibility.Activity {
+		return nil, nil, errors.New("this user's activity is private")
+	}
+	return target, viewer, nil
+}
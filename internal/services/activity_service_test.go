@@ -0,0 +1,142 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityService_InvalidSort(t *testing.T) {
+	// The sort order is checked before anything is loaded
+	service := NewActivityService(nil, nil, nil, nil)
+	_, err := service.GetPosts("someone", "viewer", "hot", 1, 20)
+	assert.EqualError(t, err, "sort must be new or top")
+}
+
+func TestActivityService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM user_follows")
+	database.DB.Exec("DELETE FROM user_blocks")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	author := models.User{Username: "activityauthor", Password: "password"}
+	viewer := models.User{Username: "activityviewer", Password: "password"}
+	hermit := models.User{Username: "activityhermit", Password: "password", IsPrivate: true}
+	blocker := models.User{Username: "activityblocker", Password: "password"}
+	for _, user := range []*models.User{&author, &viewer, &hermit, &blocker} {
+		database.DB.Create(user)
+	}
+	database.DB.Create(&models.UserBlock{BlockerID: blocker.ID, BlockedID: viewer.ID})
+
+	public := models.Sub{Name: "activitypublic", OwnerID: author.ID}
+	secret := models.Sub{Name: "activitysecret", OwnerID: author.ID, Private: true}
+	database.DB.Create(&public)
+	database.DB.Create(&secret)
+
+	older := models.Post{Title: "Older", Content: "Text", SubID: public.ID, UserID: author.ID}
+	newer := models.Post{Title: "Newer", Content: "Text", SubID: public.ID, UserID: author.ID}
+	hidden := models.Post{Title: "Members only", Content: "Text", SubID: secret.ID, UserID: author.ID}
+	draft := models.Post{Title: "Draft", Content: "Text", SubID: public.ID, UserID: author.ID, Status: models.PostStatusDraft}
+	for _, post := range []*models.Post{&older, &newer, &hidden, &draft} {
+		require.NoError(t, database.DB.Create(post).Error)
+	}
+	comment := models.Comment{Content: "On the older post", PostID: older.ID, UserID: author.ID}
+	database.DB.Create(&comment)
+	database.DB.Create(&models.Comment{Content: "In a private sub", PostID: hidden.ID, UserID: author.ID})
+	database.DB.Create(&models.Vote{UserID: viewer.ID, PostID: older.ID, Vote: 1})
+	database.DB.Create(&models.Vote{UserID: viewer.ID, PostID: newer.ID, Vote: -1})
+
+	service := NewActivityService(repositories.NewActivityRepository(), repositories.NewBlockRepository(),
+		repositories.NewFollowRepository(), repositories.NewUserRepository())
+
+	t.Run("posts leave out private subs and drafts", func(t *testing.T) {
+		posts, err := service.GetPosts("activityauthor", "activityviewer", models.ActivitySortNew, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), posts.Total)
+		require.Len(t, posts.Posts, 2)
+		assert.Equal(t, "Newer", posts.Posts[0].Title)
+
+		top, err := service.GetPosts("activityauthor", "activityviewer", models.ActivitySortTop, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, "Older", top.Posts[0].Title)
+
+		// The author sees their posts in their own private sub
+		own, err := service.GetPosts("activityauthor", "activityauthor", models.ActivitySortNew, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), own.Total)
+	})
+
+	t.Run("comments carry their post and sub", func(t *testing.T) {
+		comments, err := service.GetComments("activityauthor", "activityviewer", models.ActivitySortNew, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), comments.Total)
+		require.Len(t, comments.Comments, 1)
+		assert.Equal(t, "Older", comments.Comments[0].PostTitle)
+		assert.Equal(t, "activitypublic", comments.Comments[0].SubName)
+	})
+
+	t.Run("overview pages through posts and comments together", func(t *testing.T) {
+		overview, err := service.GetOverview("activityauthor", "activityviewer", models.ActivitySortNew, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), overview.Total)
+		require.Len(t, overview.Items, 2)
+		assert.Equal(t, models.ContentTypeComment, overview.Items[0].Type)
+		assert.Equal(t, comment.ID, overview.Items[0].Comment.ID)
+
+		next, err := service.GetOverview("activityauthor", "activityviewer", models.ActivitySortNew, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, next.Items, 1)
+		assert.Equal(t, "Older", next.Items[0].Post.Title)
+	})
+
+	t.Run("upvoted posts are only visible to their owner", func(t *testing.T) {
+		upvoted, err := service.GetUpvoted("activityviewer", "activityviewer", models.ActivitySortNew, 1, 20)
+		require.NoError(t, err)
+		require.Len(t, upvoted.Posts, 1)
+		assert.Equal(t, "Older", upvoted.Posts[0].Title)
+
+		_, err = service.GetUpvoted("activityviewer", "activityauthor", models.ActivitySortNew, 1, 20)
+		assert.EqualError(t, err, "you can only see your own upvoted posts")
+		_, err = service.GetUpvoted("activityviewer", "", models.ActivitySortNew, 1, 20)
+		assert.EqualError(t, err, "you can only see your own upvoted posts")
+	})
+
+	t.Run("logged-out viewers see public subs only", func(t *testing.T) {
+		posts, err := service.GetPosts("activityauthor", "", models.ActivitySortNew, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), posts.Total)
+
+		overview, err := service.GetOverview("activityauthor", "", models.ActivitySortNew, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), overview.Total)
+
+		_, err = service.GetPosts("activityhermit", "", models.ActivitySortNew, 1, 20)
+		assert.EqualError(t, err, "this user's activity is private")
+	})
+
+	t.Run("private users and blocks", func(t *testing.T) {
+		_, err := service.GetPosts("activityhermit", "activityviewer", models.ActivitySortNew, 1, 20)
+		assert.EqualError(t, err, "this user's activity is private")
+
+		database.DB.Create(&models.UserFollow{FollowerID: viewer.ID, FollowingID: hermit.ID, Status: models.FollowStatusAccepted})
+		_, err = service.GetPosts("activityhermit", "activityviewer", models.ActivitySortNew, 1, 20)
+		assert.NoError(t, err)
+
+		_, err = service.GetComments("activityblocker", "activityviewer", models.ActivitySortNew, 1, 20)
+		assert.EqualError(t, err, "you cannot interact with this user")
+	})
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// AdminService handles site administration: reviewing and restoring deleted content
+type AdminService struct {
+	deletedRepo repositories.IDeletedContentRepository
+	userRepo    repositories.IUserRepository
+}
+
+// NewAdminService creates a new admin service with dependency injection
+func NewAdminService(deletedRepo repositories.IDeletedContentRepository, userRepo repositories.IUserRepository) *AdminService {
+	return &AdminService{
+		deletedRepo: deletedRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// GetDeletedContent lists deleted content of one type that can still be restored
+func (s *AdminService) GetDeletedContent(username, contentType string) ([]models.DeletedContentResponse, error) {
+	if err := s.requireAdmin(username); err != nil {
+		return nil, err
+	}
+
+	return s.deletedRepo.GetDeleted(contentType, restoreWindowStart())
+}
+
+// RestoreContent undeletes a post, comment, sub or user that is still within the restore window
+func (s *AdminService) RestoreContent(username, contentType, id string) error {
+	if err := s.requireAdmin(username); err != nil {
+		return err
+	}
+
+	idUint, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return errors.New("invalid ID")
+	}
+
+	return s.deletedRepo.Restore(contentType, uint(idUint), restoreWindowStart())
+}
+
+func (s *AdminService) requireAdmin(username string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return errors.New("admin access required")
+	}
+	return nil
+}
+
+// restoreWindowStart is the earliest deletion time that can still be restored
+func restoreWindowStart() time.Time {
+	return time.Now().Add(-db.DeletedRetention())
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	admin := models.User{Username: "siteadmin", Password: "password", IsAdmin: true}
+	author := models.User{Username: "deletingauthor", Password: "password"}
+	database.DB.Create(&admin)
+	database.DB.Create(&author)
+
+	sub := models.Sub{Name: "restoresub", OwnerID: admin.ID}
+	database.DB.Create(&sub)
+	post := models.Post{Title: "Oops", Content: "Content", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+
+	moderation := NewPostModerationService(repositories.NewPostModerationRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+	service := NewAdminService(repositories.NewDeletedContentRepository(), repositories.NewUserRepository())
+	postID := fmt.Sprintf("%d", post.ID)
+
+	assert.NoError(t, moderation.DeletePost(postID, "deletingauthor"))
+
+	t.Run("regular users cannot see deleted content", func(t *testing.T) {
+		_, err := service.GetDeletedContent("deletingauthor", models.ContentTypePost)
+		assert.EqualError(t, err, "admin access required")
+
+		err = service.RestoreContent("deletingauthor", models.ContentTypePost, postID)
+		assert.EqualError(t, err, "admin access required")
+	})
+
+	t.Run("admin restores a deleted post", func(t *testing.T) {
+		items, err := service.GetDeletedContent("siteadmin", models.ContentTypePost)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+
+		assert.NoError(t, service.RestoreContent("siteadmin", models.ContentTypePost, postID))
+
+		response, err := GetPostByID(postID)
+		assert.NoError(t, err)
+		assert.False(t, response.Deleted)
+		assert.Equal(t, "Oops", response.Title)
+	})
+
+	t.Run("restoring live content fails", func(t *testing.T) {
+		err := service.RestoreContent("siteadmin", models.ContentTypePost, postID)
+		assert.EqualError(t, err, "deleted content not found")
+	})
+}
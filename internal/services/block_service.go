@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// BlockService handles users blocking each other
+type BlockService struct {
+	blockRepo repositories.IBlockRepository
+	userRepo  repositories.IUserRepository
+}
+
+// NewBlockService creates a new block service with dependency injection
+func NewBlockService(blockRepo repositories.IBlockRepository, userRepo repositories.IUserRepository) *BlockService {
+	return &BlockService{
+		blockRepo: blockRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// BlockUser blocks another user; follows between the two users are removed
+func (s *BlockService) BlockUser(username, targetUsername string) (*models.BlockResponse, error) {
+	user, target, err := s.getUsers(username, targetUsername)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == target.ID {
+		return nil, errors.New("you cannot block yourself")
+	}
+
+	if _, err := s.blockRepo.GetBlock(user.ID, target.ID); err == nil {
+		return nil, errors.New("you already blocked this user")
+	}
+
+	block := models.UserBlock{BlockerID: user.ID, BlockedID: target.ID}
+	if err := s.blockRepo.CreateBlock(&block); err != nil {
+		return nil, err
+	}
+
+	return &models.BlockResponse{
+		Username:  target.Username,
+		BlockedAt: block.CreatedAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// UnblockUser removes a block
+func (s *BlockService) UnblockUser(username, targetUsername string) error {
+	user, target, err := s.getUsers(username, targetUsername)
+	if err != nil {
+		return err
+	}
+
+	block, err := s.blockRepo.GetBlock(user.ID, target.ID)
+	if err != nil {
+		return errors.New("you have not blocked this user")
+	}
+
+	return s.blockRepo.DeleteBlock(block)
+}
+
+// GetBlockedUsers lists the users the current user has blocked
+func (s *BlockService) GetBlockedUsers(username string) ([]models.BlockResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.blockRepo.GetBlockedUsers(user.ID)
+}
+
+// CheckCanInteract verifies the target user has not blocked the user trying to reply to, mention or message them
+func (s *BlockService) CheckCanInteract(username string, targetID uint) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	blocked, err := s.blockRepo.IsBlocked(targetID, user.ID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errors.New("you cannot interact with this user")
+	}
+	return nil
+}
+
+func (s *BlockService) getUsers(username, targetUsername string) (*models.User, *models.User, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := s.userRepo.GetUserByUsername(targetUsername)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, target, nil
+}
+
+// CheckCanInteract checks for a block using the default repositories
+func CheckCanInteract(username string, targetID uint) error {
+	service := NewBlockService(repositories.NewBlockRepository(), repositories.NewUserRepository())
+	return service.CheckCanInteract(username, targetID)
+}
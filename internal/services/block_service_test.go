@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM user_blocks")
+	database.DB.Exec("DELETE FROM user_follows")
+	database.DB.Exec("DELETE FROM notifications")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	alice := models.User{Username: "blockalice", Password: "password"}
+	bob := models.User{Username: "blockbob", Password: "password"}
+	database.DB.Create(&alice)
+	database.DB.Create(&bob)
+
+	sub := models.Sub{Name: "blocksub", OwnerID: alice.ID}
+	database.DB.Create(&sub)
+	alicePost := models.Post{Title: "Alice", Content: "Content", SubID: sub.ID, UserID: alice.ID}
+	bobPost := models.Post{Title: "Bob", Content: "Content", SubID: sub.ID, UserID: bob.ID}
+	database.DB.Create(&alicePost)
+	database.DB.Create(&bobPost)
+	database.DB.Create(&models.Comment{Content: "Reply from bob", PostID: alicePost.ID, UserID: bob.ID})
+	database.DB.Create(&models.UserFollow{FollowerID: bob.ID, FollowingID: alice.ID, Status: models.FollowStatusAccepted})
+
+	service := NewBlockService(repositories.NewBlockRepository(), repositories.NewUserRepository())
+	subID := fmt.Sprintf("%d", sub.ID)
+
+	t.Run("cannot block yourself", func(t *testing.T) {
+		_, err := service.BlockUser("blockalice", "blockalice")
+		assert.EqualError(t, err, "you cannot block yourself")
+	})
+
+	t.Run("block hides content and removes follows", func(t *testing.T) {
+		block, err := service.BlockUser("blockalice", "blockbob")
+		assert.NoError(t, err)
+		assert.Equal(t, "blockbob", block.Username)
+
+		_, err = service.BlockUser("blockalice", "blockbob")
+		assert.EqualError(t, err, "you already blocked this user")
+
+		posts, err := ListSubPosts(subID, "blockalice", "", "")
+		assert.NoError(t, err)
+		assert.Len(t, *posts, 1)
+		assert.Equal(t, "Alice", (*posts)[0].Title)
+
+		comments, err := GetCommentsByPostID(fmt.Sprintf("%d", alicePost.ID), "blockalice")
+		assert.NoError(t, err)
+		assert.Empty(t, *comments)
+
+		// Other users still see bob's content
+		posts, err = ListSubPosts(subID, "blockbob", "", "")
+		assert.NoError(t, err)
+		assert.Len(t, *posts, 2)
+
+		var follows int64
+		database.DB.Model(&models.UserFollow{}).Where("follower_id = ?", bob.ID).Count(&follows)
+		assert.Equal(t, int64(0), follows)
+	})
+
+	t.Run("blocked users cannot reply or follow", func(t *testing.T) {
+		_, err := CreateComment("blockbob", models.CommentRequest{PostID: alicePost.ID, Content: "Hello?"}, alicePost)
+		assert.EqualError(t, err, "you cannot interact with this user")
+
+		followService := NewFollowService(repositories.NewFollowRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository())
+		_, err = followService.Follow("blockbob", "blockalice")
+		assert.EqualError(t, err, "you cannot interact with this user")
+
+		// The blocker can still reply to the blocked user
+		assert.NoError(t, CheckCanInteract("blockalice", bob.ID))
+	})
+
+	t.Run("unblock", func(t *testing.T) {
+		blocks, err := service.GetBlockedUsers("blockalice")
+		assert.NoError(t, err)
+		assert.Len(t, blocks, 1)
+
+		assert.NoError(t, service.UnblockUser("blockalice", "blockbob"))
+		assert.EqualError(t, service.UnblockUser("blockalice", "blockbob"), "you have not blocked this user")
+		assert.NoError(t, CheckCanInteract("blockbob", alice.ID))
+	})
+}
@@ -19,8 +19,8 @@ func NewCommentsService(commentRepo repositories.ICommentRepository) *CommentsSe
 	}
 }
 
-func (s *CommentsService) GetCommentsByPostID(postID string) (*[]models.CommentResponse, error) {
-	commentResponse, err := s.commentRepo.GetCommentsByPostID(postID)
+func (s *CommentsService) GetCommentsByPostID(postID, username string) (*[]models.CommentResponse, error) {
+	commentResponse, err := s.commentRepo.GetCommentsByPostID(postID, username)
 	if err != nil {
 		return nil, err
 	}
@@ -29,11 +29,48 @@ func (s *CommentsService) GetCommentsByPostID(postID string) (*[]models.CommentR
 }
 
 func (s *CommentsService) CreateComment(username string, commentReq models.CommentRequest, post models.Post) (*models.Comment, error) {
+	// Locked and archived posts don't take new comments
+	if err := CheckPostOpen(post); err != nil {
+		return nil, err
+	}
+
+	// Users blocked by the post author or the author of the parent comment cannot reply to them
+	if err := CheckCanInteract(username, post.UserID); err != nil {
+		return nil, err
+	}
+
+	// The author being replied to is notified: the parent comment's author for replies, otherwise the post author
+	recipientID := post.UserID
+	if commentReq.ParentID != nil {
+		parent, err := s.commentRepo.GetComment(*commentReq.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		// Deleted authors no longer resolve to a user and can't have blocked anyone
+		recipientID = 0
+		if parentAuthor, err := repositories.NewUserRepository().GetUserByID(parent.UserID); err == nil {
+			if err := CheckCanInteract(username, parentAuthor.ID); err != nil {
+				return nil, err
+			}
+			recipientID = parentAuthor.ID
+		}
+	}
+
+	// An uploaded image is linked through the API, and only its uploader can attach it
+	imageURL, err := resolveMediaURL(username, commentReq.MediaID, commentReq.ImageURL)
+	if err != nil {
+		return nil, err
+	}
+	commentReq.ImageURL = imageURL
+
 	comment, err := s.commentRepo.CreateComment(username, commentReq, post)
 	if err != nil {
 		return nil, err
 	}
 
+	notifyBestEffort(newDefaultNotificationService().CommentCreated(username, recipientID, *comment, post), "send reply notification")
+	notifyBestEffort(newDefaultMentionService().SyncCommentMentions(username, *comment), "link comment mentions")
+
 	return comment, nil
 }
 
@@ -57,11 +94,33 @@ func (s *CommentsService) UpdateComment(commentID uint, username string, comment
 		return nil, errors.New("unauthorized: can only edit own comments")
 	}
 
+	// Archived posts are read-only, comments included
+	comment, err := s.commentRepo.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+	post, err := repositories.NewPostModerationRepository().GetPost(comment.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Archived {
+		return nil, errors.New("this post is archived")
+	}
+
+	imageURL, err := resolveMediaURL(username, commentReq.MediaID, commentReq.ImageURL)
+	if err != nil {
+		return nil, err
+	}
+	commentReq.ImageURL = imageURL
+
 	updatedComment, err := s.commentRepo.UpdateComment(commentID, commentReq)
 	if err != nil {
 		return nil, err
 	}
 
+	// Re-link mentions in the new content; users who were already mentioned aren't notified again
+	notifyBestEffort(newDefaultMentionService().SyncCommentMentions(username, *updatedComment), "link comment mentions")
+
 	return updatedComment, nil
 }
 
@@ -81,9 +140,9 @@ func (s *CommentsService) DeleteComment(commentID uint, username string) error {
 }
 
 // Legacy global functions for backward compatibility
-func GetCommentsByPostID(postID string) (*[]models.CommentResponse, error) {
+func GetCommentsByPostID(postID, username string) (*[]models.CommentResponse, error) {
 	service := NewCommentsService(repositories.NewCommentRepository())
-	return service.GetCommentsByPostID(postID)
+	return service.GetCommentsByPostID(postID, username)
 }
 
 func CreateComment(username string, commentReq models.CommentRequest, post models.Post) (*models.Comment, error) {
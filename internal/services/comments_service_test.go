@@ -15,7 +15,7 @@ type MockCommentRepository struct {
 	mock.Mock
 }
 
-func (m *MockCommentRepository) GetCommentsByPostID(postID string) (*[]models.CommentResponse, error) {
+func (m *MockCommentRepository) GetCommentsByPostID(postID, username string) (*[]models.CommentResponse, error) {
 	args := m.Called(postID)
 	return args.Get(0).(*[]models.CommentResponse), args.Error(1)
 }
@@ -30,6 +30,11 @@ func (m *MockCommentRepository) GetCommentByID(commentID uint) (*models.CommentR
 	return args.Get(0).(*models.CommentResponse), args.Error(1)
 }
 
+func (m *MockCommentRepository) GetComment(commentID uint) (*models.Comment, error) {
+	args := m.Called(commentID)
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
 func (m *MockCommentRepository) UpdateComment(commentID uint, commentReq models.CommentUpdateRequest) (*models.Comment, error) {
 	args := m.Called(commentID, commentReq)
 	return args.Get(0).(*models.Comment), args.Error(1)
@@ -49,7 +54,7 @@ func TestGetCommentsByPostID_Service(t *testing.T) {
 
 	// Test with real database
 	service := NewCommentsService(repositories.NewCommentRepository())
-	comments, err := service.GetCommentsByPostID("1") // Test post ID
+	comments, err := service.GetCommentsByPostID("1", "") // Test post ID
 
 	if err != nil {
 		// No data, but service should not error
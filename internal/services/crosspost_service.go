@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// CrosspostService handles sharing posts in other subs
+type CrosspostService struct {
+	crosspostRepo repositories.ICrosspostRepository
+	pollRepo      repositories.IPollRepository
+	subRepo       repositories.ISubSettingsRepository
+	userRepo      repositories.IUserRepository
+}
+
+// NewCrosspostService creates a new crosspost service with dependency injection
+func NewCrosspostService(crosspostRepo repositories.ICrosspostRepository, pollRepo repositories.IPollRepository, subRepo repositories.ISubSettingsRepository, userRepo repositories.IUserRepository) *CrosspostService {
+	return &CrosspostService{
+		crosspostRepo: crosspostRepo,
+		pollRepo:      pollRepo,
+		subRepo:       subRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// Crosspost shares a post in another sub. Crossposting a crosspost shares the original, posts from private subs
+// can't be shared, and the target sub's posting settings apply as they would to the original post.
+func (s *CrosspostService) Crosspost(postID, username string, req models.CrosspostRequest) (*models.PostResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	postIDUint, err := strconv.ParseUint(postID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid post ID")
+	}
+
+	original, err := s.crosspostRepo.GetPost(uint(postIDUint))
+	if err != nil {
+		return nil, err
+	}
+	if original.CrosspostParentID != nil {
+		if original, err = s.crosspostRepo.GetPost(*original.CrosspostParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := s.subRepo.GetSubByID(original.SubID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Private {
+		return nil, errors.New("posts from private subs can't be crossposted")
+	}
+	if req.SubID == original.SubID {
+		return nil, errors.New("a post can't be crossposted to its own sub")
+	}
+
+	// Users blocked by the original author can't share their posts
+	if err := CheckCanInteract(username, original.UserID); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = original.Title
+	}
+	crosspost := models.Post{
+		Title:             title,
+		SubID:             req.SubID,
+		FlairID:           req.FlairID,
+		CrosspostParentID: &original.ID,
+		UserID:            user.ID,
+		Status:            models.PostStatusPublished,
+	}
+	// Crossposts carry the original's content flags, and posts shared from an NSFW sub stay NSFW
+	crosspost.NSFW, crosspost.Spoiler = original.NSFW || source.NSFW, original.Spoiler
+
+	if err := CheckPostingPermission(username, req.SubID, savedPostType(s.pollRepo, *original)); err != nil {
+		return nil, err
+	}
+	if err := CheckPostFlair(username, crosspost); err != nil {
+		return nil, err
+	}
+
+	crossposted, err := s.crosspostRepo.HasCrossposted(user.ID, original.ID, req.SubID)
+	if err != nil {
+		return nil, err
+	}
+	if crossposted {
+		return nil, errors.New("you have already crossposted this post to this sub")
+	}
+
+	if err := s.crosspostRepo.CreateCrosspost(&crosspost); err != nil {
+		return nil, err
+	}
+	return GetPostByID(fmt.Sprint(crosspost.ID))
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrosspostService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM user_blocks")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	author := models.User{Username: "crossauthor", Password: "password"}
+	sharer := models.User{Username: "crosssharer", Password: "password"}
+	blocked := models.User{Username: "crossblocked", Password: "password"}
+	database.DB.Create(&author)
+	database.DB.Create(&sharer)
+	database.DB.Create(&blocked)
+	source := models.Sub{Name: "crosssource", OwnerID: author.ID}
+	target := models.Sub{Name: "crosstarget", OwnerID: author.ID}
+	textOnly := models.Sub{Name: "crosstextonly", OwnerID: author.ID, AllowedPostTypes: models.PostTypeText}
+	secret := models.Sub{Name: "crosssecret", OwnerID: author.ID, Private: true}
+	database.DB.Create(&source)
+	database.DB.Create(&target)
+	database.DB.Create(&textOnly)
+	database.DB.Create(&secret)
+	database.DB.Create(&models.UserBlock{BlockerID: author.ID, BlockedID: blocked.ID})
+
+	service := NewCrosspostService(repositories.NewCrosspostRepository(), repositories.NewPollRepository(),
+		repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+
+	original, err := CreatePost("crossauthor", models.Post{Title: "Original", Content: "Worth sharing", SubID: source.ID})
+	require.NoError(t, err)
+	originalID := fmt.Sprint(original.ID)
+
+	t.Run("crossposts link back to the original", func(t *testing.T) {
+		crosspost, err := service.Crosspost(originalID, "crosssharer", models.CrosspostRequest{SubID: target.ID})
+		require.NoError(t, err)
+		assert.Equal(t, "Original", crosspost.Title)
+		assert.Equal(t, target.ID, crosspost.SubID)
+		require.NotNil(t, crosspost.Crosspost)
+		assert.Equal(t, original.ID, crosspost.Crosspost.PostID)
+		assert.Equal(t, "crosssource", crosspost.Crosspost.SubName)
+		assert.Equal(t, "crossauthor", crosspost.Crosspost.Username)
+		assert.Equal(t, "Worth sharing", crosspost.Crosspost.Content)
+
+		_, err = service.Crosspost(originalID, "crosssharer", models.CrosspostRequest{SubID: target.ID})
+		assert.EqualError(t, err, "you have already crossposted this post to this sub")
+
+		// Crossposting the crosspost shares the original
+		again, err := service.Crosspost(fmt.Sprint(crosspost.ID), "crossauthor", models.CrosspostRequest{SubID: target.ID, Title: "Shared again"})
+		require.NoError(t, err)
+		assert.Equal(t, "Shared again", again.Title)
+		assert.Equal(t, original.ID, again.Crosspost.PostID)
+
+		post, err := GetPostByID(originalID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), post.CrosspostCount)
+	})
+
+	t.Run("source privacy and target settings apply", func(t *testing.T) {
+		_, err := service.Crosspost(originalID, "crosssharer", models.CrosspostRequest{SubID: source.ID})
+		assert.EqualError(t, err, "a post can't be crossposted to its own sub")
+		_, err = service.Crosspost(originalID, "crossblocked", models.CrosspostRequest{SubID: target.ID})
+		assert.EqualError(t, err, "you cannot interact with this user")
+		_, err = service.Crosspost(originalID, "crosssharer", models.CrosspostRequest{SubID: 999999})
+		assert.EqualError(t, err, "sub not found")
+
+		image := "https://example.com/cat.png"
+		imagePost, err := CreatePost("crossauthor", models.Post{Title: "Cat", Content: "Look", SubID: source.ID, ImageURL: &image})
+		require.NoError(t, err)
+		_, err = service.Crosspost(fmt.Sprint(imagePost.ID), "crosssharer", models.CrosspostRequest{SubID: textOnly.ID})
+		assert.EqualError(t, err, "this post type is not allowed in this sub")
+
+		private, err := CreatePost("crossauthor", models.Post{Title: "Members only", Content: "Secret", SubID: secret.ID})
+		require.NoError(t, err)
+		_, err = service.Crosspost(fmt.Sprint(private.ID), "crossauthor", models.CrosspostRequest{SubID: target.ID})
+		assert.EqualError(t, err, "posts from private subs can't be crossposted")
+
+		// Only members can crosspost into a private sub
+		_, err = service.Crosspost(originalID, "crosssharer", models.CrosspostRequest{SubID: secret.ID})
+		assert.EqualError(t, err, "you must be a member to post in this sub")
+		require.NoError(t, database.DB.Create(&models.SubMembership{SubID: secret.ID, UserID: sharer.ID}).Error)
+		_, err = service.Crosspost(originalID, "crosssharer", models.CrosspostRequest{SubID: secret.ID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("crossposts of deleted or hidden originals keep only the link", func(t *testing.T) {
+		post, err := CreatePost("crossauthor", models.Post{Title: "Fleeting", Content: "Text", SubID: source.ID})
+		require.NoError(t, err)
+		crosspost, err := service.Crosspost(fmt.Sprint(post.ID), "crosssharer", models.CrosspostRequest{SubID: target.ID})
+		require.NoError(t, err)
+
+		require.NoError(t, database.DB.Model(&source).Update("private", true).Error)
+		shown, err := GetPostByID(fmt.Sprint(crosspost.ID))
+		require.NoError(t, err)
+		assert.True(t, shown.Crosspost.Unavailable)
+		assert.Empty(t, shown.Crosspost.Content)
+		require.NoError(t, database.DB.Model(&source).Update("private", false).Error)
+
+		require.NoError(t, database.DB.Delete(&models.Post{}, post.ID).Error)
+		shown, err = GetPostByID(fmt.Sprint(crosspost.ID))
+		require.NoError(t, err)
+		assert.True(t, shown.Crosspost.Deleted)
+		assert.Equal(t, post.ID, shown.Crosspost.PostID)
+		assert.Empty(t, shown.Crosspost.Title)
+	})
+
+	t.Run("crossposts can't be made through the create endpoint", func(t *testing.T) {
+		parentID := original.ID
+		post, err := CreatePost("crosssharer", models.Post{Title: "Sneaky", Content: "Text", SubID: target.ID, CrosspostParentID: &parentID})
+		require.NoError(t, err)
+		assert.Nil(t, post.CrosspostParentID)
+	})
+}
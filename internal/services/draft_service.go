@@ -0,0 +1,295 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// maxScheduleDays limits how far ahead a post can be scheduled
+const maxScheduleDays = 365
+
+// DraftService handles draft and scheduled posts and publishing them
+type DraftService struct {
+	draftRepo repositories.IDraftRepository
+	pollRepo  repositories.IPollRepository
+	userRepo  repositories.IUserRepository
+}
+
+// NewDraftService creates a new draft service with dependency injection
+func NewDraftService(draftRepo repositories.IDraftRepository, pollRepo repositories.IPollRepository, userRepo repositories.IUserRepository) *DraftService {
+	return &DraftService{
+		draftRepo: draftRepo,
+		pollRepo:  pollRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func newDefaultDraftService() *DraftService {
+	return NewDraftService(repositories.NewDraftRepository(), repositories.NewPollRepository(), repositories.NewUserRepository())
+}
+
+// GetDrafts lists the user's drafts and scheduled posts
+func (s *DraftService) GetDrafts(username string) ([]models.DraftResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.draftRepo.GetDrafts(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return repositories.FormatDrafts(posts), nil
+}
+
+// UpdateDraft edits the title and content of a draft or scheduled post
+func (s *DraftService) UpdateDraft(postID, username string, req models.DraftUpdateRequest) (*models.DraftResponse, error) {
+	post, err := s.getOwnDraft(postID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, errors.New("title is required")
+	}
+	postType := savedPostType(s.pollRepo, *post)
+	if (postType == models.PostTypeText || postType == models.PostTypeImage) && strings.TrimSpace(req.Content) == "" {
+		return nil, errors.New("content is required")
+	}
+
+	if err := s.draftRepo.UpdateDraft(post, title, req.Content); err != nil {
+		return nil, err
+	}
+	return draftResponse(*post), nil
+}
+
+// Schedule schedules a draft for publishing, or moves a scheduled post to a new time
+func (s *DraftService) Schedule(postID, username string, req models.ScheduleRequest) (*models.DraftResponse, error) {
+	post, err := s.getOwnDraft(postID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	var pollClosesAt *time.Time
+	if poll, err := s.pollRepo.GetPollByPostID(post.ID); err == nil {
+		pollClosesAt = poll.ClosesAt
+	}
+	if err := checkScheduledTime(req.ScheduledAt, pollClosesAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.draftRepo.SetSchedule(post, &req.ScheduledAt); err != nil {
+		return nil, err
+	}
+	return draftResponse(*post), nil
+}
+
+// CancelSchedule turns a scheduled post back into a draft
+func (s *DraftService) CancelSchedule(postID, username string) (*models.DraftResponse, error) {
+	post, err := s.getOwnDraft(postID, username)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != models.PostStatusScheduled {
+		return nil, errors.New("this post is not scheduled")
+	}
+
+	if err := s.draftRepo.SetSchedule(post, nil); err != nil {
+		return nil, err
+	}
+	return draftResponse(*post), nil
+}
+
+// DeleteDraft discards a draft or scheduled post
+func (s *DraftService) DeleteDraft(postID, username string) error {
+	post, err := s.getOwnDraft(postID, username)
+	if err != nil {
+		return err
+	}
+	return s.draftRepo.DeleteDraft(post)
+}
+
+// Publish publishes a draft or scheduled post now, checking the sub's posting settings again
+func (s *DraftService) Publish(postID, username string) (*models.PostResponse, error) {
+	post, err := s.getOwnDraft(postID, username)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPublishable(*post); err != nil {
+		return nil, err
+	}
+
+	published, err := s.publish(post, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !published {
+		// The scheduler got to it first
+		return nil, errors.New("this post has already been published")
+	}
+	return GetPostByID(fmt.Sprint(post.ID))
+}
+
+// PublishDue publishes the scheduled posts whose time has come and returns how many were published.
+// Posts the author is no longer allowed to publish go back to being drafts with the reason; errors
+// loading the sub or author are left for the next run.
+func (s *DraftService) PublishDue(now time.Time) (int, error) {
+	posts, err := s.draftRepo.GetDueScheduledPosts(now)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range posts {
+		post := &posts[i]
+		if err := s.checkPublishable(*post); err != nil {
+			if strings.HasPrefix(err.Error(), "failed to") {
+				log.Printf("Failed to check scheduled post %d: %v", post.ID, err)
+				continue
+			}
+			if _, err := s.draftRepo.ReturnToDraft(post, err.Error()); err != nil {
+				log.Printf("Failed to return scheduled post %d to drafts: %v", post.ID, err)
+			}
+			continue
+		}
+
+		published, err := s.publish(post, now)
+		if err != nil {
+			log.Printf("Failed to publish scheduled post %d: %v", post.ID, err)
+			continue
+		}
+		if published {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// PublishScheduledPosts runs the scheduler, publishing due posts every minute. Posts that came due while
+// the server was down are published on the first run, and each post is only published once.
+func PublishScheduledPosts() {
+	service := newDefaultDraftService()
+	for {
+		count, err := service.PublishDue(time.Now())
+		if err != nil {
+			log.Println("Error publishing scheduled posts:", err)
+		} else if count > 0 {
+			log.Println("Published", count, "scheduled posts.")
+		}
+		time.Sleep(1 * time.Minute)
+	}
+}
+
+// publish marks a post published and links its mentions, which drafts hold back so nobody is notified early
+func (s *DraftService) publish(post *models.Post, now time.Time) (bool, error) {
+	published, err := s.draftRepo.PublishPost(post, now)
+	if err != nil || !published {
+		return published, err
+	}
+
+	notifyBestEffort(newDefaultMentionService().SyncPostMentions(post.User.Username, *post), "link post mentions")
+	return true, nil
+}
+
+// checkPublishable checks the sub's posting settings for the author as they are now; the sub or the author's
+// standing in it may have changed since the post was written, and authors who left a private sub can't post in it
+func (s *DraftService) checkPublishable(post models.Post) error {
+	if post.User.ID == 0 {
+		return errors.New("user not found")
+	}
+	if err := CheckPostingPermission(post.User.Username, post.SubID, savedPostType(s.pollRepo, post)); err != nil {
+		return err
+	}
+	return CheckPostFlair(post.User.Username, post)
+}
+
+// savedPostType reports the type of a saved post; its poll is stored separately
+func savedPostType(pollRepo repositories.IPollRepository, post models.Post) string {
+	if _, err := pollRepo.GetPollByPostID(post.ID); err == nil {
+		post.Poll = &models.PollRequest{}
+	}
+	return post.PostType()
+}
+
+// getOwnDraft loads a draft or scheduled post and verifies the user wrote it
+func (s *DraftService) getOwnDraft(postID, username string) (*models.Post, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	postIDUint, err := strconv.ParseUint(postID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid post ID")
+	}
+
+	post, err := s.draftRepo.GetDraft(uint(postIDUint))
+	if err != nil {
+		return nil, err
+	}
+	// Other users' drafts are reported as missing so their existence isn't revealed
+	if post.UserID != user.ID {
+		return nil, errors.New("draft not found")
+	}
+	return post, nil
+}
+
+// draftResponse formats a single draft or scheduled post
+func draftResponse(post models.Post) *models.DraftResponse {
+	drafts := repositories.FormatDrafts([]models.Post{post})
+	return &drafts[0]
+}
+
+// setPublishingStatus checks the status of a new post: published by default, a draft, or scheduled when it has a time
+func setPublishingStatus(post *models.Post) error {
+	post.PublishError = ""
+	switch post.Status {
+	case "", models.PostStatusPublished:
+		post.Status = models.PostStatusPublished
+		if post.ScheduledAt != nil {
+			post.Status = models.PostStatusScheduled
+		}
+	case models.PostStatusDraft:
+		if post.ScheduledAt != nil {
+			return errors.New("a draft can't have a scheduled time")
+		}
+	case models.PostStatusScheduled:
+		if post.ScheduledAt == nil {
+			return errors.New("a scheduled post needs a scheduled time")
+		}
+	default:
+		return errors.New("status must be published, draft or scheduled")
+	}
+
+	if post.Status != models.PostStatusScheduled {
+		return nil
+	}
+	var pollClosesAt *time.Time
+	if post.Poll != nil {
+		pollClosesAt = post.Poll.ClosesAt
+	}
+	return checkScheduledTime(*post.ScheduledAt, pollClosesAt)
+}
+
+// checkScheduledTime checks a publishing time; a poll has to stay open for some time after it is published
+func checkScheduledTime(scheduledAt time.Time, pollClosesAt *time.Time) error {
+	now := time.Now()
+	if !scheduledAt.After(now) {
+		return errors.New("the scheduled time must be in the future")
+	}
+	if scheduledAt.After(now.AddDate(0, 0, maxScheduleDays)) {
+		return fmt.Errorf("posts can be scheduled at most %d days ahead", maxScheduleDays)
+	}
+	if pollClosesAt != nil && !pollClosesAt.After(scheduledAt) {
+		return errors.New("the poll must close after the post is published")
+	}
+	return nil
+}
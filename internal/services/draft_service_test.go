@@ -0,0 +1,202 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPublishingStatus(t *testing.T) {
+	soon := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Minute)
+	tooFar := time.Now().AddDate(1, 1, 0)
+
+	post := models.Post{}
+	require.NoError(t, setPublishingStatus(&post))
+	assert.Equal(t, models.PostStatusPublished, post.Status)
+
+	post = models.Post{ScheduledAt: &soon}
+	require.NoError(t, setPublishingStatus(&post))
+	assert.Equal(t, models.PostStatusScheduled, post.Status)
+
+	post = models.Post{Status: models.PostStatusDraft, PublishError: "stale"}
+	require.NoError(t, setPublishingStatus(&post))
+	assert.Equal(t, models.PostStatusDraft, post.Status)
+	assert.Empty(t, post.PublishError)
+
+	cases := []struct {
+		post     models.Post
+		expected string
+	}{
+		{models.Post{Status: models.PostStatusDraft, ScheduledAt: &soon}, "a draft can't have a scheduled time"},
+		{models.Post{Status: models.PostStatusScheduled}, "a scheduled post needs a scheduled time"},
+		{models.Post{Status: "hidden"}, "status must be published, draft or scheduled"},
+		{models.Post{ScheduledAt: &past}, "the scheduled time must be in the future"},
+		{models.Post{ScheduledAt: &tooFar}, "posts can be scheduled at most 365 days ahead"},
+		{models.Post{ScheduledAt: &tooFar, Poll: &models.PollRequest{ClosesAt: &soon}}, "posts can be scheduled at most 365 days ahead"},
+		{models.Post{ScheduledAt: &soon, Poll: &models.PollRequest{ClosesAt: &soon}}, "the poll must close after the post is published"},
+	}
+	for _, c := range cases {
+		assert.EqualError(t, setPublishingStatus(&c.post), c.expected)
+	}
+}
+
+func TestDraftService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM mentions")
+	database.DB.Exec("DELETE FROM sub_approved_submitters")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "draftowner", Password: "password"}
+	author := models.User{Username: "draftauthor", Password: "password"}
+	other := models.User{Username: "draftother", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&author)
+	database.DB.Create(&other)
+	sub := models.Sub{Name: "draftsub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	service := NewDraftService(repositories.NewDraftRepository(), repositories.NewPollRepository(), repositories.NewUserRepository())
+
+	isListed := func(t *testing.T, postID uint) bool {
+		posts, err := ListSubPosts(fmt.Sprint(sub.ID), "", "", "")
+		require.NoError(t, err)
+		for _, listed := range *posts {
+			if listed.ID == postID {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("drafts are private until published", func(t *testing.T) {
+		post, err := CreatePost("draftauthor", models.Post{Title: "Announcement", Content: "Soon, @draftother", SubID: sub.ID, Status: models.PostStatusDraft})
+		require.NoError(t, err)
+		postID := fmt.Sprint(post.ID)
+		assert.False(t, isListed(t, post.ID))
+		_, err = GetPostByID(postID)
+		assert.EqualError(t, err, "post not found")
+
+		drafts, err := service.GetDrafts("draftauthor")
+		require.NoError(t, err)
+		require.Len(t, drafts, 1)
+		assert.Equal(t, models.PostStatusDraft, drafts[0].Status)
+		assert.Empty(t, drafts[0].Mentions)
+
+		_, err = service.UpdateDraft(postID, "draftother", models.DraftUpdateRequest{Title: "Mine now", Content: "Text"})
+		assert.EqualError(t, err, "draft not found")
+		_, err = service.UpdateDraft(postID, "draftauthor", models.DraftUpdateRequest{Title: "Announcement", Content: " "})
+		assert.EqualError(t, err, "content is required")
+		draft, err := service.UpdateDraft(postID, "draftauthor", models.DraftUpdateRequest{Title: "Big announcement", Content: "Today, @draftother"})
+		require.NoError(t, err)
+		assert.Equal(t, "Big announcement", draft.Title)
+
+		published, err := service.Publish(postID, "draftauthor")
+		require.NoError(t, err)
+		assert.Equal(t, "Big announcement", published.Title)
+		assert.Len(t, published.Mentions, 1)
+		assert.True(t, isListed(t, post.ID))
+
+		_, err = service.Publish(postID, "draftauthor")
+		assert.EqualError(t, err, "draft not found")
+	})
+
+	t.Run("scheduled posts are published once when due", func(t *testing.T) {
+		at := time.Now().Add(time.Hour)
+		post, err := CreatePost("draftauthor", models.Post{Title: "Later", Content: "Text", SubID: sub.ID, ScheduledAt: &at})
+		require.NoError(t, err)
+		assert.Equal(t, models.PostStatusScheduled, post.Status)
+
+		count, err := service.PublishDue(time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		rescheduled := time.Now().Add(2 * time.Hour)
+		draft, err := service.Schedule(fmt.Sprint(post.ID), "draftauthor", models.ScheduleRequest{ScheduledAt: rescheduled})
+		require.NoError(t, err)
+		assert.Equal(t, models.PostStatusScheduled, draft.Status)
+
+		// Publishing from a stale read is ignored, as when two schedulers pick up the same post
+		var stale models.Post
+		require.NoError(t, database.DB.First(&stale, post.ID).Error)
+		count, err = service.PublishDue(rescheduled.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		publishedAgain, err := repositories.NewDraftRepository().PublishPost(&stale, time.Now())
+		require.NoError(t, err)
+		assert.False(t, publishedAgain)
+		assert.True(t, isListed(t, post.ID))
+
+		count, err = service.PublishDue(rescheduled.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("cancelling a schedule keeps the draft", func(t *testing.T) {
+		at := time.Now().Add(time.Hour)
+		post, err := CreatePost("draftauthor", models.Post{Title: "Maybe", Content: "Text", SubID: sub.ID, ScheduledAt: &at})
+		require.NoError(t, err)
+
+		draft, err := service.CancelSchedule(fmt.Sprint(post.ID), "draftauthor")
+		require.NoError(t, err)
+		assert.Equal(t, models.PostStatusDraft, draft.Status)
+		assert.Empty(t, draft.ScheduledAt)
+		_, err = service.CancelSchedule(fmt.Sprint(post.ID), "draftauthor")
+		assert.EqualError(t, err, "this post is not scheduled")
+
+		require.NoError(t, service.DeleteDraft(fmt.Sprint(post.ID), "draftauthor"))
+		_, err = service.Publish(fmt.Sprint(post.ID), "draftauthor")
+		assert.EqualError(t, err, "draft not found")
+	})
+
+	t.Run("posting settings are checked again at publish time", func(t *testing.T) {
+		at := time.Now().Add(time.Hour)
+		post, err := CreatePost("draftauthor", models.Post{Title: "Restricted", Content: "Text", SubID: sub.ID, ScheduledAt: &at})
+		require.NoError(t, err)
+		require.NoError(t, database.DB.Model(&sub).Update("restricted_posting", true).Error)
+		defer database.DB.Model(&sub).Update("restricted_posting", false)
+
+		count, err := service.PublishDue(at.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		assert.False(t, isListed(t, post.ID))
+
+		drafts, err := service.GetDrafts("draftauthor")
+		require.NoError(t, err)
+		require.Len(t, drafts, 1)
+		assert.Equal(t, models.PostStatusDraft, drafts[0].Status)
+		assert.Equal(t, "only approved submitters can post in this sub", drafts[0].PublishError)
+
+		_, err = service.Publish(fmt.Sprint(post.ID), "draftauthor")
+		assert.EqualError(t, err, "only approved submitters can post in this sub")
+	})
+
+	t.Run("authors must still belong to a private sub at publish time", func(t *testing.T) {
+		post, err := CreatePost("draftauthor", models.Post{Title: "Members only", Content: "Text", SubID: sub.ID, Status: models.PostStatusDraft})
+		require.NoError(t, err)
+		require.NoError(t, database.DB.Model(&sub).Update("private", true).Error)
+		defer database.DB.Model(&sub).Update("private", false)
+
+		_, err = service.Publish(fmt.Sprint(post.ID), "draftauthor")
+		assert.EqualError(t, err, "you must be a member to post in this sub")
+
+		require.NoError(t, database.DB.Create(&models.SubMembership{SubID: sub.ID, UserID: author.ID}).Error)
+		published, err := service.Publish(fmt.Sprint(post.ID), "draftauthor")
+		require.NoError(t, err)
+		assert.Equal(t, post.ID, published.ID)
+	})
+}
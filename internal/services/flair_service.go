@@ -0,0 +1,373 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// Limits applied when validating flair templates
+const (
+	maxFlairTextLength = 64
+	maxFlairsPerSub    = 50
+)
+
+// flairColorPattern accepts hex colors such as #FF4500
+var flairColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// FlairService handles sub flair templates and flair assignment on posts and memberships
+type FlairService struct {
+	flairRepo repositories.IFlairRepository
+	subRepo   repositories.ISubSettingsRepository
+	userRepo  repositories.IUserRepository
+}
+
+// NewFlairService creates a new flair service with dependency injection
+func NewFlairService(flairRepo repositories.IFlairRepository, subRepo repositories.ISubSettingsRepository, userRepo repositories.IUserRepository) *FlairService {
+	return &FlairService{
+		flairRepo: flairRepo,
+		subRepo:   subRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// GetFlairs lists a sub's flair templates, optionally only those of one type. A private sub's flair is only
+// listed for its members and moderators.
+func (s *FlairService) GetFlairs(subID, username, flairType string) ([]models.FlairResponse, error) {
+	if flairType != "" && flairType != models.FlairTypePost && flairType != models.FlairTypeUser {
+		return nil, errors.New("flair type must be post or user")
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Private {
+		user, err := s.userRepo.GetUserByUsername(username)
+		if err != nil {
+			return nil, err
+		}
+		isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		isMember, err := s.subRepo.IsMember(sub.ID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !isModerator && !isMember {
+			return nil, errors.New("you must be a member to view this sub")
+		}
+	}
+
+	flairs, err := s.flairRepo.GetFlairs(sub.ID, flairType)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []models.FlairResponse{}
+	for _, flair := range flairs {
+		responses = append(responses, *repositories.FormatFlair(flair))
+	}
+	return responses, nil
+}
+
+// CreateFlair adds a flair template to a sub (moderators only)
+func (s *FlairService) CreateFlair(subID, username string, req models.FlairRequest) (*models.FlairResponse, error) {
+	_, sub, err := s.getModeratedSub(subID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateFlairRequest(&req); err != nil {
+		return nil, err
+	}
+
+	count, err := s.flairRepo.CountFlairs(sub.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxFlairsPerSub {
+		return nil, fmt.Errorf("a sub can have at most %d flairs", maxFlairsPerSub)
+	}
+
+	flair := models.SubFlair{
+		SubID:           sub.ID,
+		Type:            req.Type,
+		Text:            req.Text,
+		TextColor:       req.TextColor,
+		BackgroundColor: req.BackgroundColor,
+		ModOnly:         req.ModOnly,
+	}
+	if err := s.flairRepo.CreateFlair(&flair); err != nil {
+		return nil, err
+	}
+
+	return repositories.FormatFlair(flair), nil
+}
+
+// UpdateFlair changes a flair template's text, colors and mod-only flag (moderators only)
+func (s *FlairService) UpdateFlair(subID, flairID, username string, req models.FlairRequest) (*models.FlairResponse, error) {
+	_, sub, err := s.getModeratedSub(subID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	flair, err := s.getSubFlair(sub.ID, flairID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A flair's type cannot change once it may be assigned
+	req.Type = flair.Type
+	if err := validateFlairRequest(&req); err != nil {
+		return nil, err
+	}
+
+	flair.Text = req.Text
+	flair.TextColor = req.TextColor
+	flair.BackgroundColor = req.BackgroundColor
+	flair.ModOnly = req.ModOnly
+	if err := s.flairRepo.UpdateFlair(flair); err != nil {
+		return nil, err
+	}
+
+	return repositories.FormatFlair(*flair), nil
+}
+
+// DeleteFlair removes a flair template and clears it wherever it is assigned (moderators only)
+func (s *FlairService) DeleteFlair(subID, flairID, username string) error {
+	_, sub, err := s.getModeratedSub(subID, username)
+	if err != nil {
+		return err
+	}
+
+	flair, err := s.getSubFlair(sub.ID, flairID)
+	if err != nil {
+		return err
+	}
+
+	return s.flairRepo.DeleteFlair(flair)
+}
+
+// AssignPostFlair sets or clears a post's flair (post author or sub moderators)
+func (s *FlairService) AssignPostFlair(postID, username string, req models.FlairAssignmentRequest) (*models.FlairResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	postIDUint, err := strconv.ParseUint(postID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid post ID")
+	}
+
+	post, err := s.flairRepo.GetPost(uint(postIDUint))
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.subRepo.GetSubByID(post.SubID)
+	if err != nil {
+		return nil, err
+	}
+
+	isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != user.ID && !isModerator {
+		return nil, errors.New("only the post author or a moderator can change post flair")
+	}
+
+	flair, err := s.checkAssignableFlair(sub.ID, req.FlairID, models.FlairTypePost, isModerator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.flairRepo.SetPostFlair(post, req.FlairID); err != nil {
+		return nil, err
+	}
+
+	return flair, nil
+}
+
+// AssignMemberFlair sets or clears a member's user flair; members can set their own, moderators anyone's
+func (s *FlairService) AssignMemberFlair(subID, memberUsername, username string, req models.FlairAssignmentRequest) (*models.FlairResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, err
+	}
+
+	isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := user
+	if memberUsername != user.Username {
+		if !isModerator {
+			return nil, errors.New("only moderators can change another member's flair")
+		}
+		if member, err = s.userRepo.GetUserByUsername(memberUsername); err != nil {
+			return nil, err
+		}
+	}
+
+	membership, err := s.flairRepo.GetMembership(sub.ID, member.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	flair, err := s.checkAssignableFlair(sub.ID, req.FlairID, models.FlairTypeUser, isModerator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.flairRepo.SetMemberFlair(membership, req.FlairID); err != nil {
+		return nil, err
+	}
+
+	return flair, nil
+}
+
+// CheckPostFlair verifies the flair chosen for a new post can be used by its author
+func (s *FlairService) CheckPostFlair(username string, post models.Post) error {
+	if post.FlairID == nil {
+		return nil
+	}
+
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.subRepo.GetSubByID(post.SubID)
+	if err != nil {
+		return err
+	}
+
+	isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.checkAssignableFlair(sub.ID, post.FlairID, models.FlairTypePost, isModerator)
+	return err
+}
+
+// checkAssignableFlair verifies a flair belongs to the sub, has the right type and, if mod-only, is set by a moderator.
+// A nil flair ID clears the flair and is always allowed.
+func (s *FlairService) checkAssignableFlair(subID uint, flairID *uint, flairType string, isModerator bool) (*models.FlairResponse, error) {
+	if flairID == nil {
+		return nil, nil
+	}
+
+	flair, err := s.flairRepo.GetFlairByID(*flairID)
+	if err != nil {
+		return nil, err
+	}
+	if flair.SubID != subID {
+		return nil, errors.New("flair not found")
+	}
+	if flair.Type != flairType {
+		return nil, fmt.Errorf("this flair cannot be used as %s flair", flairType)
+	}
+	if flair.ModOnly && !isModerator {
+		return nil, errors.New("only moderators can assign this flair")
+	}
+
+	return repositories.FormatFlair(*flair), nil
+}
+
+func (s *FlairService) getSub(subID string) (*models.Sub, error) {
+	subIDUint, err := strconv.ParseUint(subID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid sub ID")
+	}
+
+	return s.subRepo.GetSubByID(uint(subIDUint))
+}
+
+func (s *FlairService) getSubFlair(subID uint, flairID string) (*models.SubFlair, error) {
+	flairIDUint, err := strconv.ParseUint(flairID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid flair ID")
+	}
+
+	flair, err := s.flairRepo.GetFlairByID(uint(flairIDUint))
+	if err != nil {
+		return nil, err
+	}
+	if flair.SubID != subID {
+		return nil, errors.New("flair not found")
+	}
+	return flair, nil
+}
+
+// getModeratedSub loads the sub and verifies the user moderates it
+func (s *FlairService) getModeratedSub(subID, username string) (*models.User, *models.Sub, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isModerator {
+		return nil, nil, errors.New("only moderators can manage flair")
+	}
+
+	return user, sub, nil
+}
+
+// validateFlairRequest validates a flair template and fills in default colors
+func validateFlairRequest(req *models.FlairRequest) error {
+	req.Type = strings.ToLower(strings.TrimSpace(req.Type))
+	if req.Type != models.FlairTypePost && req.Type != models.FlairTypeUser {
+		return errors.New("flair type must be post or user")
+	}
+
+	req.Text = strings.TrimSpace(req.Text)
+	if req.Text == "" {
+		return errors.New("flair text is required")
+	}
+	if len(req.Text) > maxFlairTextLength {
+		return fmt.Errorf("flair text must be %d characters or less", maxFlairTextLength)
+	}
+
+	if req.TextColor == "" {
+		req.TextColor = "#000000"
+	}
+	if req.BackgroundColor == "" {
+		req.BackgroundColor = "#EDEFF1"
+	}
+	if !flairColorPattern.MatchString(req.TextColor) || !flairColorPattern.MatchString(req.BackgroundColor) {
+		return errors.New("flair colors must be hex colors like #FF4500")
+	}
+
+	return nil
+}
+
+// CheckPostFlair checks a new post's flair using the default repositories
+func CheckPostFlair(username string, post models.Post) error {
+	service := NewFlairService(repositories.NewFlairRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+	return service.CheckPostFlair(username, post)
+}
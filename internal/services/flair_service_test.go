@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFlairRequest(t *testing.T) {
+	t.Run("defaults colors", func(t *testing.T) {
+		req := models.FlairRequest{Type: "Post", Text: " Question "}
+		assert.NoError(t, validateFlairRequest(&req))
+		assert.Equal(t, models.FlairTypePost, req.Type)
+		assert.Equal(t, "Question", req.Text)
+		assert.Equal(t, "#000000", req.TextColor)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		req := models.FlairRequest{Type: "comment", Text: "Nope"}
+		assert.EqualError(t, validateFlairRequest(&req), "flair type must be post or user")
+	})
+
+	t.Run("missing text", func(t *testing.T) {
+		req := models.FlairRequest{Type: models.FlairTypeUser}
+		assert.EqualError(t, validateFlairRequest(&req), "flair text is required")
+	})
+
+	t.Run("invalid color", func(t *testing.T) {
+		req := models.FlairRequest{Type: models.FlairTypeUser, Text: "Mod", BackgroundColor: "red"}
+		assert.Error(t, validateFlairRequest(&req))
+	})
+}
+
+func TestFlairService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_flairs")
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "flairowner", Password: "password"}
+	member := models.User{Username: "flairmember", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&member)
+
+	sub := models.Sub{Name: "flairsub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+	database.DB.Create(&models.SubMembership{SubID: sub.ID, UserID: member.ID})
+
+	post := models.Post{Title: "Flaired", Content: "Content", SubID: sub.ID, UserID: member.ID}
+	database.DB.Create(&post)
+
+	service := NewFlairService(repositories.NewFlairRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+	subID := fmt.Sprintf("%d", sub.ID)
+
+	t.Run("members cannot manage flair", func(t *testing.T) {
+		_, err := service.CreateFlair(subID, "flairmember", models.FlairRequest{Type: models.FlairTypePost, Text: "Question"})
+		assert.EqualError(t, err, "only moderators can manage flair")
+	})
+
+	question, err := service.CreateFlair(subID, "flairowner", models.FlairRequest{Type: models.FlairTypePost, Text: "Question"})
+	assert.NoError(t, err)
+	announcement, err := service.CreateFlair(subID, "flairowner", models.FlairRequest{Type: models.FlairTypePost, Text: "Announcement", ModOnly: true})
+	assert.NoError(t, err)
+	userFlair, err := service.CreateFlair(subID, "flairowner", models.FlairRequest{Type: models.FlairTypeUser, Text: "Regular"})
+	assert.NoError(t, err)
+
+	t.Run("author assigns post flair", func(t *testing.T) {
+		flair, err := service.AssignPostFlair(fmt.Sprintf("%d", post.ID), "flairmember", models.FlairAssignmentRequest{FlairID: &question.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, "Question", flair.Text)
+
+		posts, err := ListSubPosts(subID, "flairmember", "question", "")
+		assert.NoError(t, err)
+		assert.Len(t, *posts, 1)
+		assert.Equal(t, question.ID, (*posts)[0].Flair.ID)
+
+		posts, err = ListSubPosts(subID, "flairmember", fmt.Sprintf("%d", announcement.ID), "")
+		assert.NoError(t, err)
+		assert.Empty(t, *posts)
+	})
+
+	t.Run("mod-only flair needs a moderator", func(t *testing.T) {
+		_, err := service.AssignPostFlair(fmt.Sprintf("%d", post.ID), "flairmember", models.FlairAssignmentRequest{FlairID: &announcement.ID})
+		assert.EqualError(t, err, "only moderators can assign this flair")
+
+		_, err = service.AssignPostFlair(fmt.Sprintf("%d", post.ID), "flairowner", models.FlairAssignmentRequest{FlairID: &announcement.ID})
+		assert.NoError(t, err)
+	})
+
+	t.Run("user flair cannot be used on posts", func(t *testing.T) {
+		_, err := service.AssignPostFlair(fmt.Sprintf("%d", post.ID), "flairowner", models.FlairAssignmentRequest{FlairID: &userFlair.ID})
+		assert.EqualError(t, err, "this flair cannot be used as post flair")
+	})
+
+	t.Run("member sets own flair", func(t *testing.T) {
+		_, err := service.AssignMemberFlair(subID, "flairmember", "flairmember", models.FlairAssignmentRequest{FlairID: &userFlair.ID})
+		assert.NoError(t, err)
+
+		members, err := GetSubMembers(subID, "flairmember")
+		assert.NoError(t, err)
+		assert.Equal(t, "Regular", members[0].Flair.Text)
+	})
+
+	t.Run("deleting a flair clears assignments", func(t *testing.T) {
+		assert.NoError(t, service.DeleteFlair(subID, fmt.Sprintf("%d", userFlair.ID), "flairowner"))
+
+		members, err := GetSubMembers(subID, "flairmember")
+		assert.NoError(t, err)
+		assert.Nil(t, members[0].Flair)
+	})
+
+	t.Run("private sub flair is for members only", func(t *testing.T) {
+		outsider := models.User{Username: "flairoutsider", Password: "password"}
+		database.DB.Create(&outsider)
+		private := models.Sub{Name: "flairprivate", OwnerID: owner.ID, Private: true}
+		database.DB.Create(&private)
+		database.DB.Create(&models.SubMembership{SubID: private.ID, UserID: member.ID})
+		privateID := fmt.Sprintf("%d", private.ID)
+
+		_, err := service.GetFlairs(privateID, "flairoutsider", "")
+		assert.EqualError(t, err, "you must be a member to view this sub")
+
+		_, err = service.GetFlairs(privateID, "flairmember", "")
+		assert.NoError(t, err)
+		_, err = service.GetFlairs(privateID, "flairowner", "")
+		assert.NoError(t, err)
+	})
+}
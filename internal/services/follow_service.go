@@ -0,0 +1,193 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// FollowService handles following users, follow requests for private users and the following feed
+type FollowService struct {
+	followRepo       repositories.IFollowRepository
+	userRepo         repositories.IUserRepository
+	notificationRepo repositories.INotificationRepository
+	visibility       *ProfileVisibilityPolicy
+}
+
+// NewFollowService creates a new follow service with dependency injection
+func NewFollowService(followRepo repositories.IFollowRepository, userRepo repositories.IUserRepository, notificationRepo repositories.INotificationRepository) *FollowService {
+	return &FollowService{
+		followRepo:       followRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		visibility:       NewProfileVisibilityPolicy(repositories.NewBlockRepository(), followRepo),
+	}
+}
+
+// Follow follows a user; following a private user sends them a follow request instead
+func (s *FollowService) Follow(username, targetUsername string) (*models.FollowStatusResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.userRepo.GetUserByUsername(targetUsername)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == target.ID {
+		return nil, errors.New("you cannot follow yourself")
+	}
+	if err := CheckCanInteract(username, target.ID); err != nil {
+		return nil, err
+	}
+
+	if existing, err := s.followRepo.GetFollow(user.ID, target.ID); err == nil {
+		if existing.Status == models.FollowStatusPending {
+			return nil, errors.New("follow request already pending")
+		}
+		return nil, errors.New("you already follow this user")
+	}
+
+	follow := &models.UserFollow{FollowerID: user.ID, FollowingID: target.ID, Status: models.FollowStatusAccepted}
+	notification := &models.Notification{
+		UserID:  target.ID,
+		Type:    models.NotificationTypeNewFollower,
+		Message: fmt.Sprintf("%s started following you", user.Username),
+	}
+	if target.IsPrivate {
+		follow.Status = models.FollowStatusPending
+		notification.Type = models.NotificationTypeFollowRequest
+		notification.Message = fmt.Sprintf("%s wants to follow you", user.Username)
+	}
+
+	if err := s.followRepo.CreateFollow(follow); err != nil {
+		return nil, err
+	}
+
+	notifyBestEffort(s.notificationRepo.CreateNotification(notification), "send follow notification")
+
+	return &models.FollowStatusResponse{Username: target.Username, Status: follow.Status}, nil
+}
+
+// Unfollow stops following a user, or withdraws a pending follow request
+func (s *FollowService) Unfollow(username, targetUsername string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+	target, err := s.userRepo.GetUserByUsername(targetUsername)
+	if err != nil {
+		return err
+	}
+
+	follow, err := s.followRepo.GetFollow(user.ID, target.ID)
+	if err != nil {
+		return errors.New("you do not follow this user")
+	}
+
+	return s.followRepo.DeleteFollow(follow)
+}
+
+// GetFollowers returns one page of a user's followers; a private user's list is only visible to them and their followers
+func (s *FollowService) GetFollowers(targetUsername, viewerUsername string, page, limit int) (*models.FollowListResponse, error) {
+	target, err := s.getVisibleTarget(targetUsername, viewerUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.followRepo.GetFollowers(target.ID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FollowListResponse{Users: users, Page: page, Limit: limit, Total: total}, nil
+}
+
+// GetFollowing returns one page of the users someone follows, with the same visibility rules as GetFollowers
+func (s *FollowService) GetFollowing(targetUsername, viewerUsername string, page, limit int) (*models.FollowListResponse, error) {
+	target, err := s.getVisibleTarget(targetUsername, viewerUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.followRepo.GetFollowing(target.ID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FollowListResponse{Users: users, Page: page, Limit: limit, Total: total}, nil
+}
+
+// GetFollowRequests lists pending requests to follow the current user
+func (s *FollowService) GetFollowRequests(username string) ([]models.FollowRequestResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.followRepo.GetPendingRequests(user.ID)
+}
+
+// RespondToFollowRequest approves or denies a pending request to follow the current user
+func (s *FollowService) RespondToFollowRequest(username, requestID string, approve bool) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	requestIDUint, err := strconv.ParseUint(requestID, 10, 64)
+	if err != nil {
+		return errors.New("invalid request ID")
+	}
+
+	follow, err := s.followRepo.GetFollowByID(uint(requestIDUint))
+	if err != nil {
+		return err
+	}
+	if follow.FollowingID != user.ID || follow.Status != models.FollowStatusPending {
+		return errors.New("follow request not found")
+	}
+
+	if !approve {
+		return s.followRepo.DeleteFollow(follow)
+	}
+	return s.followRepo.AcceptFollow(follow)
+}
+
+// GetFeed returns one page of posts by the users the current user follows
+func (s *FollowService) GetFeed(username string, page, limit int) ([]models.PostResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.followRepo.GetFollowedPosts(user.ID, (page-1)*limit, limit)
+}
+
+// getVisibleTarget loads the user whose follow lists are requested and checks the profile visibility policy
+// lets the viewer see them
+func (s *FollowService) getVisibleTarget(targetUsername, viewerUsername string) (*models.User, error) {
+	target, err := s.userRepo.GetUserByUsername(targetUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	// Viewers who can't be found are treated as logged out
+	var viewerID *uint
+	if viewer, err := s.userRepo.GetUserByUsername(viewerUsername); err == nil {
+		viewerID = &viewer.ID
+	}
+
+	visibility, err := s.visibility.For(target, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if visibility.Relationship == RelationshipBlocked {
+		return nil, errors.New("you cannot interact with this user")
+	}
+	if !visibility.Follows {
+		return nil, errors.New("this user's follows are private")
+	}
+	return target, nil
+}
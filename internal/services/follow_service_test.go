@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM user_follows")
+	database.DB.Exec("DELETE FROM notifications")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	alice := models.User{Username: "followalice", Password: "password"}
+	bob := models.User{Username: "followbob", Password: "password"}
+	carol := models.User{Username: "followcarol", Password: "password", IsPrivate: true}
+	database.DB.Create(&alice)
+	database.DB.Create(&bob)
+	database.DB.Create(&carol)
+
+	service := NewFollowService(repositories.NewFollowRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository())
+
+	t.Run("cannot follow yourself", func(t *testing.T) {
+		_, err := service.Follow("followalice", "followalice")
+		assert.EqualError(t, err, "you cannot follow yourself")
+	})
+
+	t.Run("following a public user is immediate", func(t *testing.T) {
+		status, err := service.Follow("followalice", "followbob")
+		assert.NoError(t, err)
+		assert.Equal(t, models.FollowStatusAccepted, status.Status)
+
+		_, err = service.Follow("followalice", "followbob")
+		assert.EqualError(t, err, "you already follow this user")
+
+		profile, err := GetUserProfile("followbob", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), profile.FollowerCount)
+
+		followers, err := service.GetFollowers("followbob", "followcarol", 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), followers.Total)
+		assert.Equal(t, "followalice", followers.Users[0].Username)
+	})
+
+	t.Run("following a private user needs approval", func(t *testing.T) {
+		status, err := service.Follow("followalice", "followcarol")
+		assert.NoError(t, err)
+		assert.Equal(t, models.FollowStatusPending, status.Status)
+
+		_, err = service.GetFollowers("followcarol", "followalice", 1, 20)
+		assert.EqualError(t, err, "this user's follows are private")
+
+		requests, err := service.GetFollowRequests("followcarol")
+		assert.NoError(t, err)
+		assert.Len(t, requests, 1)
+
+		err = service.RespondToFollowRequest("followbob", fmt.Sprintf("%d", requests[0].ID), true)
+		assert.EqualError(t, err, "follow request not found")
+
+		assert.NoError(t, service.RespondToFollowRequest("followcarol", fmt.Sprintf("%d", requests[0].ID), true))
+
+		followers, err := service.GetFollowers("followcarol", "followalice", 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), followers.Total)
+	})
+
+	t.Run("feed shows followed users' posts in visible subs", func(t *testing.T) {
+		publicSub := models.Sub{Name: "followpublic", OwnerID: carol.ID}
+		privateSub := models.Sub{Name: "followprivate", OwnerID: carol.ID, Private: true}
+		database.DB.Create(&publicSub)
+		database.DB.Create(&privateSub)
+		database.DB.Create(&models.Post{Title: "Visible", Content: "x", SubID: publicSub.ID, UserID: bob.ID})
+		database.DB.Create(&models.Post{Title: "Hidden", Content: "x", SubID: privateSub.ID, UserID: bob.ID})
+
+		posts, err := service.GetFeed("followalice", 1, 20)
+		assert.NoError(t, err)
+		assert.Len(t, posts, 1)
+		assert.Equal(t, "Visible", posts[0].Title)
+	})
+
+	t.Run("unfollow", func(t *testing.T) {
+		assert.NoError(t, service.Unfollow("followalice", "followbob"))
+		assert.EqualError(t, service.Unfollow("followalice", "followbob"), "you do not follow this user")
+	})
+}
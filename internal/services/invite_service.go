@@ -0,0 +1,223 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// maxInviteLinkHours caps how long a shareable invite link can stay valid (30 days)
+const maxInviteLinkHours = 30 * 24
+
+// InviteService handles the sub invitation lifecycle and shareable invite links
+type InviteService struct {
+	inviteRepo repositories.IInviteRepository
+	subRepo    repositories.ISubSettingsRepository
+	userRepo   repositories.IUserRepository
+}
+
+// NewInviteService creates a new invite service with dependency injection
+func NewInviteService(inviteRepo repositories.IInviteRepository, subRepo repositories.ISubSettingsRepository, userRepo repositories.IUserRepository) *InviteService {
+	return &InviteService{
+		inviteRepo: inviteRepo,
+		subRepo:    subRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// GetMyInvites lists the pending, unexpired invitations addressed to the user
+func (s *InviteService) GetMyInvites(username string) ([]models.UserInviteResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.inviteRepo.GetPendingInvitesForUser(user.ID)
+}
+
+// DeclineInvite rejects a pending invitation (invitee only)
+func (s *InviteService) DeclineInvite(inviteID, username string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := s.getInvitation(inviteID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.InviteeID != user.ID {
+		return errors.New("you are not the invitee for this invitation")
+	}
+	if invitation.Status != models.InviteStatusPending {
+		return errors.New("invitation is no longer pending")
+	}
+
+	return s.inviteRepo.UpdateInvitationStatus(invitation, models.InviteStatusRejected)
+}
+
+// RevokeInvite withdraws a pending invitation (sub owner or the original inviter)
+func (s *InviteService) RevokeInvite(subID, inviteID, username string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := s.getInvitation(inviteID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.SubID != sub.ID {
+		return errors.New("invitation not found")
+	}
+	if sub.OwnerID != user.ID && invitation.InviterID != user.ID {
+		return errors.New("only the sub owner or inviter can revoke this invitation")
+	}
+	if invitation.Status != models.InviteStatusPending {
+		return errors.New("invitation is no longer pending")
+	}
+
+	return s.inviteRepo.UpdateInvitationStatus(invitation, models.InviteStatusRevoked)
+}
+
+// CreateInviteLink creates a single-use shareable link for a private sub (owner only)
+func (s *InviteService) CreateInviteLink(subID, username string, req models.InviteLinkRequest) (*models.InviteLinkResponse, error) {
+	user, sub, err := s.getOwnedSub(subID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sub.Private {
+		return nil, errors.New("invite links are only available for private subs")
+	}
+
+	expiresIn := repositories.InviteExpiry()
+	if req.ExpiresInHours != 0 {
+		if req.ExpiresInHours < 1 || req.ExpiresInHours > maxInviteLinkHours {
+			return nil, errors.New("invite link expiry must be between 1 and 720 hours")
+		}
+		expiresIn = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	link := models.SubInviteLink{
+		SubID:     sub.ID,
+		CreatorID: user.ID,
+		ExpiresAt: time.Now().Add(expiresIn),
+	}
+	if err := s.inviteRepo.CreateInviteLink(&link); err != nil {
+		return nil, err
+	}
+
+	return formatInviteLink(link), nil
+}
+
+// GetInviteLinks lists a sub's unused, unexpired invite links (owner only)
+func (s *InviteService) GetInviteLinks(subID, username string) ([]models.InviteLinkResponse, error) {
+	_, sub, err := s.getOwnedSub(subID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.inviteRepo.GetActiveInviteLinks(sub.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []models.InviteLinkResponse{}
+	for _, link := range links {
+		responses = append(responses, *formatInviteLink(link))
+	}
+	return responses, nil
+}
+
+// RevokeInviteLink disables an invite link before it is used (owner only)
+func (s *InviteService) RevokeInviteLink(subID, linkID, username string) error {
+	_, sub, err := s.getOwnedSub(subID, username)
+	if err != nil {
+		return err
+	}
+
+	linkIDUint, err := strconv.ParseUint(linkID, 10, 64)
+	if err != nil {
+		return errors.New("invalid invite link ID")
+	}
+
+	link, err := s.inviteRepo.GetInviteLinkByID(uint(linkIDUint))
+	if err != nil {
+		return err
+	}
+	if link.SubID != sub.ID {
+		return errors.New("invite link not found")
+	}
+	if link.UsedAt != nil || link.RevokedAt != nil {
+		return errors.New("invite link is no longer valid")
+	}
+
+	return s.inviteRepo.RevokeInviteLink(link)
+}
+
+// RedeemInviteLink joins the user to the link's sub and consumes the link
+func (s *InviteService) RedeemInviteLink(token, username string) (*models.SubMembership, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.inviteRepo.RedeemInviteLink(token, user.ID)
+}
+
+func (s *InviteService) getInvitation(inviteID string) (*models.SubInvitation, error) {
+	inviteIDUint, err := strconv.ParseUint(inviteID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid invitation ID")
+	}
+
+	return s.inviteRepo.GetInvitationByID(uint(inviteIDUint))
+}
+
+func (s *InviteService) getSub(subID string) (*models.Sub, error) {
+	subIDUint, err := strconv.ParseUint(subID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid sub ID")
+	}
+
+	return s.subRepo.GetSubByID(uint(subIDUint))
+}
+
+func (s *InviteService) getOwnedSub(subID, username string) (*models.User, *models.Sub, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if sub.OwnerID != user.ID {
+		return nil, nil, errors.New("only the sub owner can manage invite links")
+	}
+
+	return user, sub, nil
+}
+
+func formatInviteLink(link models.SubInviteLink) *models.InviteLinkResponse {
+	return &models.InviteLinkResponse{
+		ID:        link.ID,
+		Token:     link.Token,
+		ExpiresAt: link.ExpiresAt.Format("2006-01-02 15:04:05"),
+		Used:      link.UsedAt != nil,
+		CreatedAt: link.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInviteService_Lifecycle(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_invite_links")
+	database.DB.Exec("DELETE FROM sub_invitations")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "lifecycleowner", Password: "password"}
+	invitee := models.User{Username: "lifecycleinvitee", Password: "password"}
+	other := models.User{Username: "lifecycleother", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&invitee)
+	database.DB.Create(&other)
+
+	private := models.Sub{Name: "lifecycleprivate", OwnerID: owner.ID, Private: true}
+	public := models.Sub{Name: "lifecyclepublic", OwnerID: owner.ID}
+	database.DB.Create(&private)
+	database.DB.Create(&public)
+
+	service := NewInviteService(repositories.NewInviteRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+	subID := fmt.Sprintf("%d", private.ID)
+
+	t.Run("invitee declines an invitation", func(t *testing.T) {
+		err := repositories.InviteUser(subID, "lifecycleowner", models.InviteRequest{InviteeUsername: "lifecycleinvitee"})
+		assert.NoError(t, err)
+
+		var invitation models.SubInvitation
+		database.DB.Where("invitee_id = ? AND status = ?", invitee.ID, models.InviteStatusPending).First(&invitation)
+
+		invites, err := service.GetMyInvites("lifecycleinvitee")
+		assert.NoError(t, err)
+		assert.Len(t, invites, 1)
+
+		inviteID := fmt.Sprintf("%d", invitation.ID)
+		assert.EqualError(t, service.DeclineInvite(inviteID, "lifecycleother"), "you are not the invitee for this invitation")
+		assert.NoError(t, service.DeclineInvite(inviteID, "lifecycleinvitee"))
+		assert.EqualError(t, service.DeclineInvite(inviteID, "lifecycleinvitee"), "invitation is no longer pending")
+	})
+
+	t.Run("owner revokes an invitation", func(t *testing.T) {
+		err := repositories.InviteUser(subID, "lifecycleowner", models.InviteRequest{InviteeUsername: "lifecycleinvitee"})
+		assert.NoError(t, err)
+
+		var invitation models.SubInvitation
+		database.DB.Where("invitee_id = ? AND status = ?", invitee.ID, models.InviteStatusPending).First(&invitation)
+
+		inviteID := fmt.Sprintf("%d", invitation.ID)
+		assert.EqualError(t, service.RevokeInvite(subID, inviteID, "lifecycleother"), "only the sub owner or inviter can revoke this invitation")
+		assert.NoError(t, service.RevokeInvite(subID, inviteID, "lifecycleowner"))
+		assert.EqualError(t, repositories.AcceptInvite(inviteID, "lifecycleinvitee"), "invitation is no longer pending")
+	})
+
+	t.Run("invite links require a private sub", func(t *testing.T) {
+		_, err := service.CreateInviteLink(fmt.Sprintf("%d", public.ID), "lifecycleowner", models.InviteLinkRequest{})
+		assert.EqualError(t, err, "invite links are only available for private subs")
+	})
+
+	t.Run("invite link expiry is bounded", func(t *testing.T) {
+		_, err := service.CreateInviteLink(subID, "lifecycleowner", models.InviteLinkRequest{ExpiresInHours: maxInviteLinkHours + 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("owner creates and revokes an invite link", func(t *testing.T) {
+		link, err := service.CreateInviteLink(subID, "lifecycleowner", models.InviteLinkRequest{ExpiresInHours: 24})
+		assert.NoError(t, err)
+
+		links, err := service.GetInviteLinks(subID, "lifecycleowner")
+		assert.NoError(t, err)
+		assert.Len(t, links, 1)
+
+		assert.NoError(t, service.RevokeInviteLink(subID, fmt.Sprintf("%d", link.ID), "lifecycleowner"))
+		_, err = service.RedeemInviteLink(link.Token, "lifecycleother")
+		assert.EqualError(t, err, "invite link is no longer valid")
+	})
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// maxJoinRequestMessageLength limits the optional message sent with a join request
+const maxJoinRequestMessageLength = 500
+
+// JoinRequestService handles requests to join private subs and their moderation queue
+type JoinRequestService struct {
+	joinRepo         repositories.IJoinRequestRepository
+	subRepo          repositories.ISubSettingsRepository
+	userRepo         repositories.IUserRepository
+	notificationRepo repositories.INotificationRepository
+}
+
+// NewJoinRequestService creates a new join request service with dependency injection
+func NewJoinRequestService(joinRepo repositories.IJoinRequestRepository, subRepo repositories.ISubSettingsRepository, userRepo repositories.IUserRepository, notificationRepo repositories.INotificationRepository) *JoinRequestService {
+	return &JoinRequestService{
+		joinRepo:         joinRepo,
+		subRepo:          subRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// RequestToJoin submits a join request for a private sub that accepts them
+func (s *JoinRequestService) RequestToJoin(subID, username string, req models.JoinRequestRequest) (*models.JoinRequestResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sub.Private {
+		return nil, errors.New("this sub is public and can be joined directly")
+	}
+	if sub.JoinMode != models.JoinModeRequest {
+		return nil, errors.New("this sub is invite-only")
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if len(message) > maxJoinRequestMessageLength {
+		return nil, fmt.Errorf("message must be %d characters or less", maxJoinRequestMessageLength)
+	}
+
+	isMember, err := s.subRepo.IsMember(sub.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if isMember || sub.OwnerID == user.ID {
+		return nil, errors.New("you are already a member of this sub")
+	}
+
+	pending, err := s.joinRepo.HasPendingJoinRequest(sub.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, errors.New("you already have a pending join request for this sub")
+	}
+
+	request := models.SubJoinRequest{
+		SubID:   sub.ID,
+		UserID:  user.ID,
+		Message: message,
+		User:    *user,
+	}
+	if err := s.joinRepo.CreateJoinRequest(&request); err != nil {
+		return nil, err
+	}
+
+	return formatJoinRequest(request), nil
+}
+
+// GetJoinRequests lists a sub's pending join requests (moderators only)
+func (s *JoinRequestService) GetJoinRequests(subID, username string) ([]models.JoinRequestResponse, error) {
+	_, sub, err := s.getModeratedSub(subID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := s.joinRepo.GetPendingJoinRequests(sub.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []models.JoinRequestResponse{}
+	for _, request := range requests {
+		responses = append(responses, *formatJoinRequest(request))
+	}
+	return responses, nil
+}
+
+// ReviewJoinRequest approves or denies a pending join request (moderators only) and notifies the requester
+func (s *JoinRequestService) ReviewJoinRequest(subID, requestID, username string, approve bool) (*models.JoinRequestResponse, error) {
+	moderator, sub, err := s.getModeratedSub(subID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	requestIDUint, err := strconv.ParseUint(requestID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid join request ID")
+	}
+
+	request, err := s.joinRepo.GetJoinRequestByID(uint(requestIDUint))
+	if err != nil {
+		return nil, err
+	}
+	if request.SubID != sub.ID {
+		return nil, errors.New("join request not found")
+	}
+	if request.Status != models.JoinRequestStatusPending {
+		return nil, errors.New("join request is no longer pending")
+	}
+
+	notification := models.Notification{
+		UserID: request.UserID,
+		SubID:  &sub.ID,
+	}
+	if approve {
+		if err := s.joinRepo.ApproveJoinRequest(request, moderator.ID); err != nil {
+			return nil, err
+		}
+		notification.Type = models.NotificationTypeJoinRequestApproved
+		notification.Message = fmt.Sprintf("Your request to join %s was approved", sub.Name)
+	} else {
+		if err := s.joinRepo.DenyJoinRequest(request, moderator.ID); err != nil {
+			return nil, err
+		}
+		notification.Type = models.NotificationTypeJoinRequestDenied
+		notification.Message = fmt.Sprintf("Your request to join %s was denied", sub.Name)
+	}
+
+	notifyBestEffort(s.notificationRepo.CreateNotification(&notification), "send join request notification")
+
+	return formatJoinRequest(*request), nil
+}
+
+func (s *JoinRequestService) getSub(subID string) (*models.Sub, error) {
+	subIDUint, err := strconv.ParseUint(subID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid sub ID")
+	}
+
+	return s.subRepo.GetSubByID(uint(subIDUint))
+}
+
+// getModeratedSub loads the sub and verifies the user moderates it
+func (s *JoinRequestService) getModeratedSub(subID, username string) (*models.User, *models.Sub, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isModerator {
+		return nil, nil, errors.New("only moderators can review join requests")
+	}
+
+	return user, sub, nil
+}
+
+func formatJoinRequest(request models.SubJoinRequest) *models.JoinRequestResponse {
+	return &models.JoinRequestResponse{
+		ID:        request.ID,
+		Username:  request.User.Username,
+		Message:   request.Message,
+		Status:    request.Status,
+		CreatedAt: request.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
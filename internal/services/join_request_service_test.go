@@ -0,0 +1,81 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinRequestService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM notifications")
+	database.DB.Exec("DELETE FROM sub_join_requests")
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "joinowner", Password: "password"}
+	moderator := models.User{Username: "joinmoderator", Password: "password"}
+	requester := models.User{Username: "joinrequester", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&moderator)
+	database.DB.Create(&requester)
+
+	requestSub := models.Sub{Name: "joinrequestsub", OwnerID: owner.ID, Private: true, JoinMode: models.JoinModeRequest}
+	inviteOnly := models.Sub{Name: "joininviteonly", OwnerID: owner.ID, Private: true}
+	database.DB.Create(&requestSub)
+	database.DB.Create(&inviteOnly)
+
+	settingsService := NewSubSettingsService(repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+	service := NewJoinRequestService(repositories.NewJoinRequestRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository())
+	subID := fmt.Sprintf("%d", requestSub.ID)
+
+	t.Run("invite-only subs reject requests", func(t *testing.T) {
+		_, err := service.RequestToJoin(fmt.Sprintf("%d", inviteOnly.ID), "joinrequester", models.JoinRequestRequest{})
+		assert.EqualError(t, err, "this sub is invite-only")
+	})
+
+	t.Run("only one pending request per user", func(t *testing.T) {
+		request, err := service.RequestToJoin(subID, "joinrequester", models.JoinRequestRequest{Message: "Hello"})
+		assert.NoError(t, err)
+		assert.Equal(t, models.JoinRequestStatusPending, request.Status)
+
+		_, err = service.RequestToJoin(subID, "joinrequester", models.JoinRequestRequest{})
+		assert.EqualError(t, err, "you already have a pending join request for this sub")
+	})
+
+	t.Run("non-moderators cannot review the queue", func(t *testing.T) {
+		_, err := service.GetJoinRequests(subID, "joinmoderator")
+		assert.EqualError(t, err, "only moderators can review join requests")
+	})
+
+	t.Run("moderator approves and requester is notified", func(t *testing.T) {
+		assert.NoError(t, settingsService.AddModerator(subID, "joinowner", "joinmoderator"))
+
+		requests, err := service.GetJoinRequests(subID, "joinmoderator")
+		assert.NoError(t, err)
+		assert.Len(t, requests, 1)
+
+		reviewed, err := service.ReviewJoinRequest(subID, fmt.Sprintf("%d", requests[0].ID), "joinmoderator", true)
+		assert.NoError(t, err)
+		assert.Equal(t, models.JoinRequestStatusApproved, reviewed.Status)
+
+		var notification models.Notification
+		err = database.DB.Where("user_id = ?", requester.ID).First(&notification).Error
+		assert.NoError(t, err)
+		assert.Equal(t, models.NotificationTypeJoinRequestApproved, notification.Type)
+
+		_, err = service.RequestToJoin(subID, "joinrequester", models.JoinRequestRequest{})
+		assert.EqualError(t, err, "you are already a member of this sub")
+	})
+}
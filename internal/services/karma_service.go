@@ -0,0 +1,38 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// KarmaService keeps user karma consistent with the votes it is computed from
+type KarmaService struct {
+	karmaRepo repositories.IKarmaRepository
+}
+
+// NewKarmaService creates a new karma service with dependency injection
+func NewKarmaService(karmaRepo repositories.IKarmaRepository) *KarmaService {
+	return &KarmaService{karmaRepo: karmaRepo}
+}
+
+// Reconcile recomputes karma from votes and returns how many users' karma was corrected
+func (s *KarmaService) Reconcile() (int64, error) {
+	return s.karmaRepo.Reconcile()
+}
+
+// ReconcileKarma runs the karma reconciliation when the server starts and then daily. Votes update karma as
+// they change, so this only catches drift such as votes removed along with purged content.
+func ReconcileKarma() {
+	service := NewKarmaService(repositories.NewKarmaRepository())
+	for {
+		count, err := service.Reconcile()
+		if err != nil {
+			log.Println("Error reconciling karma:", err)
+		} else if count > 0 {
+			log.Println("Corrected karma for", count, "users.")
+		}
+		time.Sleep(24 * time.Hour)
+	}
+}
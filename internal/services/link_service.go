@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/unfurl"
+)
+
+const (
+	linkPreviewTTL        = 24 * time.Hour // How long an unfurled preview is reused before the page is fetched again
+	linkPreviewRetryAfter = 1 * time.Hour  // How long a failed fetch is remembered
+	linkWorkers           = 2
+	linkQueueSize         = 256
+)
+
+// linkQueue hands new links to the background workers. Links that don't fit are picked up by the next sweep.
+var linkQueue = make(chan string, linkQueueSize)
+
+// enqueueLink queues a link to be unfurled without waiting
+func enqueueLink(url string) {
+	select {
+	case linkQueue <- url:
+	default:
+	}
+}
+
+// defaultUnfurler fetches link previews for posts, refusing to connect to private addresses
+var defaultUnfurler = unfurl.New(unfurl.NewClient())
+
+// LinkService validates the links of link posts and unfurls them into cached previews
+type LinkService struct {
+	linkRepo repositories.ILinkRepository
+	unfurler *unfurl.Unfurler
+}
+
+// NewLinkService creates a new link service with dependency injection
+func NewLinkService(linkRepo repositories.ILinkRepository, unfurler *unfurl.Unfurler) *LinkService {
+	return &LinkService{
+		linkRepo: linkRepo,
+		unfurler: unfurler,
+	}
+}
+
+func newDefaultLinkService() *LinkService {
+	return NewLinkService(repositories.NewLinkRepository(), defaultUnfurler)
+}
+
+// ValidateLink checks the URL of a link post and returns it cleaned up, with the domain it is listed under
+func ValidateLink(rawURL string) (string, string, error) {
+	link, err := unfurl.ParseURL(rawURL)
+	if errors.Is(err, unfurl.ErrBlockedAddress) {
+		return "", "", errors.New("links to private addresses are not allowed")
+	}
+	if err != nil {
+		return "", "", errors.New("link must be a valid http or https URL")
+	}
+	link.Fragment = ""
+	return link.String(), repositories.NormalizeDomain(link.Hostname()), nil
+}
+
+// Unfurl returns the preview of a link, fetching the page unless a recent preview is cached. A failed fetch
+// keeps serving the preview found before, if there was one.
+func (s *LinkService) Unfurl(url string) (*models.LinkPreview, error) {
+	cached, err := s.linkRepo.GetLinkPreview(url)
+	if err == nil {
+		maxAge := linkPreviewTTL
+		if cached.Failed {
+			maxAge = linkPreviewRetryAfter
+		}
+		if time.Since(cached.FetchedAt) < maxAge {
+			return cached, nil
+		}
+	}
+
+	preview := models.LinkPreview{URL: url, FetchedAt: time.Now()}
+	result, fetchErr := s.unfurler.Unfurl(context.Background(), url)
+	switch {
+	case fetchErr == nil:
+		preview.Title = result.Title
+		preview.Description = result.Description
+		preview.SiteName = result.SiteName
+		preview.ThumbnailURL = result.ThumbnailURL
+	case cached != nil && !cached.Failed:
+		preview.Title = cached.Title
+		preview.Description = cached.Description
+		preview.SiteName = cached.SiteName
+		preview.ThumbnailURL = cached.ThumbnailURL
+	default:
+		preview.Failed = true
+	}
+
+	if err := s.linkRepo.SaveLinkPreview(&preview); err != nil {
+		return nil, err
+	}
+	if fetchErr != nil {
+		return &preview, errors.New("failed to unfurl link")
+	}
+	return &preview, nil
+}
+
+// UnfurlLinks starts the workers that fetch link previews in the background and regularly queues the links
+// of posts that haven't been unfurled yet
+func UnfurlLinks() {
+	linkRepo := repositories.NewLinkRepository()
+	service := NewLinkService(linkRepo, defaultUnfurler)
+	for i := 0; i < linkWorkers; i++ {
+		go func() {
+			for url := range linkQueue {
+				if _, err := service.Unfurl(url); err != nil {
+					log.Printf("Failed to unfurl %s: %v", url, err)
+				}
+			}
+		}()
+	}
+
+	for {
+		urls, err := linkRepo.GetPendingLinks()
+		if err != nil {
+			log.Println("Failed to fetch pending links:", err)
+		}
+		for _, url := range urls {
+			enqueueLink(url)
+		}
+		time.Sleep(1 * time.Minute)
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/unfurl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serverClient connects to a test server, whatever host a link points at
+func serverClient(server *httptest.Server) *http.Client {
+	dialer := &net.Dialer{}
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}}
+}
+
+func TestLinkService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM link_previews")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	user := models.User{Username: "linkuser", Password: "password"}
+	database.DB.Create(&user)
+	sub := models.Sub{Name: "linksub", OwnerID: user.ID}
+	database.DB.Create(&sub)
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if r.URL.Path == "/broken" {
+			http.Error(w, "down", http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<head><meta property="og:title" content="Linked article">
+			<meta property="og:description" content="What it is about"><meta property="og:image" content="/cover.jpg"></head>`))
+	}))
+	defer server.Close()
+
+	unfurler := unfurl.New(serverClient(server))
+	defaultUnfurler, unfurler = unfurler, defaultUnfurler
+	defer func() { defaultUnfurler = unfurler }()
+
+	linkRepo := repositories.NewLinkRepository()
+	service := NewLinkService(linkRepo, defaultUnfurler)
+
+	t.Run("validate links", func(t *testing.T) {
+		link, domain, err := ValidateLink(" https://WWW.Example.com/story?id=1#comments ")
+		require.NoError(t, err)
+		assert.Equal(t, "https://WWW.Example.com/story?id=1", link)
+		assert.Equal(t, "example.com", domain)
+
+		_, _, err = ValidateLink("javascript:alert(1)")
+		assert.EqualError(t, err, "link must be a valid http or https URL")
+		_, _, err = ValidateLink("http://169.254.169.254/latest/meta-data")
+		assert.EqualError(t, err, "links to private addresses are not allowed")
+	})
+
+	t.Run("previews are cached", func(t *testing.T) {
+		fetches.Store(0)
+		preview, err := service.Unfurl("http://news.example.com/a")
+		require.NoError(t, err)
+		assert.Equal(t, "Linked article", preview.Title)
+		assert.Equal(t, "http://news.example.com/cover.jpg", preview.ThumbnailURL)
+
+		_, err = service.Unfurl("http://news.example.com/a")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), fetches.Load())
+
+		// Stale previews are fetched again
+		database.DB.Model(&models.LinkPreview{}).Where("url = ?", "http://news.example.com/a").
+			Update("fetched_at", time.Now().Add(-2*linkPreviewTTL))
+		_, err = service.Unfurl("http://news.example.com/a")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), fetches.Load())
+	})
+
+	t.Run("failed fetches are cached", func(t *testing.T) {
+		fetches.Store(0)
+		_, err := service.Unfurl("http://news.example.com/broken")
+		assert.EqualError(t, err, "failed to unfurl link")
+		preview, err := service.Unfurl("http://news.example.com/broken")
+		require.NoError(t, err)
+		assert.True(t, preview.Failed)
+		assert.Equal(t, int32(1), fetches.Load())
+	})
+
+	t.Run("link posts", func(t *testing.T) {
+		link := "http://www.example.org/story"
+		post, err := CreatePost("linkuser", models.Post{Title: "Read this", SubID: sub.ID, URL: &link})
+		require.NoError(t, err)
+		assert.Equal(t, "example.org", post.Domain)
+		assert.Equal(t, models.PostTypeLink, post.PostType())
+
+		// The preview is pending until a worker unfurls the link
+		response, err := GetPostByID(fmt.Sprint(post.ID))
+		require.NoError(t, err)
+		require.NotNil(t, response.Link)
+		assert.True(t, response.Link.Pending)
+		pending, err := linkRepo.GetPendingLinks()
+		require.NoError(t, err)
+		assert.Contains(t, pending, link)
+
+		_, err = service.Unfurl(link)
+		require.NoError(t, err)
+		response, err = GetPostByID(fmt.Sprint(post.ID))
+		require.NoError(t, err)
+		require.NotNil(t, response.Link)
+		assert.Equal(t, models.LinkResponse{
+			URL:          link,
+			Domain:       "example.org",
+			Title:        "Linked article",
+			Description:  "What it is about",
+			ThumbnailURL: "http://www.example.org/cover.jpg",
+		}, *response.Link)
+
+		_, err = CreatePost("linkuser", models.Post{Title: "No text", SubID: sub.ID})
+		assert.EqualError(t, err, "content is required")
+		image := "https://example.org/cat.png"
+		_, err = CreatePost("linkuser", models.Post{Title: "Both", SubID: sub.ID, URL: &link, ImageURL: &image})
+		assert.EqualError(t, err, "a post can have a link or an image, not both")
+		private := "http://10.0.0.1/admin"
+		_, err = CreatePost("linkuser", models.Post{Title: "Local", SubID: sub.ID, URL: &private})
+		assert.EqualError(t, err, "links to private addresses are not allowed")
+	})
+
+	t.Run("listings filter by domain", func(t *testing.T) {
+		_, err := CreatePost("linkuser", models.Post{Title: "Text", Content: "Just text", SubID: sub.ID})
+		require.NoError(t, err)
+
+		posts, err := GetPosts("linkuser", "WWW.example.org")
+		require.NoError(t, err)
+		require.Len(t, *posts, 1)
+		assert.Equal(t, "Read this", (*posts)[0].Title)
+
+		posts, err = ListSubPosts(fmt.Sprint(sub.ID), "linkuser", "", "example.org")
+		require.NoError(t, err)
+		assert.Len(t, *posts, 1)
+
+		posts, err = ListSubPosts(fmt.Sprint(sub.ID), "linkuser", "", "")
+		require.NoError(t, err)
+		assert.Len(t, *posts, 2)
+	})
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/imaging"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/storage"
+)
+
+const (
+	mediaWorkers    = 2
+	mediaQueueSize  = 256
+	mediaStaleAfter = 10 * time.Minute // A claim this old belongs to a worker that died, so the upload is retried
+	blurHashX       = 4
+	blurHashY       = 3
+)
+
+// mediaRenditionSizes are the sizes every image is processed into, each fitting within a square of that many pixels
+var mediaRenditionSizes = []struct {
+	name string
+	size int
+}{
+	{models.RenditionThumbnail, 160},
+	{models.RenditionPreview, 640},
+	{models.RenditionFull, 2048},
+}
+
+// errNotAnImage is returned for uploads that can't be decoded, which are marked failed instead of retried
+var errNotAnImage = errors.New("media is not a valid image")
+
+// mediaQueue hands uploads to the background workers. Uploads that don't fit are left pending
+// and picked up by the next sweep.
+var mediaQueue = make(chan uint, mediaQueueSize)
+
+// enqueueMedia queues an upload for processing without waiting
+func enqueueMedia(mediaID uint) {
+	select {
+	case mediaQueue <- mediaID:
+	default:
+	}
+}
+
+// MediaProcessor turns uploads into renditions that are safe to serve: resized, turned upright and
+// re-encoded without EXIF or GPS metadata
+type MediaProcessor struct {
+	mediaRepo repositories.IMediaRepository
+	store     storage.BlobStore
+}
+
+// NewMediaProcessor creates a new media processor with dependency injection
+func NewMediaProcessor(mediaRepo repositories.IMediaRepository, store storage.BlobStore) *MediaProcessor {
+	return &MediaProcessor{
+		mediaRepo: mediaRepo,
+		store:     store,
+	}
+}
+
+// Process generates the renditions of an upload and records its dimensions and blurhash, then deletes
+// the upload. Uploads another worker is already processing are skipped.
+func (p *MediaProcessor) Process(mediaID uint) error {
+	claimed, err := p.mediaRepo.ClaimMedia(mediaID, time.Now().Add(-mediaStaleAfter))
+	if err != nil || !claimed {
+		return err
+	}
+
+	media, err := p.mediaRepo.GetMediaByID(mediaID)
+	if err != nil {
+		return err
+	}
+
+	// Other errors leave the claim in place, so the upload is retried once the claim goes stale
+	if err := p.process(media); err != nil {
+		if errors.Is(err, errNotAnImage) {
+			_ = p.mediaRepo.FailMedia(media.ID)
+		}
+		return err
+	}
+
+	// The renditions replace the upload, which may carry the location a photo was taken
+	_ = p.store.Delete(context.Background(), media.StorageKey)
+	return nil
+}
+
+// process decodes an upload and stores each of its renditions
+func (p *MediaProcessor) process(media *models.Media) error {
+	ctx := context.Background()
+
+	file, err := p.store.Get(ctx, media.StorageKey)
+	if err != nil {
+		return errors.New("failed to read media")
+	}
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return errors.New("failed to read media")
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errNotAnImage, err)
+	}
+
+	renditions := []models.MediaRendition{}
+	base := strings.TrimSuffix(media.StorageKey, path.Ext(media.StorageKey))
+	for _, size := range mediaRenditionSizes {
+		resized := imaging.Fit(img, size.size)
+		encoded, contentType, err := imaging.Encode(resized)
+		if err != nil {
+			return err
+		}
+
+		key := base + "_" + size.name + mediaExtensions[contentType]
+		if err := p.store.Put(ctx, key, contentType, bytes.NewReader(encoded), int64(len(encoded))); err != nil {
+			return errors.New("failed to store media")
+		}
+		renditions = append(renditions, models.MediaRendition{
+			Name:        size.name,
+			StorageKey:  key,
+			ContentType: contentType,
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+			Size:        int64(len(encoded)),
+		})
+	}
+
+	bounds := img.Bounds()
+	return p.mediaRepo.CompleteMedia(media.ID, bounds.Dx(), bounds.Dy(), imaging.BlurHash(img, blurHashX, blurHashY), renditions)
+}
+
+// ProcessMediaUploads runs the background image pipeline. Uploads are processed as soon as they are queued,
+// and every minute uploads still waiting, such as those queued before a restart, are queued again.
+func ProcessMediaUploads() {
+	store, err := storage.Default()
+	if err != nil {
+		log.Println("Media processing disabled:", err)
+		return
+	}
+
+	mediaRepo := repositories.NewMediaRepository()
+	processor := NewMediaProcessor(mediaRepo, store)
+	for i := 0; i < mediaWorkers; i++ {
+		go func() {
+			for mediaID := range mediaQueue {
+				if err := processor.Process(mediaID); err != nil {
+					log.Printf("Failed to process media %d: %v", mediaID, err)
+				}
+			}
+		}()
+	}
+
+	for {
+		ids, err := mediaRepo.GetUnprocessedMedia(time.Now().Add(-mediaStaleAfter))
+		if err != nil {
+			log.Println("Failed to fetch unprocessed media:", err)
+		}
+		for _, id := range ids {
+			enqueueMedia(id)
+		}
+		time.Sleep(1 * time.Minute)
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// photoWithGPS encodes a landscape JPEG whose EXIF data says it was taken sideways, followed by GPS-looking text
+func photoWithGPS(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	tiff := []byte("II\x2a\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112) // Orientation
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 6) // Turn 90° clockwise
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	tiff = append(tiff, []byte("GPSLatitude 52.5200")...)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+
+	photo := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	photo = binary.BigEndian.AppendUint16(photo, uint16(len(payload)+2))
+	photo = append(photo, payload...)
+	return append(photo, buf.Bytes()[2:]...)
+}
+
+func TestMediaProcessor(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM media_renditions")
+	database.DB.Exec("DELETE FROM media")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	user := models.User{Username: "processoruser", Password: "password"}
+	database.DB.Create(&user)
+	sub := models.Sub{Name: "processorsub", OwnerID: user.ID}
+	database.DB.Create(&sub)
+
+	ctx := context.Background()
+	store := storage.NewLocalStore(t.TempDir())
+	mediaRepo := repositories.NewMediaRepository()
+	service := NewMediaService(mediaRepo, repositories.NewUserRepository(), store)
+	processor := NewMediaProcessor(mediaRepo, store)
+
+	t.Run("renditions are upright and stripped of metadata", func(t *testing.T) {
+		uploaded, err := service.Upload("processoruser", bytes.NewReader(photoWithGPS(t, 1200, 800)))
+		require.NoError(t, err)
+		original, err := mediaRepo.GetMediaByID(uploaded.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, processor.Process(uploaded.ID))
+
+		media, err := service.GetMedia(fmt.Sprint(uploaded.ID))
+		require.NoError(t, err)
+		assert.Equal(t, models.MediaStatusReady, media.Status)
+		assert.Equal(t, 800, media.Width)
+		assert.Equal(t, 1200, media.Height)
+		assert.Len(t, media.BlurHash, 28)
+
+		sizes := map[string][2]int{}
+		for _, rendition := range media.Renditions {
+			sizes[rendition.Name] = [2]int{rendition.Width, rendition.Height}
+			assert.Equal(t, repositories.RenditionURL(uploaded.ID, rendition.Name), rendition.URL)
+		}
+		assert.Equal(t, map[string][2]int{
+			models.RenditionThumbnail: {106, 160},
+			models.RenditionPreview:   {426, 640},
+			models.RenditionFull:      {800, 1200},
+		}, sizes)
+
+		_, file, err := service.OpenRendition(fmt.Sprint(uploaded.ID), models.RenditionPreview)
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		file.Close()
+		assert.False(t, bytes.Contains(data, []byte("GPSLatitude")))
+		config, format, err := image.DecodeConfig(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, 426, config.Width)
+
+		// The upload is deleted once processed
+		_, err = store.Get(ctx, original.StorageKey)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+
+		_, _, err = service.OpenRendition(fmt.Sprint(uploaded.ID), "huge")
+		assert.EqualError(t, err, "rendition not found")
+
+		// Processing again does nothing
+		assert.NoError(t, processor.Process(uploaded.ID))
+	})
+
+	t.Run("posts list the renditions of their image", func(t *testing.T) {
+		uploaded, err := service.Upload("processoruser", bytes.NewReader(photoWithGPS(t, 100, 50)))
+		require.NoError(t, err)
+
+		post, err := CreatePost("processoruser", models.Post{Title: "Photo", Content: "Look", SubID: sub.ID, MediaID: &uploaded.ID})
+		require.NoError(t, err)
+
+		response, err := repositories.GetPostByID(fmt.Sprint(post.ID))
+		require.NoError(t, err)
+		require.NotNil(t, response.Image)
+		assert.Equal(t, models.MediaStatusPending, response.Image.Status)
+		assert.Empty(t, response.Image.Renditions)
+
+		require.NoError(t, processor.Process(uploaded.ID))
+		response, err = repositories.GetPostByID(fmt.Sprint(post.ID))
+		require.NoError(t, err)
+		assert.Equal(t, uploaded.ID, *response.Image.MediaID)
+		assert.Equal(t, 50, response.Image.Width)
+		assert.Len(t, response.Image.Renditions, 3)
+
+		// Linked images have a single original rendition
+		link := "https://example.com/cat.png"
+		linked, err := CreatePost("processoruser", models.Post{Title: "Link", Content: "Cat", SubID: sub.ID, ImageURL: &link})
+		require.NoError(t, err)
+		response, err = repositories.GetPostByID(fmt.Sprint(linked.ID))
+		require.NoError(t, err)
+		assert.Nil(t, response.Image.MediaID)
+		assert.Equal(t, []models.RenditionResponse{{Name: models.RenditionOriginal, URL: link}}, response.Image.Renditions)
+	})
+
+	t.Run("files that aren't images fail", func(t *testing.T) {
+		uploaded, err := service.Upload("processoruser", bytes.NewReader(testPNG))
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, processor.Process(uploaded.ID), errNotAnImage)
+		media, err := mediaRepo.GetMediaByID(uploaded.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.MediaStatusFailed, media.Status)
+
+		_, _, err = service.OpenRendition(fmt.Sprint(uploaded.ID), models.RenditionFull)
+		assert.EqualError(t, err, "media could not be processed")
+	})
+
+	t.Run("stalled claims are retried", func(t *testing.T) {
+		uploaded, err := service.Upload("processoruser", bytes.NewReader(photoWithGPS(t, 40, 40)))
+		require.NoError(t, err)
+
+		claimed, err := mediaRepo.ClaimMedia(uploaded.ID, time.Now().Add(-mediaStaleAfter))
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		claimed, err = mediaRepo.ClaimMedia(uploaded.ID, time.Now().Add(-mediaStaleAfter))
+		require.NoError(t, err)
+		assert.False(t, claimed)
+
+		ids, err := mediaRepo.GetUnprocessedMedia(time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Contains(t, ids, uploaded.ID)
+	})
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/storage"
+)
+
+// defaultMaxUploadMB is the upload size limit when MEDIA_MAX_UPLOAD_MB is not set
+const defaultMaxUploadMB = 10
+
+// mediaExtensions are the content types that can be uploaded, with the file extension stored for each
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// MaxUploadSize returns the largest file that can be uploaded in bytes, configurable with MEDIA_MAX_UPLOAD_MB
+func MaxUploadSize() int64 {
+	if mb, err := strconv.Atoi(os.Getenv("MEDIA_MAX_UPLOAD_MB")); err == nil && mb > 0 {
+		return int64(mb) << 20
+	}
+	return defaultMaxUploadMB << 20
+}
+
+// MediaService handles uploaded files, which are kept in a blob store and referenced by posts, comments and avatars
+type MediaService struct {
+	mediaRepo repositories.IMediaRepository
+	userRepo  repositories.IUserRepository
+	store     storage.BlobStore
+}
+
+// NewMediaService creates a new media service with dependency injection
+func NewMediaService(mediaRepo repositories.IMediaRepository, userRepo repositories.IUserRepository, store storage.BlobStore) *MediaService {
+	return &MediaService{
+		mediaRepo: mediaRepo,
+		userRepo:  userRepo,
+		store:     store,
+	}
+}
+
+// Upload stores a file for the user and queues it for the image pipeline. Its type is detected from
+// its bytes, so a file named .png that isn't a PNG image is rejected.
+func (s *MediaService) Upload(username string, file io.Reader) (*models.MediaResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one byte past the limit to tell a file at the limit from a larger one
+	maxSize := MaxUploadSize()
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, errors.New("failed to read upload")
+	}
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file is too large, the limit is %d MB", maxSize>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := mediaExtensions[contentType]
+	if !ok {
+		return nil, errors.New("unsupported file type, only JPEG, PNG, GIF and WebP images can be uploaded")
+	}
+
+	key, err := newStorageKey(extension)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(context.Background(), key, contentType, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, errors.New("failed to store media")
+	}
+
+	media := models.Media{UserID: user.ID, StorageKey: key, ContentType: contentType, Size: int64(len(data))}
+	if err := s.mediaRepo.CreateMedia(&media); err != nil {
+		// Without a record nothing can reference the file, so it's removed again
+		_ = s.store.Delete(context.Background(), key)
+		return nil, err
+	}
+
+	enqueueMedia(media.ID)
+	return repositories.FormatMedia(media, nil), nil
+}
+
+// GetMedia describes an uploaded file and the sizes it is available in
+func (s *MediaService) GetMedia(mediaID string) (*models.MediaResponse, error) {
+	media, err := s.getMedia(mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	renditions, err := s.mediaRepo.GetRenditions(media.ID)
+	if err != nil {
+		return nil, err
+	}
+	return repositories.FormatMedia(*media, renditions), nil
+}
+
+// OpenRendition opens one size of a processed image for reading; the caller closes it. The upload
+// itself is never served, since it may still carry metadata such as the location a photo was taken.
+func (s *MediaService) OpenRendition(mediaID, name string) (*models.MediaRendition, io.ReadCloser, error) {
+	media, err := s.getMedia(mediaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch media.Status {
+	case models.MediaStatusPending, models.MediaStatusProcessing:
+		return nil, nil, errors.New("media is still being processed")
+	case models.MediaStatusFailed:
+		return nil, nil, errors.New("media could not be processed")
+	}
+
+	rendition, err := s.mediaRepo.GetRendition(media.ID, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.store.Get(context.Background(), rendition.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, errors.New("rendition not found")
+	}
+	if err != nil {
+		return nil, nil, errors.New("failed to read media")
+	}
+	return rendition, file, nil
+}
+
+// AttachMedia checks the user uploaded a file before a post, comment or avatar references it, returning its URL
+func (s *MediaService) AttachMedia(username string, mediaID uint) (string, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return "", err
+	}
+
+	media, err := s.mediaRepo.GetMediaByID(mediaID)
+	if err != nil {
+		return "", err
+	}
+	if media.UserID != user.ID {
+		return "", errors.New("you can only use media you uploaded")
+	}
+	return repositories.MediaURL(media.ID), nil
+}
+
+// getMedia loads the record of an uploaded file from its ID in a URL
+func (s *MediaService) getMedia(mediaID string) (*models.Media, error) {
+	id, err := strconv.ParseUint(mediaID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid media ID")
+	}
+	return s.mediaRepo.GetMediaByID(uint(id))
+}
+
+// resolveMediaURL returns the URL to store for an image: the uploaded file's URL when a media ID is
+// given, otherwise the link the user supplied. Attaching doesn't touch the blob store, so none is configured.
+func resolveMediaURL(username string, mediaID *uint, imageURL *string) (*string, error) {
+	if mediaID == nil {
+		return imageURL, nil
+	}
+
+	service := NewMediaService(repositories.NewMediaRepository(), repositories.NewUserRepository(), nil)
+	url, err := service.AttachMedia(username, *mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return &url, nil
+}
+
+// newStorageKey generates a random blob key, spread over directories by its first two characters
+func newStorageKey(extension string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", errors.New("failed to store media")
+	}
+	name := hex.EncodeToString(random)
+	return "media/" + name[:2] + "/" + name + extension, nil
+}
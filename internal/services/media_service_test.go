@@ -0,0 +1,149 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG starts with the PNG signature, which is all content sniffing looks at
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+func TestMediaConfig(t *testing.T) {
+	t.Setenv("MEDIA_MAX_UPLOAD_MB", "")
+	assert.Equal(t, int64(10<<20), MaxUploadSize())
+	t.Setenv("MEDIA_MAX_UPLOAD_MB", "2")
+	assert.Equal(t, int64(2<<20), MaxUploadSize())
+
+	t.Setenv("MEDIA_BASE_URL", "")
+	assert.Equal(t, "/api/media/7/file", repositories.MediaURL(7))
+	t.Setenv("MEDIA_BASE_URL", "https://api.example.com/")
+	assert.Equal(t, "https://api.example.com/api/media/7/file", repositories.MediaURL(7))
+	assert.Equal(t, "https://api.example.com/api/media/7/renditions/thumbnail", repositories.RenditionURL(7, models.RenditionThumbnail))
+
+	key, err := newStorageKey(".png")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^media/([0-9a-f]{2})/[0-9a-f]{32}\.png$`, key)
+	assert.Equal(t, key[6:8], key[9:11])
+}
+
+func TestMediaService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM media")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	uploader := models.User{Username: "mediauser", Password: "password"}
+	other := models.User{Username: "mediaother", Password: "password"}
+	database.DB.Create(&uploader)
+	database.DB.Create(&other)
+	sub := models.Sub{Name: "mediasub", OwnerID: uploader.ID}
+	database.DB.Create(&sub)
+
+	store := storage.NewLocalStore(t.TempDir())
+	service := NewMediaService(repositories.NewMediaRepository(), repositories.NewUserRepository(), store)
+	t.Setenv("MEDIA_BASE_URL", "")
+	t.Setenv("MEDIA_MAX_UPLOAD_MB", "1")
+
+	var uploaded *models.MediaResponse
+
+	t.Run("upload sniffs the content type", func(t *testing.T) {
+		var err error
+		uploaded, err = service.Upload("mediauser", bytes.NewReader(testPNG))
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", uploaded.ContentType)
+		assert.Equal(t, int64(len(testPNG)), uploaded.Size)
+		assert.Equal(t, repositories.MediaURL(uploaded.ID), uploaded.URL)
+		assert.Equal(t, models.MediaStatusPending, uploaded.Status)
+		assert.Empty(t, uploaded.Renditions)
+
+		// The upload itself is never served
+		_, _, err = service.OpenRendition(fmt.Sprint(uploaded.ID), models.RenditionFull)
+		assert.EqualError(t, err, "media is still being processed")
+	})
+
+	t.Run("rejected uploads", func(t *testing.T) {
+		_, err := service.Upload("mediauser", strings.NewReader("<html><script>alert(1)</script></html>"))
+		assert.EqualError(t, err, "unsupported file type, only JPEG, PNG, GIF and WebP images can be uploaded")
+
+		_, err = service.Upload("mediauser", strings.NewReader(""))
+		assert.EqualError(t, err, "file is empty")
+
+		_, err = service.Upload("mediauser", io.MultiReader(bytes.NewReader(testPNG), bytes.NewReader(make([]byte, 1<<20))))
+		assert.EqualError(t, err, "file is too large, the limit is 1 MB")
+	})
+
+	t.Run("get media", func(t *testing.T) {
+		media, err := service.GetMedia(fmt.Sprint(uploaded.ID))
+		assert.NoError(t, err)
+		assert.Equal(t, uploaded.ID, media.ID)
+
+		_, err = service.GetMedia("999999")
+		assert.EqualError(t, err, "media not found")
+		_, err = service.GetMedia("abc")
+		assert.EqualError(t, err, "invalid media ID")
+	})
+
+	t.Run("posts and comments reference uploaded media", func(t *testing.T) {
+		post, err := CreatePost("mediauser", models.Post{Title: "Photo", Content: "Look", SubID: sub.ID, MediaID: &uploaded.ID})
+		require.NoError(t, err)
+		require.NotNil(t, post.ImageURL)
+		assert.Equal(t, uploaded.URL, *post.ImageURL)
+		assert.Equal(t, models.PostTypeImage, post.PostType())
+
+		_, err = CreatePost("mediaother", models.Post{Title: "Stolen", Content: "Mine", SubID: sub.ID, MediaID: &uploaded.ID})
+		assert.EqualError(t, err, "you can only use media you uploaded")
+
+		comment, err := CreateComment("mediauser", models.CommentRequest{PostID: post.ID, Content: "Another", MediaID: &uploaded.ID}, *post)
+		require.NoError(t, err)
+		assert.Equal(t, uploaded.URL, *comment.ImageURL)
+		assert.Equal(t, uploaded.ID, *comment.MediaID)
+	})
+
+	t.Run("avatars reference uploaded media", func(t *testing.T) {
+		profile, err := UpdateUserProfile(uploader.ID, models.UserUpdateRequest{AvatarMediaID: &uploaded.ID})
+		require.NoError(t, err)
+		require.NotNil(t, profile.AvatarURL)
+		assert.Equal(t, uploaded.URL, *profile.AvatarURL)
+
+		missing := uint(999999)
+		_, err = UpdateUserProfile(uploader.ID, models.UserUpdateRequest{AvatarMediaID: &missing})
+		assert.EqualError(t, err, "media not found")
+	})
+
+	t.Run("purged posts take their unused uploads with them", func(t *testing.T) {
+		photo, err := service.Upload("mediauser", bytes.NewReader(testPNG))
+		require.NoError(t, err)
+		post, err := CreatePost("mediauser", models.Post{Title: "Gone soon", Content: "Bye", SubID: sub.ID, MediaID: &photo.ID})
+		require.NoError(t, err)
+		var media models.Media
+		require.NoError(t, database.DB.First(&media, photo.ID).Error)
+		require.NoError(t, database.DB.Delete(&models.Post{}, post.ID).Error)
+
+		require.NoError(t, database.PurgeDeletedBefore(time.Now().Add(time.Minute), store))
+
+		assert.Error(t, database.DB.First(&models.Media{}, photo.ID).Error)
+		_, err = store.Get(context.Background(), media.StorageKey)
+		assert.Error(t, err)
+		// The upload still used by a comment and the avatar stays
+		assert.NoError(t, database.DB.First(&models.Media{}, uploaded.ID).Error)
+	})
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// mentionPattern finds @username and r/subname (or /r/subname) references that aren't part of a longer word,
+// email address or path
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@./-])(@([A-Za-z0-9_-]+)|/?r/([A-Za-z0-9_]{3,32}))`)
+
+// MentionService resolves mentions in post and comment content, stores them and notifies mentioned users
+type MentionService struct {
+	mentionRepo      repositories.IMentionRepository
+	userRepo         repositories.IUserRepository
+	notificationRepo repositories.INotificationRepository
+	subRepo          repositories.ISubSettingsRepository
+	postRepo         repositories.IPostModerationRepository
+}
+
+// NewMentionService creates a new mention service with dependency injection
+func NewMentionService(mentionRepo repositories.IMentionRepository, userRepo repositories.IUserRepository, notificationRepo repositories.INotificationRepository, subRepo repositories.ISubSettingsRepository, postRepo repositories.IPostModerationRepository) *MentionService {
+	return &MentionService{
+		mentionRepo:      mentionRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		subRepo:          subRepo,
+		postRepo:         postRepo,
+	}
+}
+
+// SyncPostMentions stores the mentions in a new post and notifies the mentioned users
+func (s *MentionService) SyncPostMentions(username string, post models.Post) error {
+	message := fmt.Sprintf("%s mentioned you in \"%s\"", username, post.Title)
+	return s.sync(username, post.Content, post.SubID, &post.ID, nil, models.Notification{
+		Type:    models.NotificationTypeMention,
+		Message: message,
+		SubID:   &post.SubID,
+		PostID:  &post.ID,
+	})
+}
+
+// SyncCommentMentions stores the mentions in a new or edited comment; only users who weren't mentioned before are notified
+func (s *MentionService) SyncCommentMentions(username string, comment models.Comment) error {
+	post, err := s.postRepo.GetPost(comment.PostID)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("%s mentioned you in a comment", username)
+	return s.sync(username, comment.Content, post.SubID, nil, &comment.ID, models.Notification{
+		Type:      models.NotificationTypeMention,
+		Message:   message,
+		PostID:    &comment.PostID,
+		CommentID: &comment.ID,
+	})
+}
+
+// sync resolves the mentions in some content posted in a sub, replaces the stored ones and notifies newly
+// mentioned users. The author, users who blocked them and, in private subs, users who can't see the sub are
+// neither linked nor notified, so notifications don't reveal private posts.
+func (s *MentionService) sync(username, content string, subID uint, postID, commentID *uint, template models.Notification) error {
+	author, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+	sub, err := s.subRepo.GetSubByID(subID)
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.mentionRepo.GetMentionedUserIDs(postID, commentID)
+	if err != nil {
+		return err
+	}
+	alreadyMentioned := map[uint]bool{author.ID: true}
+	for _, userID := range previous {
+		alreadyMentioned[userID] = true
+	}
+
+	mentions := []models.Mention{}
+	notify := []uint{}
+	for _, span := range ParseMentions(content) {
+		mention := models.Mention{Type: span.Type, Name: span.Name, StartOffset: span.Start, EndOffset: span.End}
+
+		if span.Type == models.MentionTypeSub {
+			sub, err := repositories.GetSubByName(span.Name)
+			if err != nil {
+				continue
+			}
+			mention.TargetID = sub.ID
+			mentions = append(mentions, mention)
+			continue
+		}
+
+		user, err := s.userRepo.GetUserByUsername(span.Name)
+		if err != nil {
+			continue
+		}
+		if user.ID != author.ID && CheckCanInteract(username, user.ID) != nil {
+			continue
+		}
+		canSee, err := s.canSeeSub(sub, user.ID)
+		if err != nil {
+			return err
+		}
+		if !canSee {
+			continue
+		}
+		mention.TargetID = user.ID
+		mentions = append(mentions, mention)
+
+		if !alreadyMentioned[user.ID] {
+			alreadyMentioned[user.ID] = true
+			notify = append(notify, user.ID)
+		}
+	}
+
+	if err := s.mentionRepo.ReplaceMentions(postID, commentID, mentions); err != nil {
+		return err
+	}
+
+	for _, userID := range notify {
+		notification := template
+		notification.UserID = userID
+		notifyBestEffort(s.notificationRepo.CreateNotification(&notification), "send mention notification")
+	}
+	return nil
+}
+
+// canSeeSub reports whether a user can see the posts in a sub: any sub that is public, and private subs they
+// own, moderate or belong to
+func (s *MentionService) canSeeSub(sub *models.Sub, userID uint) (bool, error) {
+	if !sub.Private {
+		return true, nil
+	}
+	isModerator, err := isSubModerator(s.subRepo, sub, userID)
+	if err != nil || isModerator {
+		return isModerator, err
+	}
+	return s.subRepo.IsMember(sub.ID, userID)
+}
+
+// ParseMentions finds the @username and r/subname references in content. Offsets are in characters, not bytes,
+// and each span covers the whole reference including its @ or r/ prefix.
+func ParseMentions(content string) []models.MentionResponse {
+	spans := []models.MentionResponse{}
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(content, -1) {
+		span := models.MentionResponse{
+			Start: utf8.RuneCountInString(content[:match[2]]),
+			End:   utf8.RuneCountInString(content[:match[3]]),
+		}
+		if match[4] >= 0 {
+			span.Type = models.MentionTypeUser
+			span.Name = content[match[4]:match[5]]
+		} else {
+			span.Type = models.MentionTypeSub
+			span.Name = content[match[6]:match[7]]
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// newDefaultMentionService creates a mention service with the default repositories
+func newDefaultMentionService() *MentionService {
+	return NewMentionService(repositories.NewMentionRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository(),
+		repositories.NewSubSettingsRepository(), repositories.NewPostModerationRepository())
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Run("users and subs", func(t *testing.T) {
+		mentions := ParseMentions("Thanks @alice, see r/golang and /r/rust_lang")
+		assert.Equal(t, []models.MentionResponse{
+			{Type: models.MentionTypeUser, Name: "alice", Start: 7, End: 13},
+			{Type: models.MentionTypeSub, Name: "golang", Start: 19, End: 27},
+			{Type: models.MentionTypeSub, Name: "rust_lang", Start: 32, End: 44},
+		}, mentions)
+	})
+
+	t.Run("emails, paths and words are not mentions", func(t *testing.T) {
+		assert.Empty(t, ParseMentions("mail bob@example.com or open https://x.com/r/golang or bar/r/golang"))
+	})
+
+	t.Run("offsets count characters", func(t *testing.T) {
+		mentions := ParseMentions("héllo @bob")
+		assert.Len(t, mentions, 1)
+		assert.Equal(t, 6, mentions[0].Start)
+		assert.Equal(t, 10, mentions[0].End)
+	})
+}
+
+func TestMentionService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM mentions")
+	database.DB.Exec("DELETE FROM notifications")
+	database.DB.Exec("DELETE FROM user_blocks")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	author := models.User{Username: "mentionauthor", Password: "password"}
+	friend := models.User{Username: "mentionfriend", Password: "password"}
+	blocker := models.User{Username: "mentionblocker", Password: "password"}
+	database.DB.Create(&author)
+	database.DB.Create(&friend)
+	database.DB.Create(&blocker)
+	database.DB.Create(&models.UserBlock{BlockerID: blocker.ID, BlockedID: author.ID})
+
+	sub := models.Sub{Name: "mentionsub", OwnerID: author.ID}
+	database.DB.Create(&sub)
+	post := models.Post{Title: "Hello", Content: "Content", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+	comment := models.Comment{PostID: post.ID, UserID: author.ID, Content: "Hi @mentionfriend @mentionblocker in r/mentionsub"}
+	database.DB.Create(&comment)
+
+	service := NewMentionService(repositories.NewMentionRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository(),
+		repositories.NewSubSettingsRepository(), repositories.NewPostModerationRepository())
+	countNotifications := func(userID uint) int64 {
+		var count int64
+		database.DB.Model(&models.Notification{}).Where("user_id = ? AND type = ?", userID, models.NotificationTypeMention).Count(&count)
+		return count
+	}
+
+	t.Run("mentions are stored and users notified", func(t *testing.T) {
+		assert.NoError(t, service.SyncCommentMentions("mentionauthor", comment))
+
+		var mentions []models.Mention
+		database.DB.Where("comment_id = ?", comment.ID).Order("start_offset").Find(&mentions)
+		assert.Len(t, mentions, 2)
+		assert.Equal(t, friend.ID, mentions[0].TargetID)
+		assert.Equal(t, sub.ID, mentions[1].TargetID)
+
+		assert.Equal(t, int64(1), countNotifications(friend.ID))
+		assert.Equal(t, int64(0), countNotifications(blocker.ID))
+	})
+
+	t.Run("edits don't notify again", func(t *testing.T) {
+		comment.Content = "Edited, still thanks @mentionfriend"
+		assert.NoError(t, service.SyncCommentMentions("mentionauthor", comment))
+
+		var count int64
+		database.DB.Model(&models.Mention{}).Where("comment_id = ?", comment.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+		assert.Equal(t, int64(1), countNotifications(friend.ID))
+	})
+
+	t.Run("private sub posts only notify users who can see them", func(t *testing.T) {
+		member := models.User{Username: "mentionmember", Password: "password"}
+		database.DB.Create(&member)
+		private := models.Sub{Name: "mentionprivate", OwnerID: author.ID, Private: true}
+		database.DB.Create(&private)
+		database.DB.Create(&models.SubMembership{SubID: private.ID, UserID: member.ID})
+
+		secret := models.Post{Title: "Secret plans", Content: "Hi @mentionfriend and @mentionmember", SubID: private.ID, UserID: author.ID}
+		database.DB.Create(&secret)
+		assert.NoError(t, service.SyncPostMentions("mentionauthor", secret))
+
+		var mentions []models.Mention
+		database.DB.Where("post_id = ? AND comment_id IS NULL", secret.ID).Find(&mentions)
+		assert.Len(t, mentions, 1)
+		assert.Equal(t, member.ID, mentions[0].TargetID)
+		assert.Equal(t, int64(1), countNotifications(member.ID))
+		assert.Equal(t, int64(1), countNotifications(friend.ID))
+
+		reply := models.Comment{PostID: secret.ID, UserID: author.ID, Content: "@mentionfriend @mentionmember"}
+		database.DB.Create(&reply)
+		assert.NoError(t, service.SyncCommentMentions("mentionauthor", reply))
+		assert.Equal(t, int64(2), countNotifications(member.ID))
+		assert.Equal(t, int64(1), countNotifications(friend.ID))
+	})
+}
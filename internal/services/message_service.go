@@ -0,0 +1,318 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// Limits applied to conversations and messages
+const (
+	maxConversationRecipients = 9
+	maxMessageSubjectLength   = 200
+	maxMessageBodyLength      = 10000
+)
+
+// MessageService handles private conversations between users and modmail threads addressed to subs
+type MessageService struct {
+	messageRepo repositories.IMessageRepository
+	userRepo    repositories.IUserRepository
+	followRepo  repositories.IFollowRepository
+	subRepo     repositories.ISubSettingsRepository
+}
+
+// NewMessageService creates a new message service with dependency injection
+func NewMessageService(messageRepo repositories.IMessageRepository, userRepo repositories.IUserRepository, followRepo repositories.IFollowRepository, subRepo repositories.ISubSettingsRepository) *MessageService {
+	return &MessageService{
+		messageRepo: messageRepo,
+		userRepo:    userRepo,
+		followRepo:  followRepo,
+		subRepo:     subRepo,
+	}
+}
+
+// StartConversation starts a 1:1 or small group conversation. Recipients who blocked the sender,
+// or private users who don't follow the sender, cannot be messaged.
+func (s *MessageService) StartConversation(username string, req models.ConversationRequest) (*models.ConversationResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMessage(&req.Subject, &req.Body); err != nil {
+		return nil, err
+	}
+
+	participantIDs := []uint{user.ID}
+	seen := map[uint]bool{user.ID: true}
+	for _, recipientName := range req.Recipients {
+		recipient, err := s.userRepo.GetUserByUsername(strings.TrimSpace(recipientName))
+		if err != nil {
+			return nil, err
+		}
+		if seen[recipient.ID] {
+			continue
+		}
+		seen[recipient.ID] = true
+
+		if err := s.checkCanMessage(user, recipient); err != nil {
+			return nil, err
+		}
+		participantIDs = append(participantIDs, recipient.ID)
+	}
+
+	if len(participantIDs) == 1 {
+		return nil, errors.New("at least one recipient is required")
+	}
+	if len(participantIDs)-1 > maxConversationRecipients {
+		return nil, fmt.Errorf("a conversation can have at most %d recipients", maxConversationRecipients)
+	}
+
+	conversation := models.Conversation{Subject: req.Subject}
+	message := models.Message{SenderID: user.ID, Body: req.Body}
+	if err := s.messageRepo.CreateConversation(&conversation, participantIDs, &message); err != nil {
+		return nil, err
+	}
+
+	return s.messageRepo.FormatConversation(conversation, user.ID)
+}
+
+// StartModmail starts a conversation with a sub's moderators
+func (s *MessageService) StartModmail(subID, username string, req models.ModmailRequest) (*models.ConversationResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMessage(&req.Subject, &req.Body); err != nil {
+		return nil, err
+	}
+
+	conversation := models.Conversation{SubID: &sub.ID, Subject: req.Subject}
+	message := models.Message{SenderID: user.ID, Body: req.Body}
+	if err := s.messageRepo.CreateConversation(&conversation, []uint{user.ID}, &message); err != nil {
+		return nil, err
+	}
+
+	return s.messageRepo.FormatConversation(conversation, user.ID)
+}
+
+// Reply adds a message to a conversation. In private conversations nobody can reply once another participant has blocked them.
+func (s *MessageService) Reply(conversationID, username string, req models.MessageRequest) (*models.MessageResponse, error) {
+	user, conversation, err := s.getConversation(conversationID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMessageBody(&req.Body); err != nil {
+		return nil, err
+	}
+
+	if conversation.SubID == nil {
+		participantIDs, err := s.messageRepo.GetParticipantIDs(conversation.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, participantID := range participantIDs {
+			if participantID == user.ID {
+				continue
+			}
+			if err := CheckCanInteract(user.Username, participantID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	message := models.Message{ConversationID: conversation.ID, SenderID: user.ID, Body: req.Body}
+	if err := s.messageRepo.AddMessage(&message); err != nil {
+		return nil, err
+	}
+
+	return &models.MessageResponse{
+		ID:        message.ID,
+		Sender:    user.Username,
+		Body:      message.Body,
+		ReadBy:    []string{},
+		CreatedAt: message.CreatedAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// GetConversation returns a conversation with one page of its messages
+func (s *MessageService) GetConversation(conversationID, username string, page, limit int) (*models.ConversationDetailResponse, error) {
+	user, conversation, err := s.getConversation(conversationID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.messageRepo.FormatConversation(*conversation, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.messageRepo.GetMessages(conversation.ID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ConversationDetailResponse{ConversationResponse: *summary, Messages: messages, Page: page, Limit: limit}, nil
+}
+
+// MarkRead marks a conversation as read, which also updates the read receipts other participants see
+func (s *MessageService) MarkRead(conversationID, username string) error {
+	user, conversation, err := s.getConversation(conversationID, username)
+	if err != nil {
+		return err
+	}
+
+	return s.messageRepo.MarkRead(conversation.ID, user.ID)
+}
+
+// GetInbox returns one page of the user's conversations, most recently active first
+func (s *MessageService) GetInbox(username string, page, limit int) (*models.ConversationListResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	conversations, total, err := s.messageRepo.GetUserConversations(user.ID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ConversationListResponse{Conversations: conversations, Page: page, Limit: limit, Total: total}, nil
+}
+
+// GetModmail returns one page of a sub's modmail (moderators only)
+func (s *MessageService) GetModmail(subID, username string, page, limit int) (*models.ConversationListResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, err
+	}
+
+	isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !isModerator {
+		return nil, errors.New("only moderators can read modmail")
+	}
+
+	conversations, total, err := s.messageRepo.GetSubConversations(sub.ID, user.ID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ConversationListResponse{Conversations: conversations, Page: page, Limit: limit, Total: total}, nil
+}
+
+// GetUnreadCount counts the user's unread messages
+func (s *MessageService) GetUnreadCount(username string) (*models.UnreadCountResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.messageRepo.CountUnread(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UnreadCountResponse{Unread: unread}, nil
+}
+
+// checkCanMessage verifies the recipient hasn't blocked the sender and, if private, follows them
+func (s *MessageService) checkCanMessage(sender, recipient *models.User) error {
+	if err := CheckCanInteract(sender.Username, recipient.ID); err != nil {
+		return err
+	}
+
+	if recipient.IsPrivate {
+		follow, err := s.followRepo.GetFollow(recipient.ID, sender.ID)
+		if err != nil || follow.Status != models.FollowStatusAccepted {
+			return errors.New("this user only accepts messages from people they follow")
+		}
+	}
+	return nil
+}
+
+// getConversation loads a conversation the user can access: participants, and for modmail the sub's moderators.
+// Other users get the same error as for a missing conversation.
+func (s *MessageService) getConversation(conversationID, username string) (*models.User, *models.Conversation, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conversationIDUint, err := strconv.ParseUint(conversationID, 10, 64)
+	if err != nil {
+		return nil, nil, errors.New("invalid conversation ID")
+	}
+
+	conversation, err := s.messageRepo.GetConversation(uint(conversationIDUint))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isParticipant, err := s.messageRepo.IsParticipant(conversation.ID, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isParticipant {
+		return user, conversation, nil
+	}
+
+	if conversation.SubID != nil {
+		if sub, err := s.subRepo.GetSubByID(*conversation.SubID); err == nil {
+			if isModerator, err := isSubModerator(s.subRepo, sub, user.ID); err == nil && isModerator {
+				return user, conversation, nil
+			}
+		}
+	}
+
+	return nil, nil, errors.New("conversation not found")
+}
+
+func (s *MessageService) getSub(subID string) (*models.Sub, error) {
+	subIDUint, err := strconv.ParseUint(subID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid sub ID")
+	}
+
+	return s.subRepo.GetSubByID(uint(subIDUint))
+}
+
+// validateMessage trims and validates a conversation subject and message body
+func validateMessage(subject, body *string) error {
+	*subject = strings.TrimSpace(*subject)
+	if *subject == "" {
+		return errors.New("subject is required")
+	}
+	if len(*subject) > maxMessageSubjectLength {
+		return fmt.Errorf("subject must be %d characters or less", maxMessageSubjectLength)
+	}
+
+	return validateMessageBody(body)
+}
+
+// validateMessageBody trims and validates a message body
+func validateMessageBody(body *string) error {
+	*body = strings.TrimSpace(*body)
+	if *body == "" {
+		return errors.New("message body is required")
+	}
+	if len(*body) > maxMessageBodyLength {
+		return fmt.Errorf("message body must be %d characters or less", maxMessageBodyLength)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMessage(t *testing.T) {
+	t.Run("trims subject and body", func(t *testing.T) {
+		subject, body := " Hello ", " There "
+		assert.NoError(t, validateMessage(&subject, &body))
+		assert.Equal(t, "Hello", subject)
+		assert.Equal(t, "There", body)
+	})
+
+	t.Run("missing subject", func(t *testing.T) {
+		subject, body := " ", "Body"
+		assert.EqualError(t, validateMessage(&subject, &body), "subject is required")
+	})
+
+	t.Run("missing body", func(t *testing.T) {
+		body := ""
+		assert.EqualError(t, validateMessageBody(&body), "message body is required")
+	})
+
+	t.Run("body too long", func(t *testing.T) {
+		body := strings.Repeat("a", maxMessageBodyLength+1)
+		assert.Error(t, validateMessageBody(&body))
+	})
+}
+
+func TestMessageService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM messages")
+	database.DB.Exec("DELETE FROM conversation_participants")
+	database.DB.Exec("DELETE FROM conversations")
+	database.DB.Exec("DELETE FROM user_blocks")
+	database.DB.Exec("DELETE FROM user_follows")
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	alice := models.User{Username: "msgalice", Password: "password"}
+	bob := models.User{Username: "msgbob", Password: "password"}
+	carol := models.User{Username: "msgcarol", Password: "password", IsPrivate: true}
+	owner := models.User{Username: "msgowner", Password: "password"}
+	database.DB.Create(&alice)
+	database.DB.Create(&bob)
+	database.DB.Create(&carol)
+	database.DB.Create(&owner)
+
+	sub := models.Sub{Name: "msgsub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	service := NewMessageService(repositories.NewMessageRepository(), repositories.NewUserRepository(),
+		repositories.NewFollowRepository(), repositories.NewSubSettingsRepository())
+
+	var conversationID string
+
+	t.Run("start a conversation", func(t *testing.T) {
+		conversation, err := service.StartConversation("msgalice", models.ConversationRequest{
+			Recipients: []string{"msgbob"}, Subject: "Hi", Body: "Hello bob",
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"msgalice", "msgbob"}, conversation.Participants)
+		assert.Equal(t, int64(0), conversation.Unread)
+		conversationID = fmt.Sprintf("%d", conversation.ID)
+
+		count, err := service.GetUnreadCount("msgbob")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count.Unread)
+	})
+
+	t.Run("read receipts", func(t *testing.T) {
+		assert.NoError(t, service.MarkRead(conversationID, "msgbob"))
+
+		detail, err := service.GetConversation(conversationID, "msgalice", 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"msgbob"}, detail.Messages[0].ReadBy)
+
+		count, err := service.GetUnreadCount("msgbob")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count.Unread)
+	})
+
+	t.Run("outsiders cannot read a conversation", func(t *testing.T) {
+		_, err := service.GetConversation(conversationID, "msgcarol", 1, 20)
+		assert.EqualError(t, err, "conversation not found")
+	})
+
+	t.Run("private users need to follow the sender", func(t *testing.T) {
+		_, err := service.StartConversation("msgalice", models.ConversationRequest{
+			Recipients: []string{"msgcarol"}, Subject: "Hi", Body: "Hello carol",
+		})
+		assert.EqualError(t, err, "this user only accepts messages from people they follow")
+	})
+
+	t.Run("blocked users cannot reply", func(t *testing.T) {
+		database.DB.Create(&models.UserBlock{BlockerID: bob.ID, BlockedID: alice.ID})
+
+		_, err := service.Reply(conversationID, "msgalice", models.MessageRequest{Body: "Still there?"})
+		assert.EqualError(t, err, "you cannot interact with this user")
+	})
+
+	t.Run("modmail", func(t *testing.T) {
+		conversation, err := service.StartModmail(fmt.Sprintf("%d", sub.ID), "msgalice", models.ModmailRequest{Subject: "Question", Body: "Can I post links?"})
+		assert.NoError(t, err)
+		assert.Equal(t, "msgsub", conversation.SubName)
+
+		_, err = service.GetModmail(fmt.Sprintf("%d", sub.ID), "msgbob", 1, 20)
+		assert.EqualError(t, err, "only moderators can read modmail")
+
+		modmail, err := service.GetModmail(fmt.Sprintf("%d", sub.ID), "msgowner", 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), modmail.Total)
+		assert.Equal(t, int64(1), modmail.Conversations[0].Unread)
+
+		reply, err := service.Reply(fmt.Sprintf("%d", conversation.ID), "msgowner", models.MessageRequest{Body: "Yes"})
+		assert.NoError(t, err)
+		assert.Equal(t, "msgowner", reply.Sender)
+
+		inbox, err := service.GetInbox("msgalice", 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), inbox.Total)
+		assert.Equal(t, conversation.ID, inbox.Conversations[0].ID)
+		assert.Equal(t, int64(1), inbox.Conversations[0].Unread)
+	})
+}
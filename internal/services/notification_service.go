@@ -0,0 +1,277 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// voteMilestones are the post scores at which the author is notified
+var voteMilestones = []int64{10, 50, 100, 500, 1000, 5000, 10000}
+
+// NotificationService handles the notification inbox, notification preferences and the events that create notifications
+type NotificationService struct {
+	notificationRepo repositories.INotificationRepository
+	userRepo         repositories.IUserRepository
+	inviteRepo       repositories.IInviteRepository
+	subRepo          repositories.ISubSettingsRepository
+}
+
+// NewNotificationService creates a new notification service with dependency injection
+func NewNotificationService(notificationRepo repositories.INotificationRepository, userRepo repositories.IUserRepository, inviteRepo repositories.IInviteRepository, subRepo repositories.ISubSettingsRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		inviteRepo:       inviteRepo,
+		subRepo:          subRepo,
+	}
+}
+
+// GetNotifications returns one page of the user's notifications, optionally only unread ones
+func (s *NotificationService) GetNotifications(username string, unreadOnly bool, page, limit int) (*models.NotificationListResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, total, err := s.notificationRepo.GetNotifications(user.ID, unreadOnly, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.NotificationListResponse{Notifications: notifications, Page: page, Limit: limit, Total: total, Unread: unread}, nil
+}
+
+// SetRead marks one of the user's notifications as read or unread
+func (s *NotificationService) SetRead(username, notificationID string, read bool) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	notificationIDUint, err := strconv.ParseUint(notificationID, 10, 64)
+	if err != nil {
+		return errors.New("invalid notification ID")
+	}
+
+	notification, err := s.notificationRepo.GetNotification(uint(notificationIDUint))
+	if err != nil {
+		return err
+	}
+	if notification.UserID != user.ID {
+		return errors.New("notification not found")
+	}
+
+	return s.notificationRepo.SetRead(notification, read)
+}
+
+// MarkAllRead marks all of the user's notifications as read
+func (s *NotificationService) MarkAllRead(username string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	return s.notificationRepo.MarkAllRead(user.ID)
+}
+
+// GetPreferences returns whether each notification type is on for the user
+func (s *NotificationService) GetPreferences(username string) (map[string]bool, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := s.notificationRepo.GetPreferences(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := map[string]bool{}
+	for _, notificationType := range models.NotificationTypes {
+		enabled, ok := saved[notificationType]
+		preferences[notificationType] = !ok || enabled
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences turns notification types on or off; types not in the request keep their current setting
+func (s *NotificationService) UpdatePreferences(username string, req map[string]bool) (map[string]bool, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	for notificationType := range req {
+		if !isNotificationType(notificationType) {
+			return nil, fmt.Errorf("unknown notification type: %s", notificationType)
+		}
+	}
+
+	for notificationType, enabled := range req {
+		if err := s.notificationRepo.SetPreference(user.ID, notificationType, enabled); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetPreferences(username)
+}
+
+// CommentCreated notifies the author of the post or parent comment that someone replied to them
+func (s *NotificationService) CommentCreated(username string, recipientID uint, comment models.Comment, post models.Post) error {
+	if recipientID == 0 || recipientID == comment.UserID {
+		return nil
+	}
+
+	notification := models.Notification{
+		UserID:    recipientID,
+		Type:      models.NotificationTypePostReply,
+		Message:   fmt.Sprintf("%s replied to your post \"%s\"", username, post.Title),
+		SubID:     &post.SubID,
+		PostID:    &post.ID,
+		CommentID: &comment.ID,
+	}
+	if comment.ParentID != nil {
+		notification.Type = models.NotificationTypeCommentReply
+		notification.Message = fmt.Sprintf("%s replied to your comment on \"%s\"", username, post.Title)
+	}
+
+	return s.notificationRepo.CreateNotification(&notification)
+}
+
+// InviteSent notifies a user that they were invited to a private sub
+func (s *NotificationService) InviteSent(subID, inviterUsername, inviteeUsername string) error {
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return err
+	}
+
+	invitee, err := s.userRepo.GetUserByUsername(inviteeUsername)
+	if err != nil {
+		return err
+	}
+
+	return s.notificationRepo.CreateNotification(&models.Notification{
+		UserID:  invitee.ID,
+		Type:    models.NotificationTypeSubInvite,
+		Message: fmt.Sprintf("%s invited you to join %s", inviterUsername, sub.Name),
+		SubID:   &sub.ID,
+	})
+}
+
+// InviteAccepted notifies the inviter that their invitation was accepted
+func (s *NotificationService) InviteAccepted(inviteID, inviteeUsername string) error {
+	inviteIDUint, err := strconv.ParseUint(inviteID, 10, 64)
+	if err != nil {
+		return errors.New("invalid invitation ID")
+	}
+
+	invitation, err := s.inviteRepo.GetInvitationByID(uint(inviteIDUint))
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.subRepo.GetSubByID(invitation.SubID)
+	if err != nil {
+		return err
+	}
+
+	return s.notificationRepo.CreateNotification(&models.Notification{
+		UserID:  invitation.InviterID,
+		Type:    models.NotificationTypeInviteAccepted,
+		Message: fmt.Sprintf("%s accepted your invitation to %s", inviteeUsername, sub.Name),
+		SubID:   &sub.ID,
+	})
+}
+
+// PostVoted notifies the author when a vote that raised their post's score by delta carried it past a milestone
+// for the first time. Changing a downvote to an upvote moves the score by 2, so milestones are crossed rather
+// than hit exactly.
+func (s *NotificationService) PostVoted(post models.Post, delta int) error {
+	score, err := repositories.GetPostScore(post.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, milestone := range crossedMilestones(score-int64(delta), score) {
+		notification := models.Notification{
+			UserID:    post.UserID,
+			Type:      models.NotificationTypeVoteMilestone,
+			Message:   fmt.Sprintf("Your post \"%s\" reached %d points", post.Title, milestone),
+			SubID:     &post.SubID,
+			PostID:    &post.ID,
+			Milestone: milestone,
+		}
+
+		// Votes can go down and back up again; each milestone is only announced once
+		exists, err := s.notificationRepo.NotificationExists(&notification)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if err := s.notificationRepo.CreateNotification(&notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// crossedMilestones returns the milestones a score passed on its way from previous up to score
+func crossedMilestones(previous, score int64) []int64 {
+	crossed := []int64{}
+	for _, milestone := range voteMilestones {
+		if previous < milestone && milestone <= score {
+			crossed = append(crossed, milestone)
+		}
+	}
+	return crossed
+}
+
+func (s *NotificationService) getSub(subID string) (*models.Sub, error) {
+	subIDUint, err := strconv.ParseUint(subID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid sub ID")
+	}
+
+	return s.subRepo.GetSubByID(uint(subIDUint))
+}
+
+// isNotificationType reports whether a notification type can be configured
+func isNotificationType(notificationType string) bool {
+	for _, known := range models.NotificationTypes {
+		if known == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// newDefaultNotificationService creates a notification service with the default repositories
+func newDefaultNotificationService() *NotificationService {
+	return NewNotificationService(repositories.NewNotificationRepository(), repositories.NewUserRepository(),
+		repositories.NewInviteRepository(), repositories.NewSubSettingsRepository())
+}
+
+// NotifyPostVoted sends vote milestone notifications using the default repositories
+func NotifyPostVoted(post models.Post, delta int) {
+	notifyBestEffort(newDefaultNotificationService().PostVoted(post, delta), "send vote milestone notification")
+}
+
+// notifyBestEffort logs a failed follow-up to an action that has already been saved, such as a notification
+// or linking mentions. The action succeeded, so the failure isn't returned to the user.
+func notifyBestEffort(err error, what string) {
+	if err != nil {
+		log.Printf("Failed to %s: %v", what, err)
+	}
+}
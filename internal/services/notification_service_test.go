@@ -0,0 +1,153 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNotificationType(t *testing.T) {
+	assert.True(t, isNotificationType(models.NotificationTypeCommentReply))
+	assert.False(t, isNotificationType("newsletter"))
+}
+
+func TestCrossedMilestones(t *testing.T) {
+	assert.Equal(t, []int64{10}, crossedMilestones(9, 10))
+	// Changing a downvote to an upvote skips over the milestone
+	assert.Equal(t, []int64{10}, crossedMilestones(9, 11))
+	assert.Equal(t, []int64{}, crossedMilestones(10, 11))
+	assert.Equal(t, []int64{}, crossedMilestones(11, 9))
+	assert.Equal(t, []int64{10, 50}, crossedMilestones(0, 50))
+}
+
+func TestNotificationService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM notification_preferences")
+	database.DB.Exec("DELETE FROM notifications")
+	database.DB.Exec("DELETE FROM user_blocks")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM sub_invitations")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	author := models.User{Username: "notifyauthor", Password: "password"}
+	replier := models.User{Username: "notifyreplier", Password: "password"}
+	database.DB.Create(&author)
+	database.DB.Create(&replier)
+
+	sub := models.Sub{Name: "notifysub", OwnerID: author.ID, Private: true}
+	database.DB.Create(&sub)
+	post := models.Post{Title: "Hello", Content: "Content", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+
+	service := NewNotificationService(repositories.NewNotificationRepository(), repositories.NewUserRepository(),
+		repositories.NewInviteRepository(), repositories.NewSubSettingsRepository())
+
+	t.Run("replies notify the post author", func(t *testing.T) {
+		comment, err := CreateComment("notifyreplier", models.CommentRequest{PostID: post.ID, Content: "Nice"}, post)
+		assert.NoError(t, err)
+
+		// Replying to your own comment doesn't notify you
+		_, err = CreateComment("notifyreplier", models.CommentRequest{PostID: post.ID, Content: "Also", ParentID: &comment.ID}, post)
+		assert.NoError(t, err)
+
+		notifications, err := service.GetNotifications("notifyauthor", false, 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), notifications.Total)
+		assert.Equal(t, int64(1), notifications.Unread)
+		assert.Equal(t, models.NotificationTypePostReply, notifications.Notifications[0].Type)
+		assert.Equal(t, comment.ID, *notifications.Notifications[0].CommentID)
+	})
+
+	t.Run("replies to deleted authors notify nobody", func(t *testing.T) {
+		leaver := models.User{Username: "notifyleaver", Password: "password"}
+		database.DB.Create(&leaver)
+		comment, err := CreateComment("notifyleaver", models.CommentRequest{PostID: post.ID, Content: "Bye"}, post)
+		require.NoError(t, err)
+		require.NoError(t, database.DB.Delete(&leaver).Error)
+
+		// An account named like the placeholder deleted authors are shown as isn't the parent's author
+		impostor := models.User{Username: models.DeletedPlaceholder, Password: "password"}
+		database.DB.Create(&impostor)
+		_, err = CreateComment("notifyreplier", models.CommentRequest{PostID: post.ID, Content: "Reply", ParentID: &comment.ID}, post)
+		require.NoError(t, err)
+
+		notifications, err := service.GetNotifications(models.DeletedPlaceholder, false, 1, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), notifications.Total)
+	})
+
+	t.Run("invitations notify both sides", func(t *testing.T) {
+		assert.NoError(t, InviteUser(fmt.Sprintf("%d", sub.ID), "notifyauthor", models.InviteRequest{InviteeUsername: "notifyreplier"}))
+
+		notifications, err := service.GetNotifications("notifyreplier", true, 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, models.NotificationTypeSubInvite, notifications.Notifications[0].Type)
+
+		var invitation models.SubInvitation
+		database.DB.Where("invitee_id = ?", replier.ID).First(&invitation)
+		assert.NoError(t, AcceptInvite(fmt.Sprintf("%d", invitation.ID), "notifyreplier"))
+
+		notifications, err = service.GetNotifications("notifyauthor", true, 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, models.NotificationTypeInviteAccepted, notifications.Notifications[0].Type)
+	})
+
+	t.Run("vote milestones are announced once", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			voter := models.User{Username: fmt.Sprintf("notifyvoter%d", i), Password: "password"}
+			database.DB.Create(&voter)
+			database.DB.Create(&models.Vote{UserID: voter.ID, PostID: post.ID, Vote: 1})
+		}
+		assert.NoError(t, service.PostVoted(post, 1))
+		assert.NoError(t, service.PostVoted(post, 1))
+
+		var count int64
+		database.DB.Model(&models.Notification{}).Where("user_id = ? AND type = ?", author.ID, models.NotificationTypeVoteMilestone).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("read state", func(t *testing.T) {
+		notifications, err := service.GetNotifications("notifyauthor", false, 1, 20)
+		assert.NoError(t, err)
+		id := fmt.Sprintf("%d", notifications.Notifications[0].ID)
+
+		assert.NoError(t, service.SetRead("notifyauthor", id, true))
+		assert.EqualError(t, service.SetRead("notifyreplier", id, true), "notification not found")
+
+		assert.NoError(t, service.MarkAllRead("notifyauthor"))
+		notifications, err = service.GetNotifications("notifyauthor", true, 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), notifications.Total)
+	})
+
+	t.Run("disabled types are not delivered", func(t *testing.T) {
+		preferences, err := service.UpdatePreferences("notifyauthor", map[string]bool{models.NotificationTypePostReply: false})
+		assert.NoError(t, err)
+		assert.False(t, preferences[models.NotificationTypePostReply])
+		assert.True(t, preferences[models.NotificationTypeCommentReply])
+
+		_, err = service.UpdatePreferences("notifyauthor", map[string]bool{"newsletter": false})
+		assert.Error(t, err)
+
+		_, err = CreateComment("notifyreplier", models.CommentRequest{PostID: post.ID, Content: "Quiet"}, post)
+		assert.NoError(t, err)
+
+		notifications, err := service.GetNotifications("notifyauthor", true, 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), notifications.Total)
+	})
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+const (
+	minPollOptions      = 2
+	maxPollOptions      = 10
+	maxPollOptionLength = 200
+)
+
+// PollService handles voting in polls and closing them
+type PollService struct {
+	pollRepo repositories.IPollRepository
+	postRepo repositories.IPostModerationRepository
+	subRepo  repositories.ISubSettingsRepository
+	userRepo repositories.IUserRepository
+}
+
+// NewPollService creates a new poll service with dependency injection
+func NewPollService(pollRepo repositories.IPollRepository, postRepo repositories.IPostModerationRepository, subRepo repositories.ISubSettingsRepository, userRepo repositories.IUserRepository) *PollService {
+	return &PollService{
+		pollRepo: pollRepo,
+		postRepo: postRepo,
+		subRepo:  subRepo,
+		userRepo: userRepo,
+	}
+}
+
+// ValidatePoll checks the poll of a new poll post, trimming its options
+func ValidatePoll(req *models.PollRequest) error {
+	if len(req.Options) < minPollOptions || len(req.Options) > maxPollOptions {
+		return fmt.Errorf("a poll needs between %d and %d options", minPollOptions, maxPollOptions)
+	}
+
+	seen := map[string]bool{}
+	for i, option := range req.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return errors.New("poll options can't be empty")
+		}
+		if utf8.RuneCountInString(option) > maxPollOptionLength {
+			return fmt.Errorf("poll options must be %d characters or less", maxPollOptionLength)
+		}
+		if seen[strings.ToLower(option)] {
+			return errors.New("poll options must be different")
+		}
+		seen[strings.ToLower(option)] = true
+		req.Options[i] = option
+	}
+
+	if req.ClosesAt != nil && !req.ClosesAt.After(time.Now()) {
+		return errors.New("the closing time must be in the future")
+	}
+	return nil
+}
+
+// Vote records a user's vote in a post's poll and returns the updated results. Each user votes once.
+func (s *PollService) Vote(postID, username string, req models.PollVoteRequest) (*models.PollResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	post, poll, err := s.getPoll(postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckPostOpen(*post); err != nil {
+		return nil, err
+	}
+	if poll.Closed(time.Now()) {
+		return nil, errors.New("this poll is closed")
+	}
+
+	validOption := false
+	for _, option := range poll.Options {
+		if option.ID == req.OptionID {
+			validOption = true
+			break
+		}
+	}
+	if !validOption {
+		return nil, errors.New("poll option not found")
+	}
+
+	if err := s.pollRepo.CreateVote(&models.PollVote{PollID: poll.ID, UserID: user.ID, OptionID: req.OptionID}); err != nil {
+		return nil, err
+	}
+
+	results := repositories.PollFor(post.ID)
+	if results != nil {
+		results.VotedOptionID = &req.OptionID
+	}
+	return results, nil
+}
+
+// Close ends voting in a post's poll early; the author and the sub's moderators can close it
+func (s *PollService) Close(postID, username string) (*models.PollResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	post, poll, err := s.getPoll(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.UserID != user.ID {
+		sub, err := s.subRepo.GetSubByID(post.SubID)
+		if err != nil {
+			return nil, err
+		}
+		isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !isModerator {
+			return nil, errors.New("only the post author or a moderator can close this poll")
+		}
+	}
+
+	if poll.Closed(time.Now()) {
+		return nil, errors.New("this poll is already closed")
+	}
+	if err := s.pollRepo.ClosePoll(poll); err != nil {
+		return nil, err
+	}
+	return repositories.PollFor(post.ID), nil
+}
+
+// getPoll loads a post and its poll
+func (s *PollService) getPoll(postID string) (*models.Post, *models.Poll, error) {
+	postIDUint, err := strconv.ParseUint(postID, 10, 64)
+	if err != nil {
+		return nil, nil, errors.New("invalid post ID")
+	}
+
+	post, err := s.postRepo.GetPost(uint(postIDUint))
+	if err != nil {
+		return nil, nil, err
+	}
+	poll, err := s.pollRepo.GetPollByPostID(post.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return post, poll, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePoll(t *testing.T) {
+	req := models.PollRequest{Options: []string{"  Tabs ", "Spaces"}}
+	require.NoError(t, ValidatePoll(&req))
+	assert.Equal(t, []string{"Tabs", "Spaces"}, req.Options)
+
+	past := time.Now().Add(-time.Minute)
+	tooMany := make([]string, 11)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprint("Option ", i)
+	}
+	cases := []struct {
+		req      models.PollRequest
+		expected string
+	}{
+		{models.PollRequest{Options: []string{"Only"}}, "a poll needs between 2 and 10 options"},
+		{models.PollRequest{Options: tooMany}, "a poll needs between 2 and 10 options"},
+		{models.PollRequest{Options: []string{"Yes", " "}}, "poll options can't be empty"},
+		{models.PollRequest{Options: []string{"Yes", strings.Repeat("a", 201)}}, "poll options must be 200 characters or less"},
+		{models.PollRequest{Options: []string{"Yes", "yes"}}, "poll options must be different"},
+		{models.PollRequest{Options: []string{"Yes", "No"}, ClosesAt: &past}, "the closing time must be in the future"},
+	}
+	for _, c := range cases {
+		assert.EqualError(t, ValidatePoll(&c.req), c.expected)
+	}
+}
+
+func TestPollService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM poll_votes")
+	database.DB.Exec("DELETE FROM poll_options")
+	database.DB.Exec("DELETE FROM polls")
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "pollowner", Password: "password"}
+	author := models.User{Username: "pollauthor", Password: "password"}
+	voter := models.User{Username: "pollvoter", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&author)
+	database.DB.Create(&voter)
+	sub := models.Sub{Name: "pollsub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	service := NewPollService(repositories.NewPollRepository(), repositories.NewPostModerationRepository(),
+		repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+
+	createPoll := func(t *testing.T, req models.PollRequest) (string, *models.PostResponse) {
+		post, err := CreatePost("pollauthor", models.Post{Title: "Tabs or spaces?", SubID: sub.ID, Poll: &req})
+		require.NoError(t, err)
+		response, err := GetPostByID(fmt.Sprint(post.ID))
+		require.NoError(t, err)
+		require.NotNil(t, response.Poll)
+		return fmt.Sprint(post.ID), response
+	}
+
+	t.Run("vote once", func(t *testing.T) {
+		postID, post := createPoll(t, models.PollRequest{Options: []string{"Tabs", "Spaces"}})
+		assert.Len(t, post.Poll.Options, 2)
+		assert.Equal(t, "Tabs", post.Poll.Options[0].Text)
+		assert.False(t, post.Poll.Closed)
+
+		spaces := post.Poll.Options[1].ID
+		results, err := service.Vote(postID, "pollvoter", models.PollVoteRequest{OptionID: spaces})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), results.TotalVotes)
+		assert.Equal(t, int64(1), *results.Options[1].Votes)
+		assert.Equal(t, int64(0), *results.Options[0].Votes)
+		assert.Equal(t, spaces, *results.VotedOptionID)
+
+		_, err = service.Vote(postID, "pollvoter", models.PollVoteRequest{OptionID: post.Poll.Options[0].ID})
+		assert.EqualError(t, err, "you have already voted in this poll")
+		_, err = service.Vote(postID, "pollauthor", models.PollVoteRequest{OptionID: 999999})
+		assert.EqualError(t, err, "poll option not found")
+
+		// Listings show the viewer which option they picked
+		posts, err := ListSubPosts(fmt.Sprint(sub.ID), "pollvoter", "", "")
+		require.NoError(t, err)
+		for _, listed := range *posts {
+			if fmt.Sprint(listed.ID) == postID {
+				assert.Equal(t, spaces, *listed.Poll.VotedOptionID)
+			}
+		}
+	})
+
+	t.Run("hidden results are revealed when closed early", func(t *testing.T) {
+		postID, post := createPoll(t, models.PollRequest{Options: []string{"Yes", "No"}, HideResults: true})
+		results, err := service.Vote(postID, "pollvoter", models.PollVoteRequest{OptionID: post.Poll.Options[0].ID})
+		require.NoError(t, err)
+		assert.True(t, results.ResultsHidden)
+		assert.Nil(t, results.Options[0].Votes)
+		assert.Equal(t, int64(1), results.TotalVotes)
+
+		_, err = service.Close(postID, "pollvoter")
+		assert.EqualError(t, err, "only the post author or a moderator can close this poll")
+
+		results, err = service.Close(postID, "pollauthor")
+		require.NoError(t, err)
+		assert.True(t, results.Closed)
+		assert.False(t, results.ResultsHidden)
+		assert.Equal(t, int64(1), *results.Options[0].Votes)
+
+		_, err = service.Vote(postID, "pollowner", models.PollVoteRequest{OptionID: post.Poll.Options[1].ID})
+		assert.EqualError(t, err, "this poll is closed")
+		_, err = service.Close(postID, "pollowner")
+		assert.EqualError(t, err, "this poll is already closed")
+	})
+
+	t.Run("moderators can close polls and polls close on time", func(t *testing.T) {
+		postID, _ := createPoll(t, models.PollRequest{Options: []string{"Yes", "No"}})
+		_, err := service.Close(postID, "pollowner")
+		assert.NoError(t, err)
+
+		closesAt := time.Now().Add(time.Hour)
+		postID, post := createPoll(t, models.PollRequest{Options: []string{"Yes", "No"}, ClosesAt: &closesAt})
+		database.DB.Model(&models.Poll{}).Where("post_id = ?", postID).Update("closes_at", time.Now().Add(-time.Minute))
+		_, err = service.Vote(postID, "pollvoter", models.PollVoteRequest{OptionID: post.Poll.Options[0].ID})
+		assert.EqualError(t, err, "this poll is closed")
+	})
+
+	t.Run("posts without polls", func(t *testing.T) {
+		post, err := CreatePost("pollauthor", models.Post{Title: "Plain", Content: "Text", SubID: sub.ID})
+		require.NoError(t, err)
+		_, err = service.Vote(fmt.Sprint(post.ID), "pollvoter", models.PollVoteRequest{OptionID: 1})
+		assert.EqualError(t, err, "poll not found")
+
+		link := "https://example.com"
+		_, err = CreatePost("pollauthor", models.Post{Title: "Both", SubID: sub.ID, URL: &link,
+			Poll: &models.PollRequest{Options: []string{"Yes", "No"}}})
+		assert.EqualError(t, err, "a poll can't have a link or an image")
+	})
+}
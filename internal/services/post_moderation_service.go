@@ -0,0 +1,184 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// maxPinnedPosts is how many posts a sub can have pinned at once
+const maxPinnedPosts = 2
+
+// PostModerationService handles moderator tools on posts: pinning, locking, content flags and deletion
+type PostModerationService struct {
+	postRepo repositories.IPostModerationRepository
+	subRepo  repositories.ISubSettingsRepository
+	userRepo repositories.IUserRepository
+}
+
+// NewPostModerationService creates a new post moderation service with dependency injection
+func NewPostModerationService(postRepo repositories.IPostModerationRepository, subRepo repositories.ISubSettingsRepository, userRepo repositories.IUserRepository) *PostModerationService {
+	return &PostModerationService{
+		postRepo: postRepo,
+		subRepo:  subRepo,
+		userRepo: userRepo,
+	}
+}
+
+// SetPinned pins a post to the top of its sub or unpins it
+func (s *PostModerationService) SetPinned(postID, username string, pinned bool) (*models.Post, error) {
+	post, err := s.getModeratedPost(postID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if pinned && !post.Pinned {
+		count, err := s.postRepo.CountPinned(post.SubID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= maxPinnedPosts {
+			return nil, fmt.Errorf("a sub can have at most %d pinned posts", maxPinnedPosts)
+		}
+	}
+
+	if err := s.postRepo.SetPinned(post, pinned); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// SetLocked locks a post against new comments and votes, or unlocks it
+func (s *PostModerationService) SetLocked(postID, username string, locked bool) (*models.Post, error) {
+	post, err := s.getModeratedPost(postID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.postRepo.SetLocked(post, locked); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// DeletePost deletes a post; authors can delete their own posts and moderators any post in their sub
+func (s *PostModerationService) DeletePost(postID, username string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	post, err := s.getPost(postID)
+	if err != nil {
+		return err
+	}
+
+	if post.UserID != user.ID {
+		sub, err := s.subRepo.GetSubByID(post.SubID)
+		if err != nil {
+			return err
+		}
+		isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+		if err != nil {
+			return err
+		}
+		if !isModerator {
+			return errors.New("only the post author or a moderator can delete this post")
+		}
+	}
+
+	return s.postRepo.DeletePost(post)
+}
+
+// SetFlags marks a post as NSFW or a spoiler; authors can flag their own posts and moderators any post in their sub.
+// Only moderators can clear a flag, so an author cannot undo a moderator's mark.
+// Flags left out of the request keep their current value.
+func (s *PostModerationService) SetFlags(postID, username string, req models.PostFlagsRequest) (*models.Post, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.getPost(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.subRepo.GetSubByID(post.SubID)
+	if err != nil {
+		return nil, err
+	}
+	isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != user.ID && !isModerator {
+		return nil, errors.New("only the post author or a moderator can change post flags")
+	}
+
+	nsfw, spoiler := post.NSFW, post.Spoiler
+	if req.NSFW != nil {
+		nsfw = *req.NSFW
+	}
+	if req.Spoiler != nil {
+		spoiler = *req.Spoiler
+	}
+	if !isModerator && ((post.NSFW && !nsfw) || (post.Spoiler && !spoiler)) {
+		return nil, errors.New("only a moderator can remove post flags")
+	}
+	if err := s.postRepo.SetFlags(post, nsfw, spoiler); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *PostModerationService) getPost(postID string) (*models.Post, error) {
+	postIDUint, err := strconv.ParseUint(postID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid post ID")
+	}
+
+	return s.postRepo.GetPost(uint(postIDUint))
+}
+
+// getModeratedPost loads the post and verifies the user moderates its sub
+func (s *PostModerationService) getModeratedPost(postID, username string) (*models.Post, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.getPost(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.subRepo.GetSubByID(post.SubID)
+	if err != nil {
+		return nil, err
+	}
+
+	isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !isModerator {
+		return nil, errors.New("only moderators can pin or lock posts")
+	}
+
+	return post, nil
+}
+
+// CheckPostOpen returns an error if a post no longer accepts comments or votes
+func CheckPostOpen(post models.Post) error {
+	if post.Archived {
+		return errors.New("this post is archived")
+	}
+	if post.Locked {
+		return errors.New("this post is locked")
+	}
+	return nil
+}
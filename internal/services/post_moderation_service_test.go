@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPostOpen(t *testing.T) {
+	assert.NoError(t, CheckPostOpen(models.Post{}))
+	assert.EqualError(t, CheckPostOpen(models.Post{Locked: true}), "this post is locked")
+	assert.EqualError(t, CheckPostOpen(models.Post{Locked: true, Archived: true}), "this post is archived")
+}
+
+func TestPostModerationService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "pinowner", Password: "password"}
+	author := models.User{Username: "pinauthor", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&author)
+
+	sub := models.Sub{Name: "pinsub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	var posts []models.Post
+	for i := 0; i < maxPinnedPosts+1; i++ {
+		post := models.Post{Title: fmt.Sprintf("Post %d", i), Content: "Content", SubID: sub.ID, UserID: author.ID}
+		database.DB.Create(&post)
+		posts = append(posts, post)
+	}
+
+	service := NewPostModerationService(repositories.NewPostModerationRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+
+	t.Run("authors cannot pin their posts", func(t *testing.T) {
+		_, err := service.SetPinned(fmt.Sprintf("%d", posts[0].ID), "pinauthor", true)
+		assert.EqualError(t, err, "only moderators can pin or lock posts")
+	})
+
+	t.Run("pinned posts are listed first up to the limit", func(t *testing.T) {
+		for i := 0; i < maxPinnedPosts; i++ {
+			post, err := service.SetPinned(fmt.Sprintf("%d", posts[i].ID), "pinowner", true)
+			assert.NoError(t, err)
+			assert.True(t, post.Pinned)
+		}
+
+		_, err := service.SetPinned(fmt.Sprintf("%d", posts[maxPinnedPosts].ID), "pinowner", true)
+		assert.EqualError(t, err, fmt.Sprintf("a sub can have at most %d pinned posts", maxPinnedPosts))
+
+		listed, err := ListSubPosts(fmt.Sprintf("%d", sub.ID), "pinauthor", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, posts[0].ID, (*listed)[0].ID)
+		assert.True(t, (*listed)[0].Pinned)
+		assert.False(t, (*listed)[len(*listed)-1].Pinned)
+	})
+
+	t.Run("locked posts take no comments", func(t *testing.T) {
+		post, err := service.SetLocked(fmt.Sprintf("%d", posts[0].ID), "pinowner", true)
+		assert.NoError(t, err)
+		assert.True(t, post.Locked)
+
+		_, err = CreateComment("pinauthor", models.CommentRequest{PostID: post.ID, Content: "Too late"}, *post)
+		assert.EqualError(t, err, "this post is locked")
+	})
+
+	t.Run("authors can add flags but not clear a moderator's", func(t *testing.T) {
+		yes, no := true, false
+		postID := fmt.Sprintf("%d", posts[1].ID)
+
+		post, err := service.SetFlags(postID, "pinowner", models.PostFlagsRequest{NSFW: &yes})
+		assert.NoError(t, err)
+		assert.True(t, post.NSFW)
+
+		_, err = service.SetFlags(postID, "pinauthor", models.PostFlagsRequest{NSFW: &no})
+		assert.EqualError(t, err, "only a moderator can remove post flags")
+
+		post, err = service.SetFlags(postID, "pinauthor", models.PostFlagsRequest{Spoiler: &yes})
+		assert.NoError(t, err)
+		assert.True(t, post.NSFW)
+		assert.True(t, post.Spoiler)
+
+		post, err = service.SetFlags(postID, "pinowner", models.PostFlagsRequest{NSFW: &no, Spoiler: &no})
+		assert.NoError(t, err)
+		assert.False(t, post.NSFW)
+		assert.False(t, post.Spoiler)
+	})
+
+	t.Run("comments on archived posts can't be edited", func(t *testing.T) {
+		comment, err := CreateComment("pinauthor", models.CommentRequest{PostID: posts[2].ID, Content: "Before"}, posts[2])
+		assert.NoError(t, err)
+		database.DB.Model(&posts[2]).Update("archived", true)
+
+		commentsService := NewCommentsService(repositories.NewCommentRepository())
+		_, err = commentsService.UpdateComment(comment.ID, "pinauthor", models.CommentUpdateRequest{Content: "After"})
+		assert.EqualError(t, err, "this post is archived")
+	})
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
@@ -22,16 +24,106 @@ func GetAllPosts() ([]models.Post, error) {
 }
 
 func CreatePost(username string, post models.Post) (*models.Post, error) {
+	// Moderation state can't be set by the author, and crossposts are only made through the crosspost endpoint
+	post.Pinned, post.PinnedAt, post.Locked, post.Archived = false, nil, false, false
+	post.CrosspostParentID = nil
+
+	// An uploaded image is linked through the API, and only its uploader can attach it
+	imageURL, err := resolveMediaURL(username, post.MediaID, post.ImageURL)
+	if err != nil {
+		return nil, err
+	}
+	post.ImageURL = imageURL
+
+	// Link and poll posts may leave out the text; every other post needs some
+	post.Domain = ""
+	if post.Poll != nil {
+		if (post.URL != nil && *post.URL != "") || (post.ImageURL != nil && *post.ImageURL != "") {
+			return nil, errors.New("a poll can't have a link or an image")
+		}
+		if err := ValidatePoll(post.Poll); err != nil {
+			return nil, err
+		}
+	} else if post.URL != nil && *post.URL != "" {
+		if post.ImageURL != nil && *post.ImageURL != "" {
+			return nil, errors.New("a post can have a link or an image, not both")
+		}
+		link, domain, err := ValidateLink(*post.URL)
+		if err != nil {
+			return nil, err
+		}
+		post.URL, post.Domain = &link, domain
+	} else if strings.TrimSpace(post.Content) == "" {
+		return nil, errors.New("content is required")
+	}
+
+	// Drafts and scheduled posts stay private to their author; the posting settings are checked again when they're published
+	if err := setPublishingStatus(&post); err != nil {
+		return nil, err
+	}
+
+	// Enforce the sub's posting settings before saving
+	if err := CheckPostingPermission(username, post.SubID, post.PostType()); err != nil {
+		return nil, err
+	}
+	if err := CheckPostFlair(username, post); err != nil {
+		return nil, err
+	}
+
 	newPost, err := repositories.CreatePost(username, post)
 	if err != nil {
 		return nil, err
 	}
 
+	// Mentions in drafts are linked when the post is published, so nobody is notified about a post they can't see
+	if newPost.Published() {
+		notifyBestEffort(newDefaultMentionService().SyncPostMentions(username, *newPost), "link post mentions")
+	}
+	// The preview is fetched in the background; until then the post's link is pending
+	if newPost.URL != nil {
+		enqueueLink(*newPost.URL)
+	}
+
 	return newPost, nil
 }
 
-func GetPosts() (*[]models.PostResponse, error) {
-	commentResponse, err := repositories.GetPosts()
+// UpdatePost edits the content of a post; only the author can edit it, and archived posts can't be changed
+func UpdatePost(postID, username string, req models.PostUpdateRequest) (*models.Post, error) {
+	postIDUint, err := strconv.ParseUint(postID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid post ID")
+	}
+
+	user, err := repositories.NewUserRepository().GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := repositories.NewPostModerationRepository().GetPost(uint(postIDUint))
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != user.ID {
+		return nil, errors.New("unauthorized: can only edit own posts")
+	}
+	if post.Archived {
+		return nil, errors.New("this post is archived")
+	}
+
+	updatedPost, err := repositories.UpdatePost(post.ID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-link mentions in the new content; users who were already mentioned aren't notified again
+	notifyBestEffort(newDefaultMentionService().SyncPostMentions(username, *updatedPost), "link post mentions")
+
+	return updatedPost, nil
+}
+
+// GetPosts lists posts for a viewer, optionally only link posts to a domain
+func GetPosts(username, domain string) (*[]models.PostResponse, error) {
+	commentResponse, err := repositories.GetPosts(username, domain)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +152,7 @@ func TestGetPosts_Service(t *testing.T) {
 	database.DB.Create(&post1)
 	database.DB.Create(&post2)
 
-	posts, err := GetPosts()
+	posts, err := GetPosts("", "")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, posts)
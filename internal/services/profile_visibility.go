@@ -0,0 +1,141 @@
+package services
+
+import (
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// How a viewer relates to the user whose profile they are looking at
+const (
+	RelationshipSelf      = "self"      // Their own profile
+	RelationshipFollower  = "follower"  // An accepted follower; a pending follow request doesn't count
+	RelationshipStranger  = "stranger"  // Logged in without following the user
+	RelationshipAnonymous = "anonymous" // Logged out
+	RelationshipBlocked   = "blocked"   // The user has blocked the viewer
+)
+
+// Profile sections a private profile can hide, as listed in UserResponse.Hidden
+const (
+	ProfileSectionActivity = "activity"
+	ProfileSectionFollows  = "follows"
+	ProfileSectionKarma    = "karma"
+	ProfileSectionJoinDate = "join_date"
+)
+
+// ProfileVisibility is what a viewer may see of a user's profile. The username, display name, bio and avatar
+// are always visible.
+type ProfileVisibility struct {
+	Relationship string
+	Activity     bool // Post, comment and overview history, and appearing in community member lists
+	Follows      bool // Follower and following counts and lists
+	Karma        bool
+	JoinDate     bool
+}
+
+// VisibilityFor is the profile privacy policy. A public profile shows everything and a private one shows
+// everything only to the user and their accepted followers. Users who blocked the viewer show them no more
+// than a private profile would, so a block can't be worked around by making the profile public.
+func VisibilityFor(relationship string, isPrivate bool) ProfileVisibility {
+	visible := true
+	switch relationship {
+	case RelationshipSelf, RelationshipFollower:
+	case RelationshipBlocked:
+		visible = false
+	default:
+		visible = !isPrivate
+	}
+	return ProfileVisibility{Relationship: relationship, Activity: visible, Follows: visible, Karma: visible, JoinDate: visible}
+}
+
+// Hidden lists the profile sections the viewer can't see
+func (v ProfileVisibility) Hidden() []string {
+	hidden := []string{}
+	for _, section := range []struct {
+		name    string
+		visible bool
+	}{
+		{ProfileSectionActivity, v.Activity},
+		{ProfileSectionFollows, v.Follows},
+		{ProfileSectionKarma, v.Karma},
+		{ProfileSectionJoinDate, v.JoinDate},
+	} {
+		if !section.visible {
+			hidden = append(hidden, section.name)
+		}
+	}
+	return hidden
+}
+
+// ProfileVisibilityPolicy works out viewers' relationships to users and applies VisibilityFor. Profiles,
+// activity history, follow lists and community member lists all go through it.
+type ProfileVisibilityPolicy struct {
+	blockRepo  repositories.IBlockRepository
+	followRepo repositories.IFollowRepository
+}
+
+// NewProfileVisibilityPolicy creates a new profile visibility policy with dependency injection
+func NewProfileVisibilityPolicy(blockRepo repositories.IBlockRepository, followRepo repositories.IFollowRepository) *ProfileVisibilityPolicy {
+	return &ProfileVisibilityPolicy{
+		blockRepo:  blockRepo,
+		followRepo: followRepo,
+	}
+}
+
+// For returns what a viewer may see of a user's profile; a nil viewer is logged out
+func (p *ProfileVisibilityPolicy) For(target *models.User, viewerID *uint) (ProfileVisibility, error) {
+	visible, err := p.ForUsers([]models.User{*target}, viewerID)
+	if err != nil {
+		return ProfileVisibility{}, err
+	}
+	return visible[target.ID], nil
+}
+
+// ForUsers returns what a viewer may see of each user's profile, by user ID, checking blocks and follows
+// for all of them at once
+func (p *ProfileVisibilityPolicy) ForUsers(users []models.User, viewerID *uint) (map[uint]ProfileVisibility, error) {
+	blockers, followed := map[uint]bool{}, map[uint]bool{}
+	if viewerID != nil {
+		userIDs := []uint{}
+		for _, user := range users {
+			userIDs = append(userIDs, user.ID)
+		}
+
+		blockerIDs, err := p.blockRepo.GetBlockerIDs(*viewerID, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range blockerIDs {
+			blockers[id] = true
+		}
+
+		followedIDs, err := p.followRepo.GetFollowedIDs(*viewerID, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range followedIDs {
+			followed[id] = true
+		}
+	}
+
+	visibility := map[uint]ProfileVisibility{}
+	for _, user := range users {
+		relationship := RelationshipAnonymous
+		switch {
+		case viewerID == nil:
+		case user.ID == *viewerID:
+			relationship = RelationshipSelf
+		case blockers[user.ID]:
+			relationship = RelationshipBlocked
+		case followed[user.ID]:
+			relationship = RelationshipFollower
+		default:
+			relationship = RelationshipStranger
+		}
+		visibility[user.ID] = VisibilityFor(relationship, user.IsPrivate)
+	}
+	return visibility, nil
+}
+
+func newDefaultProfileVisibilityPolicy() *ProfileVisibilityPolicy {
+	return NewProfileVisibilityPolicy(repositories.NewBlockRepository(), repositories.NewFollowRepository())
+}
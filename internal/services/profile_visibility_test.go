@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibilityFor(t *testing.T) {
+	everything := []string{}
+	nothing := []string{ProfileSectionActivity, ProfileSectionFollows, ProfileSectionKarma, ProfileSectionJoinDate}
+
+	tests := []struct {
+		relationship string
+		isPrivate    bool
+		hidden       []string
+	}{
+		{RelationshipSelf, false, everything},
+		{RelationshipSelf, true, everything},
+		{RelationshipFollower, false, everything},
+		{RelationshipFollower, true, everything},
+		{RelationshipStranger, false, everything},
+		{RelationshipStranger, true, nothing},
+		{RelationshipAnonymous, false, everything},
+		{RelationshipAnonymous, true, nothing},
+		{RelationshipBlocked, false, nothing},
+		{RelationshipBlocked, true, nothing},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s private=%v", tt.relationship, tt.isPrivate), func(t *testing.T) {
+			visibility := VisibilityFor(tt.relationship, tt.isPrivate)
+			assert.Equal(t, tt.relationship, visibility.Relationship)
+			assert.Equal(t, tt.hidden, visibility.Hidden())
+		})
+	}
+}
+
+func TestProfileVisibilityPolicy(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM user_follows")
+	database.DB.Exec("DELETE FROM user_blocks")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	private := models.User{Username: "visprivate", Password: "password", IsPrivate: true, PostKarma: 5, CommentKarma: 2}
+	public := models.User{Username: "vispublic", Password: "password", PostKarma: 3}
+	follower := models.User{Username: "visfollower", Password: "password"}
+	pending := models.User{Username: "vispending", Password: "password"}
+	stranger := models.User{Username: "visstranger", Password: "password"}
+	blocked := models.User{Username: "visblocked", Password: "password"}
+	for _, user := range []*models.User{&private, &public, &follower, &pending, &stranger, &blocked} {
+		require.NoError(t, database.DB.Create(user).Error)
+	}
+	database.DB.Create(&models.UserFollow{FollowerID: follower.ID, FollowingID: private.ID, Status: models.FollowStatusAccepted})
+	database.DB.Create(&models.UserFollow{FollowerID: pending.ID, FollowingID: private.ID, Status: models.FollowStatusPending})
+	database.DB.Create(&models.UserBlock{BlockerID: public.ID, BlockedID: blocked.ID})
+
+	policy := NewProfileVisibilityPolicy(repositories.NewBlockRepository(), repositories.NewFollowRepository())
+
+	t.Run("relationships are worked out per user", func(t *testing.T) {
+		users := []models.User{private, public}
+		tests := []struct {
+			viewerID       *uint
+			privateViewer  string
+			publicViewer   string
+			privateVisible bool
+			publicVisible  bool
+		}{
+			{&private.ID, RelationshipSelf, RelationshipStranger, true, true},
+			{&follower.ID, RelationshipFollower, RelationshipStranger, true, true},
+			{&pending.ID, RelationshipStranger, RelationshipStranger, false, true},
+			{&stranger.ID, RelationshipStranger, RelationshipStranger, false, true},
+			{&blocked.ID, RelationshipStranger, RelationshipBlocked, false, false},
+			{nil, RelationshipAnonymous, RelationshipAnonymous, false, true},
+		}
+		for _, tt := range tests {
+			visibility, err := policy.ForUsers(users, tt.viewerID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.privateViewer, visibility[private.ID].Relationship)
+			assert.Equal(t, tt.publicViewer, visibility[public.ID].Relationship)
+			assert.Equal(t, tt.privateVisible, visibility[private.ID].Activity)
+			assert.Equal(t, tt.publicVisible, visibility[public.ID].Activity)
+		}
+	})
+
+	t.Run("profiles leave out hidden sections", func(t *testing.T) {
+		profile, err := GetUserProfile("visprivate", &stranger.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "visprivate", profile.Username)
+		assert.Empty(t, profile.CreatedAt)
+		assert.Zero(t, profile.Karma)
+		assert.Equal(t, []string{ProfileSectionActivity, ProfileSectionFollows, ProfileSectionKarma, ProfileSectionJoinDate}, profile.Hidden)
+
+		profile, err = GetUserProfile("visprivate", &follower.ID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, profile.CreatedAt)
+		assert.Equal(t, 7, profile.Karma)
+		assert.Equal(t, int64(1), profile.FollowerCount)
+		assert.Empty(t, profile.Hidden)
+
+		profile, err = GetUserProfile("vispublic", &blocked.ID)
+		require.NoError(t, err)
+		assert.Zero(t, profile.Karma)
+		assert.Len(t, profile.Hidden, 4)
+	})
+
+	t.Run("member lists leave out private members except for moderators", func(t *testing.T) {
+		sub := models.Sub{Name: "vissub", OwnerID: public.ID}
+		require.NoError(t, database.DB.Create(&sub).Error)
+		for _, user := range []models.User{private, follower, stranger} {
+			database.DB.Create(&models.SubMembership{SubID: sub.ID, UserID: user.ID})
+		}
+		subID := fmt.Sprintf("%d", sub.ID)
+
+		usernames := func(viewer string) []string {
+			members, err := GetSubMembers(subID, viewer)
+			require.NoError(t, err)
+			names := []string{}
+			for _, member := range members {
+				names = append(names, member.Username)
+			}
+			return names
+		}
+
+		assert.NotContains(t, usernames("visstranger"), "visprivate")
+		assert.NotContains(t, usernames(""), "visprivate")
+		assert.Contains(t, usernames("visfollower"), "visprivate")
+		assert.Contains(t, usernames("visprivate"), "visprivate")
+		assert.Contains(t, usernames("vispublic"), "visprivate")
+	})
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// maxDiffCells bounds the work done for one diff; larger changes are shown as a full replacement
+const maxDiffCells = 4_000_000
+
+// RevisionService handles the edit history of posts and comments, which only the author and moderators can see
+type RevisionService struct {
+	revisionRepo repositories.IRevisionRepository
+	userRepo     repositories.IUserRepository
+	subRepo      repositories.ISubSettingsRepository
+}
+
+// NewRevisionService creates a new revision service with dependency injection
+func NewRevisionService(revisionRepo repositories.IRevisionRepository, userRepo repositories.IUserRepository, subRepo repositories.ISubSettingsRepository) *RevisionService {
+	return &RevisionService{
+		revisionRepo: revisionRepo,
+		userRepo:     userRepo,
+		subRepo:      subRepo,
+	}
+}
+
+// GetRevisions returns every version of a post or comment, oldest first
+func (s *RevisionService) GetRevisions(contentType, contentID, username string) (*models.RevisionListResponse, error) {
+	id, revisions, err := s.getRevisions(contentType, contentID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []models.RevisionResponse{}
+	for _, revision := range revisions {
+		responses = append(responses, models.RevisionResponse{
+			Revision:    revision.Number,
+			Content:     revision.Content,
+			ContentHTML: revision.ContentHTML,
+			ImageURL:    revision.ImageURL,
+			CreatedAt:   revision.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return &models.RevisionListResponse{Type: contentType, ID: id, Revisions: responses}, nil
+}
+
+// GetDiff compares two revisions of a post or comment word by word. Without from and to,
+// the latest revision is compared with the one before it.
+func (s *RevisionService) GetDiff(contentType, contentID, username, from, to string) (*models.RevisionDiffResponse, error) {
+	id, revisions, err := s.getRevisions(contentType, contentID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	toNumber, err := revisionNumber(to, len(revisions))
+	if err != nil {
+		return nil, err
+	}
+	fromNumber, err := revisionNumber(from, max(toNumber-1, 1))
+	if err != nil {
+		return nil, err
+	}
+	if toNumber > len(revisions) || fromNumber > len(revisions) {
+		return nil, errors.New("revision not found")
+	}
+
+	fromRevision, toRevision := revisions[fromNumber-1], revisions[toNumber-1]
+	return &models.RevisionDiffResponse{
+		Type:         contentType,
+		ID:           id,
+		From:         fromNumber,
+		To:           toNumber,
+		Content:      diffWords(fromRevision.Content, toRevision.Content),
+		ImageChanged: !sameImage(fromRevision.ImageURL, toRevision.ImageURL),
+	}, nil
+}
+
+// getRevisions loads the history of a post or comment after checking the user is its author or a moderator of its sub
+func (s *RevisionService) getRevisions(contentType, contentID, username string) (uint, []models.Revision, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	contentIDUint, err := strconv.ParseUint(contentID, 10, 64)
+	if err != nil {
+		return 0, nil, errors.New("invalid " + contentType + " ID")
+	}
+	id := uint(contentIDUint)
+
+	authorID, subID, err := s.revisionRepo.GetContentOwner(contentType, id)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if authorID != user.ID {
+		sub, err := s.subRepo.GetSubByID(subID)
+		if err != nil {
+			return 0, nil, err
+		}
+		isModerator, err := isSubModerator(s.subRepo, sub, user.ID)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !isModerator {
+			return 0, nil, errors.New("only the author or a moderator can view the edit history")
+		}
+	}
+
+	revisions, err := s.revisionRepo.GetRevisions(contentType, id)
+	if err != nil {
+		return 0, nil, err
+	}
+	return id, revisions, nil
+}
+
+// revisionNumber parses a revision number, returning fallback when none is given
+func revisionNumber(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 {
+		return 0, errors.New("invalid revision number")
+	}
+	return number, nil
+}
+
+// sameImage reports whether two revisions have the same image
+func sameImage(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// diffWords computes a word-level diff between two texts. Whitespace is kept with the word before it,
+// so joining the equal and inserted segments gives the new text and joining equal and deleted ones the old.
+func diffWords(from, to string) []models.DiffSegment {
+	a, b := splitWords(from), splitWords(to)
+
+	// Common prefixes and suffixes are cheap to find and keep the table for the changed middle small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	segments := []models.DiffSegment{}
+	segments = appendSegment(segments, models.DiffOpEqual, a[:prefix]...)
+	segments = append(segments, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	segments = appendSegment(segments, models.DiffOpEqual, a[len(a)-suffix:]...)
+	return segments
+}
+
+// diffMiddle diffs the changed part of two texts with a longest common subsequence table
+func diffMiddle(a, b []string) []models.DiffSegment {
+	segments := []models.DiffSegment{}
+	if len(a)*len(b) > maxDiffCells {
+		segments = appendSegment(segments, models.DiffOpDelete, a...)
+		return appendSegment(segments, models.DiffOpInsert, b...)
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if sameWord(a[i], b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case sameWord(a[i], b[j]):
+			segments = appendSameWord(segments, a[i], b[j])
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			segments = appendSegment(segments, models.DiffOpDelete, a[i])
+			i++
+		default:
+			segments = appendSegment(segments, models.DiffOpInsert, b[j])
+			j++
+		}
+	}
+	segments = appendSegment(segments, models.DiffOpDelete, a[i:]...)
+	return appendSegment(segments, models.DiffOpInsert, b[j:]...)
+}
+
+// appendSegment adds words to a diff, merging them into the last segment when the operation is the same
+func appendSegment(segments []models.DiffSegment, op string, words ...string) []models.DiffSegment {
+	text := strings.Join(words, "")
+	if text == "" {
+		return segments
+	}
+	if last := len(segments) - 1; last >= 0 && segments[last].Op == op {
+		segments[last].Text += text
+		return segments
+	}
+	return append(segments, models.DiffSegment{Op: op, Text: text})
+}
+
+// sameWord reports whether two words match, ignoring the whitespace after them
+func sameWord(a, b string) bool {
+	return strings.TrimRightFunc(a, unicode.IsSpace) == strings.TrimRightFunc(b, unicode.IsSpace)
+}
+
+// appendSameWord adds a word found in both texts, marking only a change in the whitespace after it
+func appendSameWord(segments []models.DiffSegment, a, b string) []models.DiffSegment {
+	word := strings.TrimRightFunc(a, unicode.IsSpace)
+	segments = appendSegment(segments, models.DiffOpEqual, word)
+	if a == b {
+		return appendSegment(segments, models.DiffOpEqual, a[len(word):])
+	}
+	segments = appendSegment(segments, models.DiffOpDelete, a[len(word):])
+	return appendSegment(segments, models.DiffOpInsert, b[len(word):])
+}
+
+// splitWords splits text into words, each followed by the whitespace after it; leading whitespace is its own word
+func splitWords(text string) []string {
+	words := []string{}
+	start := 0
+	inSpace := false
+	for i, r := range text {
+		space := unicode.IsSpace(r)
+		if !space && inSpace && i > start {
+			words = append(words, text[start:i])
+			start = i
+		}
+		inSpace = space
+	}
+	if start < len(text) {
+		words = append(words, text[start:])
+	}
+	return words
+}
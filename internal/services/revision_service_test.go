@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffWords(t *testing.T) {
+	t.Run("changed words", func(t *testing.T) {
+		assert.Equal(t, []models.DiffSegment{
+			{Op: models.DiffOpEqual, Text: "the quick "},
+			{Op: models.DiffOpDelete, Text: "brown "},
+			{Op: models.DiffOpInsert, Text: "red "},
+			{Op: models.DiffOpEqual, Text: "fox jumps"},
+		}, diffWords("the quick brown fox jumps", "the quick red fox jumps"))
+	})
+
+	t.Run("additions and removals", func(t *testing.T) {
+		assert.Equal(t, []models.DiffSegment{
+			{Op: models.DiffOpDelete, Text: "hello "},
+			{Op: models.DiffOpEqual, Text: "world"},
+			{Op: models.DiffOpInsert, Text: " again"},
+		}, diffWords("hello world", "world again"))
+	})
+
+	t.Run("identical and empty texts", func(t *testing.T) {
+		assert.Equal(t, []models.DiffSegment{{Op: models.DiffOpEqual, Text: "same text"}}, diffWords("same text", "same text"))
+		assert.Equal(t, []models.DiffSegment{{Op: models.DiffOpInsert, Text: "new"}}, diffWords("", "new"))
+		assert.Empty(t, diffWords("", ""))
+	})
+}
+
+func TestSplitWords(t *testing.T) {
+	assert.Equal(t, []string{"  ", "a ", "b\n", "c"}, splitWords("  a b\nc"))
+	assert.Empty(t, splitWords(""))
+}
+
+func TestRevisionService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM revisions")
+	database.DB.Exec("DELETE FROM mentions")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	author := models.User{Username: "revisionauthor", Password: "password"}
+	owner := models.User{Username: "revisionowner", Password: "password"}
+	stranger := models.User{Username: "revisionstranger", Password: "password"}
+	database.DB.Create(&author)
+	database.DB.Create(&owner)
+	database.DB.Create(&stranger)
+
+	sub := models.Sub{Name: "revisionsub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+	post := models.Post{Title: "Hello", Content: "First draft", SubID: sub.ID, UserID: author.ID}
+	database.DB.Create(&post)
+	comment := models.Comment{PostID: post.ID, UserID: author.ID, Content: "Rude comment"}
+	database.DB.Create(&comment)
+
+	service := NewRevisionService(repositories.NewRevisionRepository(), repositories.NewUserRepository(), repositories.NewSubSettingsRepository())
+	commentID := fmt.Sprint(comment.ID)
+
+	t.Run("unedited content has one revision", func(t *testing.T) {
+		revisions, err := service.GetRevisions(models.ContentTypeComment, commentID, "revisionauthor")
+		assert.NoError(t, err)
+		assert.Len(t, revisions.Revisions, 1)
+		assert.Equal(t, "Rude comment", revisions.Revisions[0].Content)
+	})
+
+	t.Run("edits keep the original", func(t *testing.T) {
+		commentsService := NewCommentsService(repositories.NewCommentRepository())
+		_, err := commentsService.UpdateComment(comment.ID, "revisionauthor", models.CommentUpdateRequest{Content: "Polite comment"})
+		assert.NoError(t, err)
+
+		revisions, err := service.GetRevisions(models.ContentTypeComment, commentID, "revisionowner")
+		assert.NoError(t, err)
+		assert.Len(t, revisions.Revisions, 2)
+		assert.Equal(t, "Rude comment", revisions.Revisions[0].Content)
+		assert.Equal(t, 2, revisions.Revisions[1].Revision)
+
+		diff, err := service.GetDiff(models.ContentTypeComment, commentID, "revisionowner", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, diff.From)
+		assert.Equal(t, 2, diff.To)
+		assert.Equal(t, models.DiffOpDelete, diff.Content[0].Op)
+
+		_, err = service.GetDiff(models.ContentTypeComment, commentID, "revisionowner", "1", "3")
+		assert.EqualError(t, err, "revision not found")
+	})
+
+	t.Run("post edits are recorded", func(t *testing.T) {
+		_, err := UpdatePost(fmt.Sprint(post.ID), "revisionowner", models.PostUpdateRequest{Content: "Hijacked"})
+		assert.EqualError(t, err, "unauthorized: can only edit own posts")
+
+		updated, err := UpdatePost(fmt.Sprint(post.ID), "revisionauthor", models.PostUpdateRequest{Content: "Final *version*"})
+		assert.NoError(t, err)
+		assert.Contains(t, updated.ContentHTML, "<em>version</em>")
+
+		revisions, err := service.GetRevisions(models.ContentTypePost, fmt.Sprint(post.ID), "revisionauthor")
+		assert.NoError(t, err)
+		assert.Len(t, revisions.Revisions, 2)
+		assert.Equal(t, "First draft", revisions.Revisions[0].Content)
+	})
+
+	t.Run("other users cannot see the history", func(t *testing.T) {
+		_, err := service.GetRevisions(models.ContentTypeComment, commentID, "revisionstranger")
+		assert.EqualError(t, err, "only the author or a moderator can view the edit history")
+	})
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// maxSavedCategoryLength limits the length of saved item category names
+const maxSavedCategoryLength = 50
+
+// SavedService handles the posts and comments users save for later and the posts they hide
+type SavedService struct {
+	savedRepo repositories.ISavedRepository
+	userRepo  repositories.IUserRepository
+}
+
+// NewSavedService creates a new saved service with dependency injection
+func NewSavedService(savedRepo repositories.ISavedRepository, userRepo repositories.IUserRepository) *SavedService {
+	return &SavedService{
+		savedRepo: savedRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// Save saves a post or comment, filing it under an optional category
+func (s *SavedService) Save(contentType, contentID, username string, req models.SaveRequest) error {
+	user, id, err := s.getContent(contentType, contentID, username)
+	if err != nil {
+		return err
+	}
+
+	category := strings.TrimSpace(req.Category)
+	if len(category) > maxSavedCategoryLength {
+		return fmt.Errorf("category must be %d characters or less", maxSavedCategoryLength)
+	}
+
+	return s.savedRepo.SaveItem(&models.SavedItem{UserID: user.ID, ContentType: contentType, ContentID: id, Category: category})
+}
+
+// Unsave removes a saved post or comment
+func (s *SavedService) Unsave(contentType, contentID, username string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseContentID(contentType, contentID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.savedRepo.DeleteSavedItem(user.ID, contentType, id)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("you have not saved this %s", contentType)
+	}
+	return nil
+}
+
+// GetSaved returns one page of the user's saved items, optionally only posts or comments and only one category
+func (s *SavedService) GetSaved(username, contentType, category string, page, limit int) (*models.SavedListResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" && contentType != models.ContentTypePost && contentType != models.ContentTypeComment {
+		return nil, errors.New("type must be post or comment")
+	}
+
+	items, total, err := s.savedRepo.GetSavedItems(user.ID, contentType, strings.TrimSpace(category), (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.savedRepo.GetCategories(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SavedListResponse{Items: items, Categories: categories, Page: page, Limit: limit, Total: total}, nil
+}
+
+// HidePost hides a post from the user's post listings and feeds
+func (s *SavedService) HidePost(postID, username string) error {
+	user, id, err := s.getContent(models.ContentTypePost, postID, username)
+	if err != nil {
+		return err
+	}
+
+	return s.savedRepo.HidePost(user.ID, id)
+}
+
+// UnhidePost shows a hidden post in the user's listings again
+func (s *SavedService) UnhidePost(postID, username string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseContentID(models.ContentTypePost, postID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.savedRepo.UnhidePost(user.ID, id)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.New("you have not hidden this post")
+	}
+	return nil
+}
+
+// GetHidden returns one page of the posts the user has hidden
+func (s *SavedService) GetHidden(username string, page, limit int) (*models.HiddenPostListResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, total, err := s.savedRepo.GetHiddenPosts(user.ID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.HiddenPostListResponse{Posts: posts, Page: page, Limit: limit, Total: total}, nil
+}
+
+// getContent loads the user and checks the post or comment they refer to exists
+func (s *SavedService) getContent(contentType, contentID, username string) (*models.User, uint, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	id, err := parseContentID(contentType, contentID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.savedRepo.ContentExists(contentType, id); err != nil {
+		return nil, 0, err
+	}
+	return user, id, nil
+}
+
+// parseContentID parses the ID of a post or comment from a URL
+func parseContentID(contentType, contentID string) (uint, error) {
+	id, err := strconv.ParseUint(contentID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s ID", contentType)
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM saved_items")
+	database.DB.Exec("DELETE FROM hidden_posts")
+	database.DB.Exec("DELETE FROM votes")
+	database.DB.Exec("DELETE FROM comments")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	user := models.User{Username: "saveduser", Password: "password"}
+	database.DB.Create(&user)
+	sub := models.Sub{Name: "savedsub", OwnerID: user.ID}
+	database.DB.Create(&sub)
+	first := models.Post{Title: "First", Content: "Content", SubID: sub.ID, UserID: user.ID}
+	second := models.Post{Title: "Second", Content: "Content", SubID: sub.ID, UserID: user.ID}
+	database.DB.Create(&first)
+	database.DB.Create(&second)
+	comment := models.Comment{PostID: first.ID, UserID: user.ID, Content: "Worth keeping"}
+	database.DB.Create(&comment)
+
+	service := NewSavedService(repositories.NewSavedRepository(), repositories.NewUserRepository())
+	firstID, secondID, subID := fmt.Sprint(first.ID), fmt.Sprint(second.ID), fmt.Sprint(sub.ID)
+
+	t.Run("save posts and comments", func(t *testing.T) {
+		assert.NoError(t, service.Save(models.ContentTypePost, firstID, "saveduser", models.SaveRequest{Category: "recipes"}))
+		assert.NoError(t, service.Save(models.ContentTypeComment, fmt.Sprint(comment.ID), "saveduser", models.SaveRequest{}))
+
+		// Saving again moves the item instead of duplicating it
+		assert.NoError(t, service.Save(models.ContentTypePost, firstID, "saveduser", models.SaveRequest{Category: "  travel "}))
+
+		err := service.Save(models.ContentTypePost, firstID, "saveduser", models.SaveRequest{Category: strings.Repeat("a", 51)})
+		assert.EqualError(t, err, "category must be 50 characters or less")
+		assert.EqualError(t, service.Save(models.ContentTypePost, "999999", "saveduser", models.SaveRequest{}), "post not found")
+
+		saved, err := service.GetSaved("saveduser", "", "", 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), saved.Total)
+		assert.Equal(t, []string{"travel"}, saved.Categories)
+
+		saved, err = service.GetSaved("saveduser", "", "travel", 1, 20)
+		assert.NoError(t, err)
+		assert.Len(t, saved.Items, 1)
+		assert.True(t, saved.Items[0].Post.Saved)
+
+		saved, err = service.GetSaved("saveduser", models.ContentTypeComment, "", 1, 20)
+		assert.NoError(t, err)
+		assert.Len(t, saved.Items, 1)
+		assert.Equal(t, first.ID, saved.Items[0].PostID)
+
+		posts, err := ListSubPosts(subID, "saveduser", "", "")
+		assert.NoError(t, err)
+		for _, post := range *posts {
+			assert.Equal(t, post.ID == first.ID, post.Saved)
+		}
+	})
+
+	t.Run("unsave", func(t *testing.T) {
+		assert.NoError(t, service.Unsave(models.ContentTypeComment, fmt.Sprint(comment.ID), "saveduser"))
+		assert.EqualError(t, service.Unsave(models.ContentTypeComment, fmt.Sprint(comment.ID), "saveduser"), "you have not saved this comment")
+	})
+
+	t.Run("hidden posts are left out of listings", func(t *testing.T) {
+		assert.NoError(t, service.HidePost(secondID, "saveduser"))
+		assert.NoError(t, service.HidePost(secondID, "saveduser"))
+
+		posts, err := ListSubPosts(subID, "saveduser", "", "")
+		assert.NoError(t, err)
+		assert.Len(t, *posts, 1)
+		assert.Equal(t, first.ID, (*posts)[0].ID)
+
+		posts, err = GetPosts("saveduser", "")
+		assert.NoError(t, err)
+		assert.Len(t, *posts, 1)
+
+		hidden, err := service.GetHidden("saveduser", 1, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), hidden.Total)
+
+		assert.NoError(t, service.UnhidePost(secondID, "saveduser"))
+		assert.EqualError(t, service.UnhidePost(secondID, "saveduser"), "you have not hidden this post")
+
+		posts, err = ListSubPosts(subID, "saveduser", "", "")
+		assert.NoError(t, err)
+		assert.Len(t, *posts, 2)
+	})
+}
@@ -2,13 +2,26 @@ package services
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
+	"time"
 
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
 )
 
+// subNamePattern restricts sub names to 3-32 letters, digits or underscores
+var subNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{3,32}$`)
+
+// ValidateSubName checks that a sub name only uses the allowed character set
+func ValidateSubName(name string) error {
+	if !subNamePattern.MatchString(name) {
+		return fmt.Errorf("sub name must be 3-32 characters and contain only letters, numbers and underscores")
+	}
+	return nil
+}
+
 func GetSubs(username string) (*[]models.Sub, error) {
 	subs, err := repositories.GetSubs(username)
 	if err != nil {
@@ -19,6 +32,10 @@ func GetSubs(username string) (*[]models.Sub, error) {
 }
 
 func CreateSub(username string, subRequest models.SubRequest) (*models.Sub, error) {
+	if err := ValidateSubName(subRequest.Name); err != nil {
+		return nil, err
+	}
+
 	newSub, err := repositories.CreateSub(username, subRequest)
 	if err != nil {
 		return nil, err
@@ -42,6 +59,8 @@ func InviteUser(subID, username string, inviteRequest models.InviteRequest) erro
 		return err
 	}
 
+	notifyBestEffort(newDefaultNotificationService().InviteSent(subID, username, inviteRequest.InviteeUsername), "send invitation notification")
+
 	return nil
 }
 
@@ -51,11 +70,13 @@ func AcceptInvite(inviteID, username string) error {
 		return err
 	}
 
+	notifyBestEffort(newDefaultNotificationService().InviteAccepted(inviteID, username), "send invitation accepted notification")
+
 	return nil
 }
 
-func ListSubPosts(subID, username string) (*[]models.PostResponse, error) {
-	subPosts, err := repositories.ListSubPosts(subID, username)
+func ListSubPosts(subID, username, flair, domain string) (*[]models.PostResponse, error) {
+	subPosts, err := repositories.ListSubPosts(subID, username, flair, domain)
 	if err != nil {
 		return nil, err
 	}
@@ -126,12 +147,14 @@ func DeleteSub(subID, username string) error {
 func GetSubMembers(subID, username string) ([]models.SubMemberResponse, error) {
 	// Get user information for access control
 	var user models.User
-	var isOwner bool
+	var isOwner, isModerator bool
+	var viewerID *uint
 
 	if username != "" {
 		if err := db.DB.Where("username = ?", username).First(&user).Error; err != nil {
 			return nil, fmt.Errorf("user not found")
 		}
+		viewerID = &user.ID
 
 		// Check if user is the owner (only needed for private subs, but calculate once)
 		var sub models.Sub
@@ -139,6 +162,11 @@ func GetSubMembers(subID, username string) ([]models.SubMemberResponse, error) {
 			return nil, fmt.Errorf("sub not found")
 		}
 		isOwner = sub.OwnerID == user.ID
+
+		var err error
+		if isModerator, err = isSubModerator(repositories.NewSubSettingsRepository(), &sub, user.ID); err != nil {
+			return nil, err
+		}
 	}
 
 	members, err := repositories.GetSubMembers(subID, user.ID, isOwner)
@@ -146,7 +174,43 @@ func GetSubMembers(subID, username string) ([]models.SubMemberResponse, error) {
 		return nil, err
 	}
 
-	return members, nil
+	// Moderators see every member; others only see members whose activity the profile visibility policy shows them
+	if isModerator {
+		return members, nil
+	}
+	return visibleSubMembers(members, viewerID)
+}
+
+// visibleSubMembers drops the members whose community memberships the viewer isn't allowed to see
+func visibleSubMembers(members []models.SubMemberResponse, viewerID *uint) ([]models.SubMemberResponse, error) {
+	usernames := []string{}
+	for _, member := range members {
+		usernames = append(usernames, member.Username)
+	}
+	if len(usernames) == 0 {
+		return members, nil
+	}
+
+	var users []models.User
+	if err := db.DB.Where("username IN ?", usernames).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch sub members")
+	}
+	visibility, err := newDefaultProfileVisibilityPolicy().ForUsers(users, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	visibleNames := map[string]bool{}
+	for _, user := range users {
+		visibleNames[user.Username] = visibility[user.ID].Activity
+	}
+
+	visible := []models.SubMemberResponse{}
+	for _, member := range members {
+		if visibleNames[member.Username] {
+			visible = append(visible, member)
+		}
+	}
+	return visible, nil
 }
 
 func GetPendingInvites(subID, username string) ([]models.InviteResponse, error) {
@@ -163,3 +227,57 @@ func GetPendingInvites(subID, username string) ([]models.InviteResponse, error)
 
 	return invites, nil
 }
+
+// GetSubByName returns a sub's details by name; private subs are only visible to their owner, members and invitees
+func GetSubByName(name, username string) (*models.SubResponse, error) {
+	sub, err := repositories.GetSubByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.Private && !canSeePrivateSub(sub, username) {
+		return nil, fmt.Errorf("sub not found")
+	}
+
+	return &models.SubResponse{
+		ID:          sub.ID,
+		Name:        sub.Name,
+		Description: sub.Description,
+		Owner:       sub.Owner.Username,
+		Private:     sub.Private,
+		CreatedAt:   sub.CreatedAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// ResolveSubName returns the ID of the sub with the given name. Private subs are reported as not found to
+// anyone but their owner, members and invitees, so their names can't be probed.
+func ResolveSubName(name, username string) (uint, error) {
+	sub, err := repositories.GetSubByName(name)
+	if err != nil {
+		return 0, err
+	}
+	if sub.Private && !canSeePrivateSub(sub, username) {
+		return 0, fmt.Errorf("sub not found")
+	}
+	return sub.ID, nil
+}
+
+// canSeePrivateSub reports whether a user may know that a private sub exists
+func canSeePrivateSub(sub *models.Sub, username string) bool {
+	var user models.User
+	if username == "" || db.DB.Where("username = ?", username).First(&user).Error != nil {
+		return false
+	}
+	if sub.OwnerID == user.ID {
+		return true
+	}
+
+	var count int64
+	db.DB.Model(&models.SubMembership{}).Where("sub_id = ? AND user_id = ?", sub.ID, user.ID).Count(&count)
+	if count > 0 {
+		return true
+	}
+	db.DB.Model(&models.SubInvitation{}).Where("sub_id = ? AND invitee_id = ? AND status = ?", sub.ID, user.ID, models.InviteStatusPending).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).Count(&count)
+	return count > 0
+}
@@ -11,6 +11,15 @@ import (
 
 // TestMain is defined in auth_service_test.go
 
+func TestValidateSubName(t *testing.T) {
+	assert.NoError(t, ValidateSubName("golang"))
+	assert.NoError(t, ValidateSubName("Ask_Cortex_2024"))
+	assert.Error(t, ValidateSubName("go"))
+	assert.Error(t, ValidateSubName("has space"))
+	assert.Error(t, ValidateSubName("dash-name"))
+	assert.Error(t, ValidateSubName("this_name_is_far_too_long_for_a_sub"))
+}
+
 func TestGetSubs_Service(t *testing.T) {
 	if database.DB == nil {
 		t.Skip("Database not available, skipping integration test")
@@ -186,7 +195,7 @@ func TestListSubPosts_Service(t *testing.T) {
 	}
 	database.DB.Create(&post)
 
-	posts, err := ListSubPosts(fmt.Sprintf("%d", sub.ID), "listsubuser")
+	posts, err := ListSubPosts(fmt.Sprintf("%d", sub.ID), "listsubuser", "", "")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, posts)
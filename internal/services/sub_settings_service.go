@@ -0,0 +1,452 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// Limits applied when validating sub settings
+const (
+	maxSubRules            = 15
+	maxSubRuleTitleLength  = 100
+	maxSubRuleDescLength   = 500
+	maxSubSidebarLength    = 10000
+	maxSubMinAccountAgeDay = 3650
+	maxSubArchiveAfterDays = 3650
+)
+
+// allowedSubPostTypes lists every post type a sub may enable
+var allowedSubPostTypes = []string{models.PostTypeText, models.PostTypeImage, models.PostTypeLink, models.PostTypePoll}
+
+// SubSettingsService handles sub settings and posting restriction business logic
+type SubSettingsService struct {
+	settingsRepo repositories.ISubSettingsRepository
+	userRepo     repositories.IUserRepository
+}
+
+// NewSubSettingsService creates a new sub settings service with dependency injection
+func NewSubSettingsService(settingsRepo repositories.ISubSettingsRepository, userRepo repositories.IUserRepository) *SubSettingsService {
+	return &SubSettingsService{
+		settingsRepo: settingsRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// GetSettings returns a sub's settings; private subs are only visible to members and the owner
+func (s *SubSettingsService) GetSettings(subID, username string) (*models.SubSettingsResponse, error) {
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.Private {
+		if username == "" {
+			return nil, errors.New("you must be a member to view this sub's settings")
+		}
+
+		user, err := s.userRepo.GetUserByUsername(username)
+		if err != nil {
+			return nil, err
+		}
+
+		if sub.OwnerID != user.ID {
+			isMember, err := s.settingsRepo.IsMember(sub.ID, user.ID)
+			if err != nil {
+				return nil, err
+			}
+			if !isMember {
+				return nil, errors.New("you must be a member to view this sub's settings")
+			}
+		}
+	}
+
+	return s.buildSettingsResponse(sub)
+}
+
+// UpdateSettings applies a settings update to a sub (owner only)
+func (s *SubSettingsService) UpdateSettings(subID, username string, req models.SubSettingsRequest) (*models.SubSettingsResponse, error) {
+	sub, err := s.getOwnedSub(subID, username, "only the sub owner can update sub settings")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSubSettings(req); err != nil {
+		return nil, err
+	}
+
+	if req.Sidebar != nil {
+		sub.Sidebar = *req.Sidebar
+	}
+	if req.AllowedPostTypes != nil {
+		sub.AllowedPostTypes = strings.Join(normalizePostTypes(*req.AllowedPostTypes), ",")
+	}
+	if req.MinAccountAgeDays != nil {
+		sub.MinAccountAgeDays = *req.MinAccountAgeDays
+	}
+	if req.MinKarma != nil {
+		sub.MinKarma = *req.MinKarma
+	}
+	if req.RestrictedPosting != nil {
+		sub.RestrictedPosting = *req.RestrictedPosting
+	}
+	if req.NSFW != nil {
+		sub.NSFW = *req.NSFW
+	}
+	if req.JoinMode != nil {
+		sub.JoinMode = *req.JoinMode
+	}
+	if req.ArchiveAfterDays != nil {
+		sub.ArchiveAfterDays = *req.ArchiveAfterDays
+	}
+
+	var rules *[]models.SubRule
+	if req.Rules != nil {
+		newRules := make([]models.SubRule, 0, len(*req.Rules))
+		for _, rule := range *req.Rules {
+			newRules = append(newRules, models.SubRule{
+				Title:       strings.TrimSpace(rule.Title),
+				Description: strings.TrimSpace(rule.Description),
+				CreatedAt:   time.Now(),
+			})
+		}
+		rules = &newRules
+	}
+
+	if err := s.settingsRepo.UpdateSettings(sub, rules); err != nil {
+		return nil, err
+	}
+
+	return s.buildSettingsResponse(sub)
+}
+
+// GetApprovedSubmitters lists the users allowed to post in a restricted sub (owner only)
+func (s *SubSettingsService) GetApprovedSubmitters(subID, username string) ([]models.ApprovedSubmitterResponse, error) {
+	sub, err := s.getOwnedSub(subID, username, "only the sub owner can manage approved submitters")
+	if err != nil {
+		return nil, err
+	}
+
+	submitters, err := s.settingsRepo.GetApprovedSubmitters(sub.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []models.ApprovedSubmitterResponse{}
+	for _, submitter := range submitters {
+		responses = append(responses, models.ApprovedSubmitterResponse{
+			Username:   submitter.User.Username,
+			ApprovedAt: submitter.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return responses, nil
+}
+
+// AddApprovedSubmitter approves a user to post in a restricted sub (owner only)
+func (s *SubSettingsService) AddApprovedSubmitter(subID, username, submitterUsername string) error {
+	sub, err := s.getOwnedSub(subID, username, "only the sub owner can manage approved submitters")
+	if err != nil {
+		return err
+	}
+
+	submitter, err := s.userRepo.GetUserByUsername(submitterUsername)
+	if err != nil {
+		return errors.New("submitter user not found")
+	}
+
+	return s.settingsRepo.AddApprovedSubmitter(sub.ID, submitter.ID)
+}
+
+// RemoveApprovedSubmitter revokes a user's approval to post in a restricted sub (owner only)
+func (s *SubSettingsService) RemoveApprovedSubmitter(subID, username, submitterUsername string) error {
+	sub, err := s.getOwnedSub(subID, username, "only the sub owner can manage approved submitters")
+	if err != nil {
+		return err
+	}
+
+	submitter, err := s.userRepo.GetUserByUsername(submitterUsername)
+	if err != nil {
+		return errors.New("submitter user not found")
+	}
+
+	return s.settingsRepo.RemoveApprovedSubmitter(sub.ID, submitter.ID)
+}
+
+// GetModerators lists a sub's moderators (owner only)
+func (s *SubSettingsService) GetModerators(subID, username string) ([]models.ModeratorResponse, error) {
+	sub, err := s.getOwnedSub(subID, username, "only the sub owner can manage moderators")
+	if err != nil {
+		return nil, err
+	}
+
+	moderators, err := s.settingsRepo.GetModerators(sub.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []models.ModeratorResponse{}
+	for _, moderator := range moderators {
+		responses = append(responses, models.ModeratorResponse{
+			Username: moderator.User.Username,
+			AddedAt:  moderator.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return responses, nil
+}
+
+// AddModerator grants a user moderation rights in a sub (owner only)
+func (s *SubSettingsService) AddModerator(subID, username, moderatorUsername string) error {
+	sub, err := s.getOwnedSub(subID, username, "only the sub owner can manage moderators")
+	if err != nil {
+		return err
+	}
+
+	moderator, err := s.userRepo.GetUserByUsername(moderatorUsername)
+	if err != nil {
+		return errors.New("moderator user not found")
+	}
+	if moderator.ID == sub.OwnerID {
+		return errors.New("the sub owner is already a moderator")
+	}
+
+	return s.settingsRepo.AddModerator(sub.ID, moderator.ID)
+}
+
+// RemoveModerator revokes a user's moderation rights in a sub (owner only)
+func (s *SubSettingsService) RemoveModerator(subID, username, moderatorUsername string) error {
+	sub, err := s.getOwnedSub(subID, username, "only the sub owner can manage moderators")
+	if err != nil {
+		return err
+	}
+
+	moderator, err := s.userRepo.GetUserByUsername(moderatorUsername)
+	if err != nil {
+		return errors.New("moderator user not found")
+	}
+
+	return s.settingsRepo.RemoveModerator(sub.ID, moderator.ID)
+}
+
+// CheckPostingPermission verifies that a user may submit a post of the given type to a sub
+func (s *SubSettingsService) CheckPostingPermission(username string, subID uint, postType string) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.settingsRepo.GetSubByID(subID)
+	if err != nil {
+		return err
+	}
+
+	if !containsString(splitPostTypes(sub.AllowedPostTypes), postType) {
+		return errors.New("this post type is not allowed in this sub")
+	}
+
+	// The owner is exempt from the remaining posting restrictions
+	if sub.OwnerID == user.ID {
+		return nil
+	}
+
+	// Only members and moderators can post in a private sub
+	if sub.Private {
+		isModerator, err := isSubModerator(s.settingsRepo, sub, user.ID)
+		if err != nil {
+			return err
+		}
+		isMember, err := s.settingsRepo.IsMember(sub.ID, user.ID)
+		if err != nil {
+			return err
+		}
+		if !isModerator && !isMember {
+			return errors.New("you must be a member to post in this sub")
+		}
+	}
+
+	if sub.MinAccountAgeDays > 0 && time.Since(user.CreatedAt) < time.Duration(sub.MinAccountAgeDays)*24*time.Hour {
+		return errors.New("your account is too new to post in this sub")
+	}
+
+	if sub.MinKarma > 0 && user.Karma() < sub.MinKarma {
+		return errors.New("you don't have enough karma to post in this sub")
+	}
+
+	if sub.RestrictedPosting {
+		approved, err := s.settingsRepo.IsApprovedSubmitter(sub.ID, user.ID)
+		if err != nil {
+			return err
+		}
+		if !approved {
+			return errors.New("only approved submitters can post in this sub")
+		}
+	}
+
+	return nil
+}
+
+// getSub parses the sub ID and loads the sub
+func (s *SubSettingsService) getSub(subID string) (*models.Sub, error) {
+	subIDUint, err := strconv.ParseUint(subID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid sub ID")
+	}
+
+	return s.settingsRepo.GetSubByID(uint(subIDUint))
+}
+
+// getOwnedSub loads the sub and verifies the user owns it
+func (s *SubSettingsService) getOwnedSub(subID, username, notOwnerMessage string) (*models.Sub, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.OwnerID != user.ID {
+		return nil, errors.New(notOwnerMessage)
+	}
+
+	return sub, nil
+}
+
+func (s *SubSettingsService) buildSettingsResponse(sub *models.Sub) (*models.SubSettingsResponse, error) {
+	rules, err := s.settingsRepo.GetRules(sub.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleResponses := []models.SubRuleResponse{}
+	for _, rule := range rules {
+		ruleResponses = append(ruleResponses, models.SubRuleResponse{
+			Position:    rule.Position,
+			Title:       rule.Title,
+			Description: rule.Description,
+		})
+	}
+
+	return &models.SubSettingsResponse{
+		SubID:             sub.ID,
+		Rules:             ruleResponses,
+		Sidebar:           sub.Sidebar,
+		AllowedPostTypes:  splitPostTypes(sub.AllowedPostTypes),
+		MinAccountAgeDays: sub.MinAccountAgeDays,
+		MinKarma:          sub.MinKarma,
+		RestrictedPosting: sub.RestrictedPosting,
+		NSFW:              sub.NSFW,
+		JoinMode:          sub.JoinMode,
+		ArchiveAfterDays:  sub.ArchiveAfterDays,
+	}, nil
+}
+
+// validateSubSettings performs validation on a sub settings update
+func validateSubSettings(req models.SubSettingsRequest) error {
+	if req.Rules != nil {
+		if len(*req.Rules) > maxSubRules {
+			return fmt.Errorf("a sub can have at most %d rules", maxSubRules)
+		}
+		for _, rule := range *req.Rules {
+			title := strings.TrimSpace(rule.Title)
+			if title == "" {
+				return errors.New("rule title is required")
+			}
+			if len(title) > maxSubRuleTitleLength {
+				return fmt.Errorf("rule title must be %d characters or less", maxSubRuleTitleLength)
+			}
+			if len(rule.Description) > maxSubRuleDescLength {
+				return fmt.Errorf("rule description must be %d characters or less", maxSubRuleDescLength)
+			}
+		}
+	}
+
+	if req.Sidebar != nil && len(*req.Sidebar) > maxSubSidebarLength {
+		return fmt.Errorf("sidebar must be %d characters or less", maxSubSidebarLength)
+	}
+
+	if req.AllowedPostTypes != nil {
+		if len(*req.AllowedPostTypes) == 0 {
+			return errors.New("at least one post type must be allowed")
+		}
+		for _, postType := range *req.AllowedPostTypes {
+			if !containsString(allowedSubPostTypes, strings.ToLower(strings.TrimSpace(postType))) {
+				return fmt.Errorf("invalid post type: %s", postType)
+			}
+		}
+	}
+
+	if req.JoinMode != nil && *req.JoinMode != models.JoinModeInviteOnly && *req.JoinMode != models.JoinModeRequest {
+		return fmt.Errorf("join mode must be %s or %s", models.JoinModeInviteOnly, models.JoinModeRequest)
+	}
+
+	if req.MinAccountAgeDays != nil && (*req.MinAccountAgeDays < 0 || *req.MinAccountAgeDays > maxSubMinAccountAgeDay) {
+		return fmt.Errorf("minimum account age must be between 0 and %d days", maxSubMinAccountAgeDay)
+	}
+
+	if req.ArchiveAfterDays != nil && (*req.ArchiveAfterDays < 0 || *req.ArchiveAfterDays > maxSubArchiveAfterDays) {
+		return fmt.Errorf("archive age must be between 0 and %d days", maxSubArchiveAfterDays)
+	}
+
+	if req.MinKarma != nil && *req.MinKarma < 0 {
+		return errors.New("minimum karma can't be negative")
+	}
+
+	return nil
+}
+
+// isSubModerator reports whether the user is the sub's owner or one of its moderators
+func isSubModerator(settingsRepo repositories.ISubSettingsRepository, sub *models.Sub, userID uint) (bool, error) {
+	if sub.OwnerID == userID {
+		return true, nil
+	}
+	return settingsRepo.IsModerator(sub.ID, userID)
+}
+
+// normalizePostTypes lowercases, trims and de-duplicates post types, keeping a stable order
+func normalizePostTypes(postTypes []string) []string {
+	normalized := []string{}
+	for _, postType := range allowedSubPostTypes {
+		for _, requested := range postTypes {
+			if strings.ToLower(strings.TrimSpace(requested)) == postType {
+				normalized = append(normalized, postType)
+				break
+			}
+		}
+	}
+	return normalized
+}
+
+// splitPostTypes turns the stored comma-separated post types into a slice
+func splitPostTypes(postTypes string) []string {
+	result := []string{}
+	for _, postType := range strings.Split(postTypes, ",") {
+		if postType = strings.TrimSpace(postType); postType != "" {
+			result = append(result, postType)
+		}
+	}
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPostingPermission checks posting permission using the default repositories
+func CheckPostingPermission(username string, subID uint, postType string) error {
+	service := NewSubSettingsService(repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+	return service.CheckPostingPermission(username, subID, postType)
+}
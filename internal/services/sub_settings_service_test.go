@@ -0,0 +1,224 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSubSettings(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	t.Run("valid settings", func(t *testing.T) {
+		rules := []models.SubRuleRequest{{Title: "Be kind", Description: "No personal attacks"}}
+		postTypes := []string{"text", "Link"}
+		err := validateSubSettings(models.SubSettingsRequest{
+			Rules:             &rules,
+			AllowedPostTypes:  &postTypes,
+			MinAccountAgeDays: intPtr(30),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("too many rules", func(t *testing.T) {
+		rules := make([]models.SubRuleRequest, maxSubRules+1)
+		for i := range rules {
+			rules[i].Title = fmt.Sprintf("Rule %d", i)
+		}
+		err := validateSubSettings(models.SubSettingsRequest{Rules: &rules})
+		assert.Error(t, err)
+	})
+
+	t.Run("rule without title", func(t *testing.T) {
+		rules := []models.SubRuleRequest{{Title: "   "}}
+		err := validateSubSettings(models.SubSettingsRequest{Rules: &rules})
+		assert.EqualError(t, err, "rule title is required")
+	})
+
+	t.Run("sidebar too long", func(t *testing.T) {
+		sidebar := strings.Repeat("a", maxSubSidebarLength+1)
+		err := validateSubSettings(models.SubSettingsRequest{Sidebar: &sidebar})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown post type", func(t *testing.T) {
+		postTypes := []string{"video"}
+		err := validateSubSettings(models.SubSettingsRequest{AllowedPostTypes: &postTypes})
+		assert.EqualError(t, err, "invalid post type: video")
+	})
+
+	t.Run("no post types", func(t *testing.T) {
+		postTypes := []string{}
+		err := validateSubSettings(models.SubSettingsRequest{AllowedPostTypes: &postTypes})
+		assert.EqualError(t, err, "at least one post type must be allowed")
+	})
+
+	t.Run("unknown join mode", func(t *testing.T) {
+		joinMode := "open"
+		err := validateSubSettings(models.SubSettingsRequest{JoinMode: &joinMode})
+		assert.EqualError(t, err, "join mode must be invite_only or request")
+	})
+
+	t.Run("archive age out of range", func(t *testing.T) {
+		err := validateSubSettings(models.SubSettingsRequest{ArchiveAfterDays: intPtr(-1)})
+		assert.Error(t, err)
+	})
+
+	t.Run("negative account age", func(t *testing.T) {
+		err := validateSubSettings(models.SubSettingsRequest{MinAccountAgeDays: intPtr(-1)})
+		assert.Error(t, err)
+	})
+
+	t.Run("negative karma", func(t *testing.T) {
+		err := validateSubSettings(models.SubSettingsRequest{MinKarma: intPtr(-1)})
+		assert.EqualError(t, err, "minimum karma can't be negative")
+	})
+}
+
+func TestNormalizePostTypes(t *testing.T) {
+	assert.Equal(t, []string{"text", "link"}, normalizePostTypes([]string{" LINK", "text", "link"}))
+	assert.Equal(t, []string{"text", "image"}, splitPostTypes("text, image,"))
+}
+
+func TestSubSettingsService_UpdateSettings(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_rules")
+	database.DB.Exec("DELETE FROM sub_approved_submitters")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "settingsowner", Password: "password"}
+	other := models.User{Username: "settingsother", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&other)
+
+	sub := models.Sub{Name: "settingssub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	service := NewSubSettingsService(repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+	subID := fmt.Sprintf("%d", sub.ID)
+
+	t.Run("owner updates settings", func(t *testing.T) {
+		rules := []models.SubRuleRequest{{Title: "First"}, {Title: "Second", Description: "Details"}}
+		sidebar := "Welcome!"
+		postTypes := []string{"text"}
+		nsfw := true
+		settings, err := service.UpdateSettings(subID, "settingsowner", models.SubSettingsRequest{
+			Rules:            &rules,
+			Sidebar:          &sidebar,
+			AllowedPostTypes: &postTypes,
+			NSFW:             &nsfw,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, settings.Rules, 2)
+		assert.Equal(t, 1, settings.Rules[0].Position)
+		assert.Equal(t, "Second", settings.Rules[1].Title)
+		assert.Equal(t, "Welcome!", settings.Sidebar)
+		assert.Equal(t, []string{"text"}, settings.AllowedPostTypes)
+		assert.True(t, settings.NSFW)
+	})
+
+	t.Run("rules are replaced as a whole", func(t *testing.T) {
+		rules := []models.SubRuleRequest{{Title: "Only rule"}}
+		settings, err := service.UpdateSettings(subID, "settingsowner", models.SubSettingsRequest{Rules: &rules})
+
+		assert.NoError(t, err)
+		assert.Len(t, settings.Rules, 1)
+		assert.Equal(t, "Welcome!", settings.Sidebar) // Untouched fields are kept
+	})
+
+	t.Run("non-owner cannot update settings", func(t *testing.T) {
+		sidebar := "Hijacked"
+		_, err := service.UpdateSettings(subID, "settingsother", models.SubSettingsRequest{Sidebar: &sidebar})
+
+		assert.EqualError(t, err, "only the sub owner can update sub settings")
+	})
+}
+
+func TestSubSettingsService_CheckPostingPermission(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM sub_approved_submitters")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM posts")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "postingowner", Password: "password"}
+	newcomer := models.User{Username: "postingnewcomer", Password: "password"}
+	veteran := models.User{Username: "postingveteran", Password: "password", CreatedAt: time.Now().AddDate(-1, 0, 0)}
+	database.DB.Create(&owner)
+	database.DB.Create(&newcomer)
+	database.DB.Create(&veteran)
+
+	textOnly := models.Sub{Name: "textonlysub", OwnerID: owner.ID, AllowedPostTypes: "text"}
+	agedSub := models.Sub{Name: "agedsub", OwnerID: owner.ID, MinAccountAgeDays: 30}
+	restricted := models.Sub{Name: "restrictedsub", OwnerID: owner.ID, RestrictedPosting: true}
+	karmaSub := models.Sub{Name: "karmasub", OwnerID: owner.ID, MinKarma: 10}
+	database.DB.Create(&textOnly)
+	database.DB.Create(&karmaSub)
+	database.DB.Create(&agedSub)
+	database.DB.Create(&restricted)
+
+	service := NewSubSettingsService(repositories.NewSubSettingsRepository(), repositories.NewUserRepository())
+
+	t.Run("disallowed post type", func(t *testing.T) {
+		err := service.CheckPostingPermission("postingveteran", textOnly.ID, models.PostTypeImage)
+		assert.EqualError(t, err, "this post type is not allowed in this sub")
+	})
+
+	t.Run("account too new", func(t *testing.T) {
+		assert.EqualError(t, service.CheckPostingPermission("postingnewcomer", agedSub.ID, models.PostTypeText), "your account is too new to post in this sub")
+		assert.NoError(t, service.CheckPostingPermission("postingveteran", agedSub.ID, models.PostTypeText))
+	})
+
+	t.Run("not enough karma", func(t *testing.T) {
+		assert.EqualError(t, service.CheckPostingPermission("postingveteran", karmaSub.ID, models.PostTypeText), "you don't have enough karma to post in this sub")
+
+		// Post and comment karma both count
+		database.DB.Model(&veteran).Updates(map[string]interface{}{"post_karma": 6, "comment_karma": 4})
+		assert.NoError(t, service.CheckPostingPermission("postingveteran", karmaSub.ID, models.PostTypeText))
+	})
+
+	t.Run("restricted posting", func(t *testing.T) {
+		assert.EqualError(t, service.CheckPostingPermission("postingveteran", restricted.ID, models.PostTypeText), "only approved submitters can post in this sub")
+
+		err := service.AddApprovedSubmitter(fmt.Sprintf("%d", restricted.ID), "postingowner", "postingveteran")
+		assert.NoError(t, err)
+		assert.NoError(t, service.CheckPostingPermission("postingveteran", restricted.ID, models.PostTypeText))
+	})
+
+	t.Run("owner bypasses restrictions", func(t *testing.T) {
+		assert.NoError(t, service.CheckPostingPermission("postingowner", restricted.ID, models.PostTypeText))
+	})
+
+	t.Run("private subs take posts from members only", func(t *testing.T) {
+		private := models.Sub{Name: "postingprivate", OwnerID: owner.ID, Private: true}
+		require.NoError(t, database.DB.Create(&private).Error)
+		assert.EqualError(t, service.CheckPostingPermission("postingveteran", private.ID, models.PostTypeText), "you must be a member to post in this sub")
+		_, err := CreatePost("postingveteran", models.Post{Title: "Let me in", Content: "Text", SubID: private.ID})
+		assert.EqualError(t, err, "you must be a member to post in this sub")
+
+		require.NoError(t, database.DB.Create(&models.SubMembership{SubID: private.ID, UserID: veteran.ID}).Error)
+		assert.NoError(t, service.CheckPostingPermission("postingveteran", private.ID, models.PostTypeText))
+		assert.NoError(t, service.CheckPostingPermission("postingowner", private.ID, models.PostTypeText))
+	})
+}
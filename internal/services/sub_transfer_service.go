@@ -0,0 +1,165 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+)
+
+// SubTransferService handles handing a sub over to a new owner
+type SubTransferService struct {
+	transferRepo     repositories.ISubTransferRepository
+	subRepo          repositories.ISubSettingsRepository
+	userRepo         repositories.IUserRepository
+	notificationRepo repositories.INotificationRepository
+}
+
+// NewSubTransferService creates a new sub transfer service with dependency injection
+func NewSubTransferService(transferRepo repositories.ISubTransferRepository, subRepo repositories.ISubSettingsRepository, userRepo repositories.IUserRepository, notificationRepo repositories.INotificationRepository) *SubTransferService {
+	return &SubTransferService{
+		transferRepo:     transferRepo,
+		subRepo:          subRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// InitiateTransfer offers ownership of a sub to another user (owner only); the recipient has to accept it
+func (s *SubTransferService) InitiateTransfer(subID, username string, req models.TransferRequest) (*models.TransferResponse, error) {
+	owner, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.OwnerID != owner.ID {
+		return nil, errors.New("only the sub owner can transfer ownership")
+	}
+
+	recipient, err := s.userRepo.GetUserByUsername(req.Username)
+	if err != nil {
+		return nil, errors.New("recipient user not found")
+	}
+	if recipient.ID == owner.ID {
+		return nil, errors.New("you already own this sub")
+	}
+
+	if _, err := s.transferRepo.GetPendingTransferForSub(sub.ID); err == nil {
+		return nil, errors.New("an ownership transfer is already pending for this sub")
+	}
+
+	transfer := models.SubOwnershipTransfer{
+		SubID:      sub.ID,
+		FromUserID: owner.ID,
+		ToUserID:   recipient.ID,
+	}
+	if err := s.transferRepo.CreateTransfer(&transfer); err != nil {
+		return nil, err
+	}
+
+	notifyBestEffort(s.notificationRepo.CreateNotification(&models.Notification{
+		UserID:  recipient.ID,
+		Type:    models.NotificationTypeSubTransfer,
+		Message: fmt.Sprintf("%s wants to transfer ownership of %s to you", owner.Username, sub.Name),
+		SubID:   &sub.ID,
+	}), "send sub transfer notification")
+
+	return formatTransfer(transfer, sub.Name, owner.Username, recipient.Username), nil
+}
+
+// CancelTransfer withdraws the sub's pending ownership transfer (owner only)
+func (s *SubTransferService) CancelTransfer(subID, username string) error {
+	owner, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.getSub(subID)
+	if err != nil {
+		return err
+	}
+	if sub.OwnerID != owner.ID {
+		return errors.New("only the sub owner can transfer ownership")
+	}
+
+	transfer, err := s.transferRepo.GetPendingTransferForSub(sub.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.transferRepo.UpdateTransferStatus(transfer, models.TransferStatusCancelled)
+}
+
+// GetMyTransfers lists the pending ownership transfers offered to the user
+func (s *SubTransferService) GetMyTransfers(username string) ([]models.TransferResponse, error) {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := s.transferRepo.GetPendingTransfersForUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range transfers {
+		transfers[i].ToUsername = user.Username
+	}
+	return transfers, nil
+}
+
+// RespondToTransfer accepts or declines a pending ownership transfer (recipient only)
+func (s *SubTransferService) RespondToTransfer(transferID, username string, accept bool) error {
+	user, err := s.userRepo.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	transferIDUint, err := strconv.ParseUint(transferID, 10, 64)
+	if err != nil {
+		return errors.New("invalid transfer ID")
+	}
+
+	transfer, err := s.transferRepo.GetTransferByID(uint(transferIDUint))
+	if err != nil {
+		return err
+	}
+	if transfer.ToUserID != user.ID {
+		return errors.New("you are not the recipient of this transfer")
+	}
+	if transfer.Status != models.TransferStatusPending {
+		return errors.New("ownership transfer is no longer pending")
+	}
+
+	if !accept {
+		return s.transferRepo.UpdateTransferStatus(transfer, models.TransferStatusDeclined)
+	}
+	return s.transferRepo.CompleteTransfer(transfer)
+}
+
+func (s *SubTransferService) getSub(subID string) (*models.Sub, error) {
+	subIDUint, err := strconv.ParseUint(subID, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid sub ID")
+	}
+
+	return s.subRepo.GetSubByID(uint(subIDUint))
+}
+
+func formatTransfer(transfer models.SubOwnershipTransfer, subName, fromUsername, toUsername string) *models.TransferResponse {
+	return &models.TransferResponse{
+		ID:           transfer.ID,
+		SubID:        transfer.SubID,
+		SubName:      subName,
+		FromUsername: fromUsername,
+		ToUsername:   toUsername,
+		Status:       transfer.Status,
+		CreatedAt:    transfer.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CodeAndCraft-Online/cortex-api/internal/database"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubTransferService(t *testing.T) {
+	if database.DB == nil {
+		t.Skip("Database not available, skipping integration test")
+		return
+	}
+
+	// Clear tables to avoid conflicts
+	database.DB.Exec("DELETE FROM notifications")
+	database.DB.Exec("DELETE FROM sub_ownership_transfers")
+	database.DB.Exec("DELETE FROM sub_moderators")
+	database.DB.Exec("DELETE FROM sub_memberships")
+	database.DB.Exec("DELETE FROM subs")
+	database.DB.Exec("DELETE FROM users")
+
+	owner := models.User{Username: "transferowner", Password: "password"}
+	recipient := models.User{Username: "transferrecipient", Password: "password"}
+	other := models.User{Username: "transferother", Password: "password"}
+	database.DB.Create(&owner)
+	database.DB.Create(&recipient)
+	database.DB.Create(&other)
+
+	sub := models.Sub{Name: "transfersub", OwnerID: owner.ID}
+	database.DB.Create(&sub)
+
+	service := NewSubTransferService(repositories.NewSubTransferRepository(), repositories.NewSubSettingsRepository(), repositories.NewUserRepository(), repositories.NewNotificationRepository())
+	subID := fmt.Sprintf("%d", sub.ID)
+
+	t.Run("only the owner can transfer", func(t *testing.T) {
+		_, err := service.InitiateTransfer(subID, "transferother", models.TransferRequest{Username: "transferrecipient"})
+		assert.EqualError(t, err, "only the sub owner can transfer ownership")
+	})
+
+	t.Run("declined transfer keeps the owner", func(t *testing.T) {
+		transfer, err := service.InitiateTransfer(subID, "transferowner", models.TransferRequest{Username: "transferrecipient"})
+		assert.NoError(t, err)
+
+		_, err = service.InitiateTransfer(subID, "transferowner", models.TransferRequest{Username: "transferother"})
+		assert.EqualError(t, err, "an ownership transfer is already pending for this sub")
+
+		transferID := fmt.Sprintf("%d", transfer.ID)
+		assert.EqualError(t, service.RespondToTransfer(transferID, "transferother", true), "you are not the recipient of this transfer")
+		assert.NoError(t, service.RespondToTransfer(transferID, "transferrecipient", false))
+
+		var stored models.Sub
+		database.DB.First(&stored, sub.ID)
+		assert.Equal(t, owner.ID, stored.OwnerID)
+	})
+
+	t.Run("accepted transfer changes the owner", func(t *testing.T) {
+		transfer, err := service.InitiateTransfer(subID, "transferowner", models.TransferRequest{Username: "transferrecipient"})
+		assert.NoError(t, err)
+
+		transfers, err := service.GetMyTransfers("transferrecipient")
+		assert.NoError(t, err)
+		assert.Len(t, transfers, 1)
+		assert.Equal(t, "transferowner", transfers[0].FromUsername)
+
+		assert.NoError(t, service.RespondToTransfer(fmt.Sprintf("%d", transfer.ID), "transferrecipient", true))
+
+		var stored models.Sub
+		database.DB.First(&stored, sub.ID)
+		assert.Equal(t, recipient.ID, stored.OwnerID)
+
+		isModerator, err := repositories.NewSubSettingsRepository().IsModerator(sub.ID, owner.ID)
+		assert.NoError(t, err)
+		assert.True(t, isModerator)
+	})
+}
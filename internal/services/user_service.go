@@ -4,6 +4,7 @@ package services
 import (
 	"errors"
 	"regexp"
+	"strings"
 
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
 	"github.com/CodeAndCraft-Online/cortex-api/internal/models"
@@ -11,25 +12,44 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ValidateUsername rejects the names reserved for deleted accounts: the placeholder they are shown as and
+// the names purged accounts are renamed to
+func ValidateUsername(username string) error {
+	if username == models.DeletedPlaceholder || strings.HasPrefix(strings.ToLower(username), models.DeletedUsernamePrefix) {
+		return errors.New("this username is reserved")
+	}
+	return nil
+}
+
 // UserService handles user business logic
 type UserService struct {
-	userRepo repositories.IUserRepository
+	userRepo   repositories.IUserRepository
+	followRepo repositories.IFollowRepository
+	visibility *ProfileVisibilityPolicy
 }
 
 // NewUserService creates a new user service with dependency injection
-func NewUserService(userRepo repositories.IUserRepository) *UserService {
+func NewUserService(userRepo repositories.IUserRepository, followRepo repositories.IFollowRepository) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:   userRepo,
+		followRepo: followRepo,
+		visibility: NewProfileVisibilityPolicy(repositories.NewBlockRepository(), followRepo),
 	}
 }
 
-// GetUserProfile returns the publicly visible profile for a user
+// GetUserProfile returns a user's profile as the requesting user may see it; a nil requestingUserID is a
+// logged-out viewer. Sections a private profile hides are left empty and listed in Hidden.
 func (s *UserService) GetUserProfile(username string, requestingUserID *uint) (*models.UserResponse, error) {
 	user, err := s.userRepo.GetUserByUsername(username)
 	if err != nil {
 		return nil, err
 	}
 
+	visibility, err := s.visibility.For(user, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
 	response := &models.UserResponse{
 		ID:          user.ID,
 		Username:    user.Username,
@@ -37,7 +57,21 @@ func (s *UserService) GetUserProfile(username string, requestingUserID *uint) (*
 		Bio:         user.Bio,
 		AvatarURL:   user.AvatarURL,
 		IsPrivate:   user.IsPrivate,
-		CreatedAt:   user.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if hidden := visibility.Hidden(); len(hidden) > 0 {
+		response.Hidden = hidden
+	}
+
+	if visibility.JoinDate {
+		response.CreatedAt = user.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+	if visibility.Follows {
+		if response.FollowerCount, response.FollowingCount, err = s.followRepo.CountFollows(user.ID); err != nil {
+			return nil, err
+		}
+	}
+	if visibility.Karma {
+		response.PostKarma, response.CommentKarma, response.Karma = user.PostKarma, user.CommentKarma, user.Karma()
 	}
 
 	return response, nil
@@ -60,6 +94,9 @@ func (s *UserService) GetUserProfileInternal(userID uint) (*models.UserProfileRe
 		IsPrivate:   user.IsPrivate,
 		CreatedAt:   user.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:   user.UpdatedAt.Format("2006-01-02 15:04:05"),
+
+		NSFWContent:    user.NSFWContent,
+		SpoilerContent: user.SpoilerContent,
 	}
 
 	return response, nil
@@ -72,6 +109,17 @@ func (s *UserService) UpdateUserProfile(userID uint, updates models.UserUpdateRe
 		return nil, err
 	}
 
+	// An uploaded avatar replaces any avatar link, and only its uploader can use it
+	if updates.AvatarMediaID != nil {
+		user, err := s.userRepo.GetUserByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if updates.AvatarURL, err = resolveMediaURL(user.Username, updates.AvatarMediaID, updates.AvatarURL); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update the user
 	_, err := s.userRepo.UpdateUser(userID, updates)
 	if err != nil {
@@ -138,31 +186,43 @@ func (s *UserService) validateUserUpdates(userID uint, updates models.UserUpdate
 		}
 	}
 
+	if updates.NSFWContent != nil && !validContentSetting(*updates.NSFWContent) {
+		return errors.New("nsfw_content must be hide, blur or show")
+	}
+	if updates.SpoilerContent != nil && !validContentSetting(*updates.SpoilerContent) {
+		return errors.New("spoiler_content must be hide, blur or show")
+	}
+
 	return nil
 }
 
+// validContentSetting checks a setting for how sensitive content is shown
+func validContentSetting(setting string) bool {
+	return setting == models.ContentHide || setting == models.ContentBlur || setting == models.ContentShow
+}
+
 // Legacy global functions for backward compatibility
 func GetUserProfile(username string, requestingUserID *uint) (*models.UserResponse, error) {
-	service := NewUserService(repositories.NewUserRepository())
+	service := NewUserService(repositories.NewUserRepository(), repositories.NewFollowRepository())
 	return service.GetUserProfile(username, requestingUserID)
 }
 
 func GetUserProfileInternal(userID uint) (*models.UserProfileResponse, error) {
-	service := NewUserService(repositories.NewUserRepository())
+	service := NewUserService(repositories.NewUserRepository(), repositories.NewFollowRepository())
 	return service.GetUserProfileInternal(userID)
 }
 
 func UpdateUserProfile(userID uint, updates models.UserUpdateRequest) (*models.UserProfileResponse, error) {
-	service := NewUserService(repositories.NewUserRepository())
+	service := NewUserService(repositories.NewUserRepository(), repositories.NewFollowRepository())
 	return service.UpdateUserProfile(userID, updates)
 }
 
 func DeleteUserAccount(userID uint, password string) error {
-	service := NewUserService(repositories.NewUserRepository())
+	service := NewUserService(repositories.NewUserRepository(), repositories.NewFollowRepository())
 	return service.DeleteUserAccount(userID, password)
 }
 
 func ChangePassword(userID uint, currentPassword, newPassword string) error {
-	service := NewUserService(repositories.NewUserRepository())
+	service := NewUserService(repositories.NewUserRepository(), repositories.NewFollowRepository())
 	return service.ChangePassword(userID, currentPassword, newPassword)
 }
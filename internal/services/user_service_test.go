@@ -135,6 +135,16 @@ func TestUpdateUserProfile(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid email format")
 	})
 
+	t.Run("content settings", func(t *testing.T) {
+		result, err := UpdateUserProfile(user.ID, models.UserUpdateRequest{NSFWContent: stringPtr(models.ContentBlur)})
+		assert.NoError(t, err)
+		assert.Equal(t, models.ContentBlur, result.NSFWContent)
+		assert.Equal(t, models.ContentBlur, result.SpoilerContent)
+
+		_, err = UpdateUserProfile(user.ID, models.UserUpdateRequest{SpoilerContent: stringPtr("maybe")})
+		assert.EqualError(t, err, "spoiler_content must be hide, blur or show")
+	})
+
 	t.Run("duplicate email", func(t *testing.T) {
 		// Create another user with different email first
 		user2 := models.User{
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when a blob doesn't exist in the store
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores uploaded files by key. Keys are generated by the server and use "/" as a separator.
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Storage backends selectable with MEDIA_STORAGE
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+var (
+	defaultStore    BlobStore
+	defaultStoreErr error
+	defaultOnce     sync.Once
+)
+
+// Default returns the store configured by the environment, creating it on first use
+func Default() (BlobStore, error) {
+	defaultOnce.Do(func() {
+		defaultStore, defaultStoreErr = NewFromEnv()
+	})
+	return defaultStore, defaultStoreErr
+}
+
+// NewFromEnv creates the store selected by MEDIA_STORAGE: "local" (the default) keeps files under
+// MEDIA_LOCAL_DIR, and "s3" uses the bucket described by the S3_* variables
+func NewFromEnv() (BlobStore, error) {
+	switch backend := strings.ToLower(strings.TrimSpace(os.Getenv("MEDIA_STORAGE"))); backend {
+	case "", BackendLocal:
+		dir := os.Getenv("MEDIA_LOCAL_DIR")
+		if dir == "" {
+			dir = "./uploads"
+		}
+		return NewLocalStore(dir), nil
+	case BackendS3:
+		return NewS3Store(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			Region:          os.Getenv("S3_REGION"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown media storage backend %q", backend)
+	}
+}
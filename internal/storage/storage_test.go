@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir())
+
+	t.Run("put, get and delete", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "media/ab/abcdef.png", "image/png", strings.NewReader("png bytes"), 9))
+
+		body, err := store.Get(ctx, "media/ab/abcdef.png")
+		require.NoError(t, err)
+		data, err := io.ReadAll(body)
+		body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "png bytes", string(data))
+
+		require.NoError(t, store.Delete(ctx, "media/ab/abcdef.png"))
+		_, err = store.Get(ctx, "media/ab/abcdef.png")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("put replaces an existing blob", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "replace.txt", "text/plain", strings.NewReader("old"), 3))
+		require.NoError(t, store.Put(ctx, "replace.txt", "text/plain", strings.NewReader("new"), 3))
+
+		body, err := store.Get(ctx, "replace.txt")
+		require.NoError(t, err)
+		defer body.Close()
+		data, _ := io.ReadAll(body)
+		assert.Equal(t, "new", string(data))
+	})
+
+	t.Run("deleting a missing blob succeeds", func(t *testing.T) {
+		assert.NoError(t, store.Delete(ctx, "missing.png"))
+	})
+
+	t.Run("keys can't escape the root", func(t *testing.T) {
+		assert.Error(t, store.Put(ctx, "../outside.png", "image/png", strings.NewReader("x"), 1))
+		_, err := store.Get(ctx, "media/../../outside.png")
+		assert.Error(t, err)
+		assert.Error(t, store.Delete(ctx, ""))
+	})
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Run("local by default", func(t *testing.T) {
+		t.Setenv("MEDIA_STORAGE", "")
+		t.Setenv("MEDIA_LOCAL_DIR", t.TempDir())
+
+		store, err := NewFromEnv()
+		require.NoError(t, err)
+		assert.IsType(t, &LocalStore{}, store)
+	})
+
+	t.Run("s3 needs a bucket", func(t *testing.T) {
+		t.Setenv("MEDIA_STORAGE", "s3")
+		t.Setenv("S3_ENDPOINT", "http://localhost:9000")
+		t.Setenv("S3_BUCKET", "")
+
+		_, err := NewFromEnv()
+		assert.Error(t, err)
+	})
+
+	t.Run("s3", func(t *testing.T) {
+		t.Setenv("MEDIA_STORAGE", "S3")
+		t.Setenv("S3_ENDPOINT", "http://localhost:9000")
+		t.Setenv("S3_BUCKET", "cortex")
+		t.Setenv("S3_ACCESS_KEY_ID", "key")
+		t.Setenv("S3_SECRET_ACCESS_KEY", "secret")
+
+		store, err := NewFromEnv()
+		require.NoError(t, err)
+		assert.IsType(t, &S3Store{}, store)
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		t.Setenv("MEDIA_STORAGE", "ftp")
+
+		_, err := NewFromEnv()
+		assert.Error(t, err)
+	})
+}
@@ -45,7 +45,7 @@ func SetupTestDB() (*gorm.DB, func(), error) {
 		database.DB = db
 
 		// Auto-migrate test database
-		err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.Comment{}, &models.Vote{}, &models.SubInvitation{}, &models.Sub{}, &models.SubMembership{}, &models.PasswordResetToken{})
+		err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.Comment{}, &models.Vote{}, &models.SubInvitation{}, &models.Sub{}, &models.SubMembership{}, &models.PasswordResetToken{}, &models.SubRule{}, &models.SubApprovedSubmitter{}, &models.SubInviteLink{}, &models.SubModerator{}, &models.SubJoinRequest{}, &models.Notification{}, &models.SubOwnershipTransfer{}, &models.SubFlair{}, &models.UserFollow{}, &models.UserBlock{}, &models.Conversation{}, &models.ConversationParticipant{}, &models.Message{}, &models.NotificationPreference{}, &models.Mention{}, &models.Revision{}, &models.SavedItem{}, &models.HiddenPost{}, &models.Media{}, &models.MediaRendition{}, &models.LinkPreview{}, &models.Poll{}, &models.PollOption{}, &models.PollVote{})
 		if err != nil {
 			return nil, nil, err
 		}
@@ -107,7 +107,7 @@ func SetupTestDB() (*gorm.DB, func(), error) {
 	}
 
 	// Auto-migrate test database
-	err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.Comment{}, &models.Vote{}, &models.SubInvitation{}, &models.Sub{}, &models.SubMembership{}, &models.PasswordResetToken{})
+	err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.Comment{}, &models.Vote{}, &models.SubInvitation{}, &models.Sub{}, &models.SubMembership{}, &models.PasswordResetToken{}, &models.SubRule{}, &models.SubApprovedSubmitter{}, &models.SubInviteLink{}, &models.SubModerator{}, &models.SubJoinRequest{}, &models.Notification{}, &models.SubOwnershipTransfer{}, &models.SubFlair{}, &models.UserFollow{}, &models.UserBlock{}, &models.Conversation{}, &models.ConversationParticipant{}, &models.Message{}, &models.NotificationPreference{}, &models.Mention{}, &models.Revision{}, &models.SavedItem{}, &models.HiddenPost{}, &models.Media{}, &models.MediaRendition{}, &models.LinkPreview{}, &models.Poll{}, &models.PollOption{}, &models.PollVote{})
 	if err != nil {
 		log.Printf("Failed to migrate test database: %s", err)
 		return nil, nil, err
@@ -0,0 +1,215 @@
+// Package unfurl fetches the OpenGraph title, description and thumbnail of a linked page
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// Timeout bounds a whole unfurl, including redirects and reading the page
+	Timeout = 5 * time.Second
+	// maxPageBytes is how much of a page is read looking for its metadata, which belongs in the <head>
+	maxPageBytes = 512 << 10
+
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+	maxURLLength         = 2048
+	userAgent            = "CortexBot/1.0 (+link previews)"
+)
+
+// HTTPClient sends the requests used to unfurl links; *http.Client satisfies it
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Preview is the metadata found on a linked page. Any of the fields may be empty.
+type Preview struct {
+	Title        string
+	Description  string
+	SiteName     string
+	ThumbnailURL string
+}
+
+// Unfurler fetches link previews through an HTTP client
+type Unfurler struct {
+	client HTTPClient
+}
+
+// New creates an unfurler that fetches pages through the given client. Use NewClient for links submitted by
+// users, so they can't be pointed at the server's own network.
+func New(client HTTPClient) *Unfurler {
+	return &Unfurler{client: client}
+}
+
+// ParseURL checks that a link is an absolute http or https URL that doesn't point at a private address
+func ParseURL(rawURL string) (*url.URL, error) {
+	u, err := parseHTTPURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if blockedHost(u.Hostname()) {
+		return nil, ErrBlockedAddress
+	}
+	return u, nil
+}
+
+// parseHTTPURL checks that a link is an absolute http or https URL
+func parseHTTPURL(rawURL string) (*url.URL, error) {
+	if len(rawURL) > maxURLLength {
+		return nil, errors.New("unfurl: URL is too long")
+	}
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("unfurl: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return nil, errors.New("unfurl: only absolute http and https URLs can be unfurled")
+	}
+	return u, nil
+}
+
+// Unfurl fetches a page and reads its preview from the OpenGraph tags, falling back to the Twitter card tags,
+// the <title> and the meta description. Links to anything other than HTML have an empty preview. Which
+// addresses can be reached is up to the client.
+func (u *Unfurler) Unfurl(ctx context.Context, rawURL string) (*Preview, error) {
+	target, err := parseHTTPURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unfurl: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.1")
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unfurl: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unfurl: %s returned status %d", target.Host, resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return &Preview{}, nil
+	}
+
+	// Relative thumbnails are resolved against the page the redirects ended on
+	base := target
+	if resp.Request != nil && resp.Request.URL != nil {
+		base = resp.Request.URL
+	}
+	return parse(io.LimitReader(resp.Body, maxPageBytes), base), nil
+}
+
+// parse reads the preview metadata from the head of an HTML page
+func parse(r io.Reader, base *url.URL) *Preview {
+	meta := map[string]string{}
+	var title string
+	inTitle := false
+
+	tokenizer := html.NewTokenizer(r)
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch token.DataAtom {
+			case atom.Body:
+				return buildPreview(meta, title, base)
+			case atom.Title:
+				inTitle = tokenType == html.StartTagToken
+			case atom.Meta:
+				var key, content string
+				for _, attr := range token.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "property", "name":
+						if key == "" {
+							key = strings.ToLower(strings.TrimSpace(attr.Val))
+						}
+					case "content":
+						content = strings.TrimSpace(attr.Val)
+					}
+				}
+				// The first value of each tag wins, as it does for crawlers
+				if _, seen := meta[key]; key != "" && content != "" && !seen {
+					meta[key] = content
+				}
+			}
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = strings.TrimSpace(token.Data)
+			}
+		case html.EndTagToken:
+			switch token.DataAtom {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				return buildPreview(meta, title, base)
+			}
+		}
+	}
+	return buildPreview(meta, title, base)
+}
+
+// buildPreview picks the best value for each field from the tags found on a page
+func buildPreview(meta map[string]string, title string, base *url.URL) *Preview {
+	preview := &Preview{
+		Title:       truncate(firstOf(meta["og:title"], meta["twitter:title"], title), maxTitleLength),
+		Description: truncate(firstOf(meta["og:description"], meta["twitter:description"], meta["description"]), maxDescriptionLength),
+		SiteName:    truncate(meta["og:site_name"], maxTitleLength),
+	}
+
+	thumbnail := firstOf(meta["og:image:secure_url"], meta["og:image"], meta["og:image:url"], meta["twitter:image"], meta["twitter:image:src"])
+	if thumbnail != "" {
+		if ref, err := url.Parse(thumbnail); err == nil {
+			resolved := base.ResolveReference(ref)
+			if (resolved.Scheme == "http" || resolved.Scheme == "https") && len(resolved.String()) <= maxURLLength {
+				preview.ThumbnailURL = resolved.String()
+			}
+		}
+	}
+	return preview
+}
+
+// firstOf returns the first non-empty value
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// truncate shortens text to at most max characters, collapsing runs of whitespace
+func truncate(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	return strings.TrimSpace(string([]rune(text)[:max-1])) + "…"
+}
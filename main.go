@@ -24,6 +24,7 @@ import (
 	_ "github.com/CodeAndCraft-Online/cortex-api/docs"
 	db "github.com/CodeAndCraft-Online/cortex-api/internal/database"
 	routes "github.com/CodeAndCraft-Online/cortex-api/internal/routes"
+	"github.com/CodeAndCraft-Online/cortex-api/internal/services"
 	pkg "github.com/CodeAndCraft-Online/cortex-api/pkg"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -33,6 +34,10 @@ import (
 func main() {
 
 	db.InitDB()
+	go services.ProcessMediaUploads()
+	go services.UnfurlLinks()
+	go services.PublishScheduledPosts()
+	go services.ReconcileKarma()
 	router := gin.Default()
 
 	// ✅ Apply rate limiter: 100 requests per minute per IP
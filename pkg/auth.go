@@ -67,3 +67,27 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware stores the username from a valid JWT token like AuthMiddleware, but lets requests
+// without one through as logged out instead of rejecting them
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenParts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+			c.Next()
+			return
+		}
+
+		token, err := jwt.Parse(tokenParts[1], func(token *jwt.Token) (interface{}, error) {
+			return []byte(os.Getenv("JWT_SECRET")), nil
+		})
+		if err == nil && token.Valid {
+			if claims, ok := token.Claims.(jwt.MapClaims); ok {
+				if username, exists := claims["username"].(string); exists {
+					c.Set("username", username)
+				}
+			}
+		}
+		c.Next()
+	}
+}
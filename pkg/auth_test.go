@@ -89,3 +89,44 @@ func setupTestRouter() *gin.Engine {
 	})
 	return router
 }
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(OptionalAuthMiddleware())
+	router.GET("/public", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"username": c.GetString("username")})
+	})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"username": "testuser",
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	})
+	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		header   string
+		username string
+	}{
+		{"no token", "", ""},
+		{"invalid format", "InvalidFormat", ""},
+		{"invalid token", "Bearer invalid.token.here", ""},
+		{"valid token", "Bearer " + tokenString, "testuser"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/public", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, `{"username":"`+tt.username+`"}`, w.Body.String())
+		})
+	}
+}